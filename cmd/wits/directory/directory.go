@@ -0,0 +1,126 @@
+package directory
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"strings"
+
+	can "github.com/TheDonDope/wits-tui/pkg/cannabis"
+	"github.com/TheDonDope/wits-tui/pkg/i18n"
+	"github.com/TheDonDope/wits-tui/pkg/service"
+	"github.com/TheDonDope/wits-tui/pkg/settings"
+	"github.com/TheDonDope/wits-tui/pkg/storage"
+	"github.com/spf13/cobra"
+)
+
+// Command is the directory command.
+var Command = &cobra.Command{
+	Use:   "directory",
+	Short: "Manage the directory of manufacturers and pharmacies",
+}
+
+// listCommand lists the manufacturers and pharmacies.
+var listCommand = &cobra.Command{
+	Use:   "list",
+	Short: "List the manufacturers and pharmacies",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		if err := useLocale(); err != nil {
+			return err
+		}
+		svc := newDirectoryService()
+		renderDirectory(cmd.OutOrStdout(), svc.GetManufacturers(), svc.GetPharmacies())
+		return nil
+	},
+}
+
+// migrateCommand maps the free text manufacturers, countries and pharmacies of
+// the strains onto the directory.
+var migrateCommand = &cobra.Command{
+	Use:   "migrate",
+	Short: "Map the manufacturers, countries and pharmacies of the strains onto the directory",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		if err := useLocale(); err != nil {
+			return err
+		}
+		strains := service.NewStrainService(storage.NewStrainStore())
+		result, err := newDirectoryService().Migrate(strains.GetStrains())
+		if err != nil {
+			log.Printf("🚨 🖥️  (cmd/wits/directory/directory.go) ❓ 🗒️  Error migrating directory: %v \n", err)
+			return fmt.Errorf("migrate directory: %w", err)
+		}
+		for _, s := range result.Strains {
+			if err := strains.UpdateStrain(s); err != nil {
+				return fmt.Errorf("update strain %q: %w", s.Strain, err)
+			}
+		}
+		renderMigration(cmd.OutOrStdout(), result)
+		return nil
+	},
+}
+
+func init() {
+	Command.AddCommand(listCommand)
+	Command.AddCommand(migrateCommand)
+}
+
+// useLocale loads the settings and translates the output to the configured
+// locale.
+func useLocale() error {
+	s, err := settings.Load()
+	if err != nil {
+		log.Printf("🚨 🖥️  (cmd/wits/directory/directory.go) ❓ 🗒️  Error loading settings: %v \n", err)
+		return err
+	}
+	return i18n.UseLocale(i18n.Locale(s.Locale))
+}
+
+// newDirectoryService returns a directory service accessing the configured
+// stores.
+func newDirectoryService() service.DirectoryService {
+	return service.NewDirectoryService(storage.NewManufacturerStore(), storage.NewPharmacyStore())
+}
+
+// renderDirectory writes the given manufacturers and pharmacies with their
+// aliases and countries to the given writer.
+func renderDirectory(w io.Writer, manufacturers []*can.Manufacturer, pharmacies []*can.Pharmacy) {
+	fmt.Fprintln(w, i18n.T("Manufacturers"))
+	if len(manufacturers) == 0 {
+		fmt.Fprintln(w, "  "+i18n.T("No manufacturers."))
+	}
+	for _, m := range manufacturers {
+		fmt.Fprintln(w, "  "+entryLine(m.Name, m.Aliases, m.Country))
+	}
+	fmt.Fprintln(w, i18n.T("Pharmacies"))
+	if len(pharmacies) == 0 {
+		fmt.Fprintln(w, "  "+i18n.T("No pharmacies."))
+	}
+	for _, p := range pharmacies {
+		fmt.Fprintln(w, "  "+entryLine(p.Name, p.Aliases, p.Country))
+	}
+}
+
+// entryLine returns the line of a directory entry, e.g.
+// `Aurora [CA] (aka Aurora Cannabis)`.
+func entryLine(name string, aliases []string, country string) string {
+	line := name
+	if country != "" {
+		line += " [" + country + "]"
+	}
+	if len(aliases) > 0 {
+		line += " (" + i18n.T("aka %s", strings.Join(aliases, ", ")) + ")"
+	}
+	return line
+}
+
+// renderMigration writes the result of a directory migration to the given
+// writer.
+func renderMigration(w io.Writer, result *service.DirectoryMigration) {
+	fmt.Fprintln(w, i18n.T("Updated %d strains, added %d manufacturers and %d pharmacies.",
+		len(result.Strains), result.Manufacturers, result.Pharmacies))
+	if len(result.UnknownCountries) > 0 {
+		fmt.Fprintln(w, i18n.T("Unknown countries: %s", strings.Join(result.UnknownCountries, ", ")))
+	}
+}
@@ -0,0 +1,3 @@
+// Package directory provides the commands to manage the directory of
+// manufacturers and pharmacies
+package directory // import "github.com/TheDonDope/wits-tui/cmd/wits/directory"
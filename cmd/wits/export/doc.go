@@ -0,0 +1,2 @@
+// Package export provides the commands to export the data of Wits to other applications
+package export // import "github.com/TheDonDope/wits-tui/cmd/wits/export"
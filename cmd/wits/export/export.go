@@ -0,0 +1,65 @@
+package export
+
+import (
+	"log"
+	"os"
+	"time"
+
+	"github.com/TheDonDope/wits-tui/pkg/i18n"
+	"github.com/TheDonDope/wits-tui/pkg/service"
+	"github.com/TheDonDope/wits-tui/pkg/settings"
+	"github.com/TheDonDope/wits-tui/pkg/storage"
+	"github.com/spf13/cobra"
+)
+
+var output string
+
+// Command is the export command.
+var Command = &cobra.Command{
+	Use:   "export",
+	Short: "Export the data to other applications",
+}
+
+// icsCommand exports the sessions, reminders and prescription expiries as an
+// iCalendar file.
+var icsCommand = &cobra.Command{
+	Use:   "ics",
+	Short: "Export the sessions, reminders and prescription expiries as an iCalendar (.ics) file",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		s, err := settings.Load()
+		if err != nil {
+			log.Printf("🚨 🖥️  (cmd/wits/export/export.go) ❓ 🗒️  Error loading settings: %v \n", err)
+			return err
+		}
+		// The reminders are described like their notifications
+		if err := i18n.UseLocale(i18n.Locale(s.Locale)); err != nil {
+			return err
+		}
+		now := time.Now()
+		strainStore := storage.NewStrainStore()
+		c := service.Calendar(
+			service.NewSessionService(storage.NewSessionStore(), strainStore).GetSessions(),
+			service.NewReminderService(storage.NewReminderStore()).GetReminders(),
+			service.NewPrescriptionService(storage.NewPrescriptionStore()).GetPrescriptions(),
+			now)
+		if output == "" || output == "-" {
+			return c.Encode(cmd.OutOrStdout(), now)
+		}
+		f, err := os.Create(output)
+		if err != nil {
+			log.Printf("🚨 🖥️  (cmd/wits/export/export.go) ❓ 🗒️  Error creating the calendar file: %v \n", err)
+			return err
+		}
+		if err := c.Encode(f, now); err != nil {
+			f.Close()
+			return err
+		}
+		return f.Close()
+	},
+}
+
+func init() {
+	icsCommand.Flags().StringVarP(&output, "output", "o", "", "the file to write the calendar to, standard output if none is given")
+	Command.AddCommand(icsCommand)
+}
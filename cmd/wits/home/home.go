@@ -1,8 +1,10 @@
 package home
 
 import (
+	"context"
 	"log"
 
+	"github.com/TheDonDope/wits-tui/pkg/settings"
 	"github.com/TheDonDope/wits-tui/pkg/tui"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/spf13/cobra"
@@ -14,7 +16,21 @@ var Command = &cobra.Command{
 	Short: "Launch the main menu",
 	Args:  cobra.NoArgs,
 	RunE: func(_ *cobra.Command, _ []string) error {
-		_, err := tea.NewProgram(tui.InitialMenuModel(), tea.WithAltScreen()).Run()
+		s, err := settings.Load()
+		if err != nil {
+			log.Printf("🚨 🖥️  (cmd/wits/home/home.go) ❓ 🗒️  Error loading settings: %v \n", err)
+			return err
+		}
+		if err := tui.Configure(s); err != nil {
+			log.Printf("🚨 🖥️  (cmd/wits/home/home.go) ❓ 🗒️  Error applying settings: %v \n", err)
+			return err
+		}
+		tui.MigrateStrains()
+		p := tea.NewProgram(tui.InitialMenuModel(), tea.WithAltScreen())
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go tui.WatchReminders(ctx, p.Send)
+		_, err = p.Run()
 		if err != nil {
 			log.Fatalf("🚨 🖥️  (cmd/wits/main.go) ❓ 🗒️  Error starting program: %v \n", err)
 			return err
@@ -8,7 +8,15 @@ import (
 	"os"
 	"runtime/debug"
 
+	"github.com/TheDonDope/wits-tui/cmd/wits/directory"
+	"github.com/TheDonDope/wits-tui/cmd/wits/export"
 	"github.com/TheDonDope/wits-tui/cmd/wits/home"
+	"github.com/TheDonDope/wits-tui/cmd/wits/reminders"
+	"github.com/TheDonDope/wits-tui/cmd/wits/report"
+	"github.com/TheDonDope/wits-tui/cmd/wits/spend"
+	"github.com/TheDonDope/wits-tui/cmd/wits/stock"
+	"github.com/TheDonDope/wits-tui/cmd/wits/strain"
+	"github.com/TheDonDope/wits-tui/pkg/cannabis"
 	"github.com/TheDonDope/wits-tui/pkg/version"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/joho/godotenv"
@@ -41,6 +49,13 @@ var (
 
 func init() {
 	rootCmd.CompletionOptions.HiddenDefaultCmd = true
+	rootCmd.AddCommand(directory.Command)
+	rootCmd.AddCommand(export.Command)
+	rootCmd.AddCommand(reminders.Command)
+	rootCmd.AddCommand(report.Command)
+	rootCmd.AddCommand(spend.Command)
+	rootCmd.AddCommand(stock.Command)
+	rootCmd.AddCommand(strain.Command)
 
 	if len(CommitSHA) >= 7 {
 		vt := rootCmd.VersionTemplate()
@@ -65,6 +80,7 @@ func main() {
 	ctx := context.Background()
 	loadEnvironment()
 	ensureWitsFolders()
+	loadCatalog()
 	f, err := tea.LogToFile(fmt.Sprintf("%s/%s/%s", os.Getenv("WITS_DIR"), os.Getenv("LOG_DIR"), os.Getenv("LOG_FILE")), "debug")
 	if err != nil {
 		log.Fatalf("🚨 🖥️  (cmd/wits/main.go) ❓ 🗒️  Failed setting the debug log file: %v \n", err)
@@ -83,6 +99,13 @@ func loadEnvironment() {
 	log.Println("✅ 🖥️  (cmd/wits/main.go) loadEnvironment()")
 }
 
+func loadCatalog() {
+	if err := cannabis.LoadCatalog(os.Getenv("WITS_DIR")); err != nil {
+		log.Fatalf("🚨 🖥️  (cmd/wits/main.go) ❓ 🗒️  Failed to load the catalog: %v \n", err)
+	}
+	log.Println("✅ 🖥️  (cmd/wits/main.go) loadCatalog()")
+}
+
 func ensureWitsFolders() error {
 	log.Println("✅ 🖥️  (cmd/wits/main.go) ensureWitsFolders()")
 	return os.MkdirAll(fmt.Sprintf("%s/%s", os.Getenv("WITS_DIR"), os.Getenv("LOG_DIR")), os.ModePerm)
@@ -0,0 +1,2 @@
+// Package reminders provides the commands to schedule and notify reminders
+package reminders // import "github.com/TheDonDope/wits-tui/cmd/wits/reminders"
@@ -0,0 +1,205 @@
+package reminders
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"strings"
+	"time"
+
+	can "github.com/TheDonDope/wits-tui/pkg/cannabis"
+	"github.com/TheDonDope/wits-tui/pkg/i18n"
+	"github.com/TheDonDope/wits-tui/pkg/notify"
+	"github.com/TheDonDope/wits-tui/pkg/service"
+	"github.com/TheDonDope/wits-tui/pkg/settings"
+	"github.com/TheDonDope/wits-tui/pkg/storage"
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+)
+
+var (
+	dryRun   bool
+	kind     string
+	times    []string
+	weekdays []string
+	strain   string
+	grams    float64
+	leadDays int
+	notes    string
+)
+
+// Command is the reminders command.
+var Command = &cobra.Command{
+	Use:   "reminders",
+	Short: "Schedule reminders and notify the due ones",
+}
+
+// dueCommand notifies the due reminders, e.g. from a cron job or systemd
+// timer.
+var dueCommand = &cobra.Command{
+	Use:   "due",
+	Short: "Notify the due reminders through the configured notifiers",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		s, err := settings.Load()
+		if err != nil {
+			log.Printf("🚨 🖥️  (cmd/wits/reminders/reminders.go) ❓ 🗒️  Error loading settings: %v \n", err)
+			return err
+		}
+		// The notifications are translated like in the tui
+		if err := i18n.UseLocale(i18n.Locale(s.Locale)); err != nil {
+			return err
+		}
+		now := time.Now()
+		strainStore := storage.NewStrainStore()
+		c := service.GatherReminderConditions(
+			service.NewStrainService(strainStore).GetStrains(),
+			service.NewSessionService(storage.NewSessionStore(), strainStore).GetSessions(),
+			service.NewPrescriptionService(storage.NewPrescriptionStore()),
+			service.NewBreakService(storage.NewBreakStore()),
+			now,
+			service.StockThresholds{LowGrams: s.Stock.LowGrams, LowDays: s.Stock.LowDays, UsageWindow: s.Stock.UsageWindowDays})
+		svc := service.NewReminderService(storage.NewReminderStore())
+		due := svc.Due(c, now)
+		if dryRun {
+			for _, d := range due {
+				n := service.ReminderNotification(d)
+				fmt.Fprintf(cmd.OutOrStdout(), "%s: %s\n", n.Title, n.Body)
+			}
+			return nil
+		}
+		n := notify.New(s.Reminders, cmd.OutOrStdout(), true)
+		for _, d := range due {
+			if err := n.Notify(service.ReminderNotification(d)); err != nil {
+				log.Printf("🚨 🖥️  (cmd/wits/reminders/reminders.go) ❓ 🗒️  Error notifying reminder: %v \n", err)
+			}
+		}
+		return svc.MarkNotified(due, now)
+	},
+}
+
+// listCommand lists the scheduled reminders.
+var listCommand = &cobra.Command{
+	Use:   "list",
+	Short: "List the scheduled reminders",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		render(cmd.OutOrStdout(), service.NewReminderService(storage.NewReminderStore()).GetReminders())
+		return nil
+	},
+}
+
+// addCommand schedules a new reminder.
+var addCommand = &cobra.Command{
+	Use:   "add",
+	Short: "Schedule a reminder",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		k, err := can.ParseReminderKind(kind)
+		if err != nil {
+			return err
+		}
+		for _, t := range times {
+			if _, _, err := can.ParseTimeOfDay(t); err != nil {
+				return err
+			}
+		}
+		days, err := parseWeekdays(weekdays)
+		if err != nil {
+			return err
+		}
+		r := &can.Reminder{
+			ID:        uuid.New(),
+			Kind:      k,
+			Times:     times,
+			Weekdays:  days,
+			Strain:    strain,
+			Grams:     grams,
+			LeadDays:  leadDays,
+			Notes:     notes,
+			CreatedAt: time.Now(),
+		}
+		if err := service.NewReminderService(storage.NewReminderStore()).AddReminder(r); err != nil {
+			return err
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "Scheduled reminder %s\n", r.ID)
+		return nil
+	},
+}
+
+// removeCommand removes a scheduled reminder.
+var removeCommand = &cobra.Command{
+	Use:   "remove <id>",
+	Short: "Remove the reminder with the given ID",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(_ *cobra.Command, args []string) error {
+		id, err := uuid.Parse(args[0])
+		if err != nil {
+			return fmt.Errorf("reminder %q: %w", args[0], err)
+		}
+		return service.NewReminderService(storage.NewReminderStore()).RemoveReminder(id)
+	},
+}
+
+func init() {
+	dueCommand.Flags().BoolVar(&dryRun, "dry-run", false, "print the due reminders without notifying them")
+	addCommand.Flags().StringVar(&kind, "kind", string(can.DoseReminder), "the kind of the reminder (dose, refill, reorder, check-in)")
+	addCommand.Flags().StringSliceVar(&times, "at", []string{"08:00"}, "the times of day (HH:MM)")
+	addCommand.Flags().StringSliceVar(&weekdays, "weekday", nil, "the weekdays (e.g. mon), every day if none are given")
+	addCommand.Flags().StringVar(&strain, "strain", "", "the strain to dose or reorder")
+	addCommand.Flags().Float64Var(&grams, "grams", 0, "the amount of a dose in grams")
+	addCommand.Flags().IntVar(&leadDays, "lead-days", 0, "the days ahead to refill or reorder")
+	addCommand.Flags().StringVar(&notes, "notes", "", "notes shown with the reminder")
+	Command.AddCommand(dueCommand)
+	Command.AddCommand(listCommand)
+	Command.AddCommand(addCommand)
+	Command.AddCommand(removeCommand)
+}
+
+// parseWeekdays parses the given weekdays by the first three letters of their
+// english names.
+func parseWeekdays(names []string) ([]time.Weekday, error) {
+	var days []time.Weekday
+	for _, name := range names {
+		found := false
+		for d := time.Sunday; d <= time.Saturday; d++ {
+			if strings.EqualFold(name, d.String()[:3]) || strings.EqualFold(name, d.String()) {
+				days = append(days, d)
+				found = true
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("unknown weekday %q", name)
+		}
+	}
+	return days, nil
+}
+
+// render writes the given reminders to the given writer.
+func render(w io.Writer, reminders []*can.Reminder) {
+	if len(reminders) == 0 {
+		fmt.Fprintln(w, "No reminders scheduled.")
+		return
+	}
+	for _, r := range reminders {
+		schedule := strings.Join(r.Times, ", ")
+		if len(r.Weekdays) > 0 {
+			days := make([]string, len(r.Weekdays))
+			for i, d := range r.Weekdays {
+				days[i] = d.String()[:3]
+			}
+			schedule += " on " + strings.Join(days, ", ")
+		}
+		fmt.Fprintf(w, "%s  %s at %s", r.ID, r.Kind, schedule)
+		if r.Strain != "" {
+			fmt.Fprintf(w, ", %s", r.Strain)
+		}
+		if r.Grams > 0 {
+			fmt.Fprintf(w, ", %s g", i18n.FormatFloat(r.Grams, 2))
+		}
+		if r.LeadDays > 0 {
+			fmt.Fprintf(w, ", %d days ahead", r.LeadDays)
+		}
+		fmt.Fprintln(w)
+	}
+}
@@ -0,0 +1,2 @@
+// Package report provides the command to generate a consumption report for a doctor's visit
+package report // import "github.com/TheDonDope/wits-tui/cmd/wits/report"
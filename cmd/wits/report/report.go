@@ -0,0 +1,95 @@
+package report
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/TheDonDope/wits-tui/pkg/report"
+	"github.com/TheDonDope/wits-tui/pkg/service"
+	"github.com/TheDonDope/wits-tui/pkg/storage"
+	"github.com/spf13/cobra"
+)
+
+// defaultDays is the number of days up to today a report covers, if no start
+// is given.
+const defaultDays = 30
+
+var (
+	from   string
+	to     string
+	format string
+	output string
+)
+
+// Command is the report command.
+var Command = &cobra.Command{
+	Use:   "report",
+	Short: "Summarize the consumption, symptoms and prescriptions for a doctor's visit",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		f, err := report.ParseFormat(format)
+		if err != nil {
+			return err
+		}
+		start, end, err := parseRange(from, to, time.Now())
+		if err != nil {
+			return err
+		}
+		strainStore := storage.NewStrainStore()
+		r := service.BuildReport(
+			service.NewSessionService(storage.NewSessionStore(), strainStore).GetSessions(),
+			service.NewStrainService(strainStore).GetStrains(),
+			service.NewPrescriptionService(storage.NewPrescriptionStore()).GetPrescriptions(),
+			start, end)
+		dir := filepath.Join(os.Getenv("WITS_DIR"), report.TemplatesDir)
+		if output == "" || output == "-" {
+			return report.Render(cmd.OutOrStdout(), f, r, dir)
+		}
+		file, err := os.Create(output)
+		if err != nil {
+			log.Printf("🚨 🖥️  (cmd/wits/report/report.go) ❓ 🗒️  Error creating the report file: %v \n", err)
+			return err
+		}
+		if err := report.Render(file, f, r, dir); err != nil {
+			file.Close()
+			return err
+		}
+		return file.Close()
+	},
+}
+
+func init() {
+	Command.Flags().StringVar(&from, "from", "", fmt.Sprintf("the first day to summarize (YYYY-MM-DD), %d days before the last one if not given", defaultDays-1))
+	Command.Flags().StringVar(&to, "to", "", "the last day to summarize (YYYY-MM-DD), today if not given")
+	Command.Flags().StringVar(&format, "format", string(report.Markdown), "the format of the report (md, html)")
+	Command.Flags().StringVarP(&output, "output", "o", "", "the file to write the report to, standard output if none is given")
+}
+
+// parseRange returns the time range [start, end) covering the given days. The
+// range ends with the given time's day and spans defaultDays if the days are
+// not given.
+func parseRange(from, to string, now time.Time) (time.Time, time.Time, error) {
+	end := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local).AddDate(0, 0, 1)
+	if to != "" {
+		t, err := time.ParseInLocation(time.DateOnly, to, time.Local)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid --to day %q, expected YYYY-MM-DD", to)
+		}
+		end = t.AddDate(0, 0, 1)
+	}
+	start := end.AddDate(0, 0, -defaultDays)
+	if from != "" {
+		t, err := time.ParseInLocation(time.DateOnly, from, time.Local)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid --from day %q, expected YYYY-MM-DD", from)
+		}
+		start = t
+	}
+	if !start.Before(end) {
+		return time.Time{}, time.Time{}, fmt.Errorf("--from %s is after --to %s", start.Format(time.DateOnly), end.AddDate(0, 0, -1).Format(time.DateOnly))
+	}
+	return start, end, nil
+}
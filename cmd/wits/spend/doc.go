@@ -0,0 +1,2 @@
+// Package spend provides the command to summarize the spending on purchases
+package spend // import "github.com/TheDonDope/wits-tui/cmd/wits/spend"
@@ -0,0 +1,96 @@
+package spend
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"time"
+
+	"github.com/TheDonDope/wits-tui/pkg/i18n"
+	"github.com/TheDonDope/wits-tui/pkg/service"
+	"github.com/TheDonDope/wits-tui/pkg/settings"
+	"github.com/TheDonDope/wits-tui/pkg/storage"
+	"github.com/spf13/cobra"
+)
+
+const monthLayout = "2006-01"
+
+var (
+	from string
+	to   string
+)
+
+// Command is the spend command.
+var Command = &cobra.Command{
+	Use:   "spend",
+	Short: "Summarize the monthly spending by manufacturer and pharmacy",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		s, err := settings.Load()
+		if err != nil {
+			log.Printf("🚨 🖥️  (cmd/wits/spend/spend.go) ❓ 🗒️  Error loading settings: %v \n", err)
+			return err
+		}
+		if err := i18n.UseLocale(i18n.Locale(s.Locale)); err != nil {
+			return err
+		}
+		start, end, err := parseRange(from, to)
+		if err != nil {
+			return err
+		}
+		strains := service.NewStrainService(storage.NewStrainStore()).GetStrains()
+		currency := s.Currency
+		if currency == "" {
+			currency = settings.DefaultCurrency
+		}
+		render(cmd.OutOrStdout(), service.SummarizeSpend(strains, start, end), currency)
+		return nil
+	},
+}
+
+func init() {
+	Command.Flags().StringVar(&from, "from", "", "the first month to summarize (YYYY-MM)")
+	Command.Flags().StringVar(&to, "to", "", "the last month to summarize (YYYY-MM)")
+}
+
+// parseRange returns the time range [start, end) covering the given months.
+// Empty months leave the respective bound open.
+func parseRange(from, to string) (time.Time, time.Time, error) {
+	var start, end time.Time
+	if from != "" {
+		t, err := time.ParseInLocation(monthLayout, from, time.Local)
+		if err != nil {
+			return start, end, fmt.Errorf("invalid --from month %q, expected YYYY-MM", from)
+		}
+		start = t
+	}
+	if to != "" {
+		t, err := time.ParseInLocation(monthLayout, to, time.Local)
+		if err != nil {
+			return start, end, fmt.Errorf("invalid --to month %q, expected YYYY-MM", to)
+		}
+		end = t.AddDate(0, 1, 0)
+	}
+	return start, end, nil
+}
+
+// render writes the given monthly spending to the given writer.
+func render(w io.Writer, summary []*service.MonthlySpend, currency string) {
+	if len(summary) == 0 {
+		fmt.Fprintln(w, i18n.T("No priced purchases found."))
+		return
+	}
+	var total, grams float64
+	for _, ms := range summary {
+		total += ms.Total
+		grams += ms.Grams
+		fmt.Fprintln(w, ms.Month.Format(monthLayout)+"  "+i18n.T("%s for %s g", i18n.FormatMoney(ms.Total, currency), i18n.FormatFloat(ms.Grams, 1)))
+		fmt.Fprintln(w, "  "+service.SpendGroupsLine(i18n.T("Manufacturers"), ms.ByManufacturer, currency))
+		fmt.Fprintln(w, "  "+service.SpendGroupsLine(i18n.T("Pharmacies"), ms.ByPharmacy, currency))
+	}
+	fmt.Fprint(w, "\n"+i18n.T("Total: %s for %s g", i18n.FormatMoney(total, currency), i18n.FormatFloat(grams, 1)))
+	if grams > 0 {
+		fmt.Fprint(w, " "+i18n.T("(%s per g)", i18n.FormatMoney(total/grams, currency)))
+	}
+	fmt.Fprintln(w)
+}
@@ -0,0 +1,2 @@
+// Package stock provides the command to forecast when the stock runs out
+package stock // import "github.com/TheDonDope/wits-tui/cmd/wits/stock"
@@ -0,0 +1,81 @@
+package stock
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"time"
+
+	"github.com/TheDonDope/wits-tui/pkg/i18n"
+	"github.com/TheDonDope/wits-tui/pkg/service"
+	"github.com/TheDonDope/wits-tui/pkg/settings"
+	"github.com/TheDonDope/wits-tui/pkg/storage"
+	"github.com/spf13/cobra"
+)
+
+const dateLayout = "2006-01-02"
+
+// ErrStockLow is returned if the stock is below the configured thresholds, so
+// that the command exits with a non-zero code (e.g. for use in a cron job).
+var ErrStockLow = errors.New("stock is running low")
+
+// Command is the stock command.
+var Command = &cobra.Command{
+	Use:   "stock",
+	Short: "Forecast when the stock runs out, failing if it is running low",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		s, err := settings.Load()
+		if err != nil {
+			log.Printf("🚨 🖥️  (cmd/wits/stock/stock.go) ❓ 🗒️  Error loading settings: %v \n", err)
+			return err
+		}
+		if err := i18n.UseLocale(i18n.Locale(s.Locale)); err != nil {
+			return err
+		}
+		strainStore := storage.NewStrainStore()
+		strains := service.NewStrainService(strainStore).GetStrains()
+		sessions := service.NewSessionService(storage.NewSessionStore(), strainStore).GetSessions()
+		overall, perStrain := service.ForecastStock(strains, sessions, time.Now(), service.StockThresholds{
+			LowGrams:    s.Stock.LowGrams,
+			LowDays:     s.Stock.LowDays,
+			UsageWindow: s.Stock.UsageWindowDays,
+		})
+		render(cmd.OutOrStdout(), overall, perStrain)
+		if overall.Low {
+			return ErrStockLow
+		}
+		for _, f := range perStrain {
+			if f.Low {
+				return ErrStockLow
+			}
+		}
+		return nil
+	},
+}
+
+// render writes the given overall and per strain forecasts to the given
+// writer.
+func render(w io.Writer, overall service.StockForecast, perStrain []service.StockForecast) {
+	fmt.Fprintln(w, i18n.T("Total: %s", line(overall)))
+	for _, f := range perStrain {
+		fmt.Fprintf(w, "  %s: %s\n", f.Product, line(f))
+	}
+}
+
+// line returns the amount and the predicted run-out of the given forecast,
+// marking forecasts running low.
+func line(f service.StockForecast) string {
+	l := fmt.Sprintf("%s g", i18n.FormatFloat(f.Amount, 1))
+	if f.Predicted() {
+		l += ", " + i18n.T("%s g per day, runs out on %s (%s days)",
+			i18n.FormatFloat(f.DailyUsage, 2), f.RunOut.Format(dateLayout), i18n.FormatFloat(f.DaysLeft, 0))
+	} else {
+		l += ", " + i18n.T("no consumption logged recently")
+	}
+	if f.Low {
+		l += " [" + i18n.T("LOW") + "]"
+	}
+	return l
+}
@@ -0,0 +1,2 @@
+// Package strain provides the commands to query the strains
+package strain // import "github.com/TheDonDope/wits-tui/cmd/wits/strain"
@@ -0,0 +1,105 @@
+package strain
+
+import (
+	"fmt"
+	"io"
+	"log"
+
+	"github.com/TheDonDope/wits-tui/pkg/i18n"
+	"github.com/TheDonDope/wits-tui/pkg/service"
+	"github.com/TheDonDope/wits-tui/pkg/settings"
+	"github.com/TheDonDope/wits-tui/pkg/storage"
+	"github.com/spf13/cobra"
+)
+
+var (
+	effects []string
+	flavors []string
+)
+
+// Command is the strain command.
+var Command = &cobra.Command{
+	Use:   "strain",
+	Short: "Query the strains",
+}
+
+// similarCommand ranks the strains by their similarity to a strain.
+var similarCommand = &cobra.Command{
+	Use:   "similar <name>",
+	Short: "Rank the other strains by their similarity to the named strain",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := useLocale(); err != nil {
+			return err
+		}
+		svc := service.NewStrainService(storage.NewStrainStore())
+		strain, err := svc.FindStrainByName(args[0])
+		if err != nil {
+			log.Printf("🚨 🖥️  (cmd/wits/strain/strain.go) ❓ 🗒️  Error finding strain %v: %v \n", args[0], err)
+			return fmt.Errorf("strain %q: %w", args[0], err)
+		}
+		renderSimilar(cmd.OutOrStdout(), service.SimilarStrains(strain, svc.GetStrains()))
+		return nil
+	},
+}
+
+// recommendCommand recommends the strains in stock matching desired effects
+// and flavors.
+var recommendCommand = &cobra.Command{
+	Use:   "recommend",
+	Short: "Recommend the strains in stock best matching the desired effects and flavors",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		if len(effects)+len(flavors) == 0 {
+			return fmt.Errorf("no effects or flavors given")
+		}
+		if err := useLocale(); err != nil {
+			return err
+		}
+		strains := service.NewStrainService(storage.NewStrainStore()).GetStrains()
+		renderMatches(cmd.OutOrStdout(), service.RecommendStrains(strains, effects, flavors))
+		return nil
+	},
+}
+
+func init() {
+	recommendCommand.Flags().StringSliceVar(&effects, "effect", nil, "the desired effects (e.g. sedative)")
+	recommendCommand.Flags().StringSliceVar(&flavors, "flavor", nil, "the desired flavors (e.g. citrus)")
+	Command.AddCommand(similarCommand)
+	Command.AddCommand(recommendCommand)
+}
+
+// useLocale loads the settings and translates the output to the configured
+// locale.
+func useLocale() error {
+	s, err := settings.Load()
+	if err != nil {
+		log.Printf("🚨 🖥️  (cmd/wits/strain/strain.go) ❓ 🗒️  Error loading settings: %v \n", err)
+		return err
+	}
+	return i18n.UseLocale(i18n.Locale(s.Locale))
+}
+
+// renderSimilar writes the given similar strains with the reasons for their
+// similarity to the given writer.
+func renderSimilar(w io.Writer, similar []service.StrainSimilarity) {
+	if len(similar) == 0 {
+		fmt.Fprintln(w, i18n.T("No other strains to compare."))
+		return
+	}
+	for _, sim := range similar {
+		fmt.Fprintln(w, service.SimilarityLine(sim))
+	}
+}
+
+// renderMatches writes the given matching strains with the effects and flavors
+// they provide to the given writer.
+func renderMatches(w io.Writer, matches []service.StrainMatch) {
+	if len(matches) == 0 {
+		fmt.Fprintln(w, i18n.T("None of your strains provides the desired effects or flavors."))
+		return
+	}
+	for _, m := range matches {
+		fmt.Fprintln(w, service.MatchLine(m))
+	}
+}
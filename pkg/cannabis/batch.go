@@ -0,0 +1,72 @@
+package cannabis
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Batch is the type for a purchased batch (lot) of a strain. Batches of the
+// same product can differ in their lab values and best-before dates.
+type Batch struct {
+	ID             uuid.UUID // The unique identifier
+	Number         string    // The batch number as printed on the package
+	PurchasedAt    time.Time // The purchase date
+	Pharmacy       string    // The dispensing pharmacy
+	Price          float64   // The price paid for the batch
+	Grams          float64   // The purchased amount in grams
+	Remaining      float64   // The amount in grams still in stock
+	THC            float64   // The tested THC content in %
+	CBD            float64   // The tested CBD content in %
+	ExpiresAt      time.Time // The best-before date
+	PrescriptionID uuid.UUID // The prescription the batch was dispensed on, if any
+}
+
+// Expired reports whether the batch is past its best-before date at the given
+// time. Batches without a best-before date never expire.
+func (b *Batch) Expired(at time.Time) bool {
+	return !b.ExpiresAt.IsZero() && at.After(b.ExpiresAt)
+}
+
+// AddBatch adds the given batch to the strain and recalculates the strain
+// level amount and potency. If this is the first batch of a strain with stock,
+// the existing stock is kept as an initial batch.
+func (s *Strain) AddBatch(b *Batch) {
+	if len(s.Batches) == 0 && s.Amount > 0 {
+		s.Batches = append(s.Batches, &Batch{
+			ID:          uuid.New(),
+			PurchasedAt: s.CreatedAt,
+			Grams:       s.Amount,
+			Remaining:   s.Amount,
+			THC:         s.THC,
+			CBD:         s.CBD,
+		})
+	}
+	s.Batches = append(s.Batches, b)
+	s.Recalculate()
+}
+
+// Recalculate computes the amount of the strain as the sum of the remaining
+// grams of its batches, and the THC and CBD content as the average of the
+// batches weighted by their remaining grams. Strains without batches are left
+// unchanged.
+func (s *Strain) Recalculate() {
+	if len(s.Batches) == 0 {
+		return
+	}
+	var amount, thc, cbd float64
+	for _, b := range s.Batches {
+		amount += b.Remaining
+		thc += b.THC * b.Remaining
+		cbd += b.CBD * b.Remaining
+	}
+	s.Amount = amount
+	if amount > 0 {
+		s.THC = thc / amount
+		s.CBD = cbd / amount
+		return
+	}
+	// Without any stock left, the potency of the latest batch is kept
+	latest := s.Batches[len(s.Batches)-1]
+	s.THC, s.CBD = latest.THC, latest.CBD
+}
@@ -0,0 +1,57 @@
+package cannabis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStrain_AddBatch(t *testing.T) {
+	t.Run("WithoutStock", func(t *testing.T) {
+		s := &Strain{}
+		s.AddBatch(&Batch{Grams: 10, Remaining: 10, THC: 20, CBD: 1})
+		s.AddBatch(&Batch{Grams: 5, Remaining: 5, THC: 26, CBD: 0.1})
+
+		require.Len(t, s.Batches, 2)
+		assert.InDelta(t, 15, s.Amount, 1e-9)
+		assert.InDelta(t, 22, s.THC, 1e-9)
+		assert.InDelta(t, 0.7, s.CBD, 1e-9)
+	})
+
+	t.Run("KeepsExistingStock", func(t *testing.T) {
+		created := time.Date(2023, time.October, 5, 12, 0, 0, 0, time.UTC)
+		s := &Strain{Amount: 5, THC: 18, CBD: 1, CreatedAt: created}
+		s.AddBatch(&Batch{Grams: 5, Remaining: 5, THC: 22, CBD: 1})
+
+		require.Len(t, s.Batches, 2)
+		assert.Equal(t, created, s.Batches[0].PurchasedAt)
+		assert.InDelta(t, 10, s.Amount, 1e-9)
+		assert.InDelta(t, 20, s.THC, 1e-9)
+	})
+}
+
+func TestStrain_Recalculate(t *testing.T) {
+	t.Run("WithoutBatches", func(t *testing.T) {
+		s := &Strain{Amount: 3, THC: 20}
+		s.Recalculate()
+		assert.Equal(t, 3.0, s.Amount)
+		assert.Equal(t, 20.0, s.THC)
+	})
+
+	t.Run("WithoutStock", func(t *testing.T) {
+		s := &Strain{Batches: []*Batch{{Grams: 10, THC: 20}, {Grams: 10, THC: 24, CBD: 1}}}
+		s.Recalculate()
+		assert.Equal(t, 0.0, s.Amount)
+		assert.Equal(t, 24.0, s.THC)
+		assert.Equal(t, 1.0, s.CBD)
+	})
+}
+
+func TestBatch_Expired(t *testing.T) {
+	now := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+	assert.False(t, (&Batch{}).Expired(now))
+	assert.False(t, (&Batch{ExpiresAt: now.AddDate(0, 1, 0)}).Expired(now))
+	assert.True(t, (&Batch{ExpiresAt: now.AddDate(0, -1, 0)}).Expired(now))
+}
@@ -0,0 +1,113 @@
+package cannabis
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// CatalogFile is the name of the user catalog within the WITS_DIR.
+const CatalogFile = "catalog.yml"
+
+//go:embed catalog.yml
+var builtinCatalog []byte
+
+// Catalog is the layout of the reference catalog files, listing cannabinoids
+// and terpenes with their identifiers.
+type Catalog struct {
+	Cannabinoids []Cannabinoid `yaml:"cannabinoids"`
+	Terpenes     []*Terpene    `yaml:"terpenes"`
+}
+
+func init() {
+	c, err := ParseCatalog(builtinCatalog)
+	if err == nil {
+		err = MergeCatalog(c)
+	}
+	if err != nil {
+		panic(fmt.Sprintf("invalid built-in catalog: %v", err))
+	}
+}
+
+// ParseCatalog parses the given catalog data.
+func ParseCatalog(data []byte) (*Catalog, error) {
+	c := &Catalog{}
+	if err := yaml.Unmarshal(data, c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// validate checks that every entry of the catalog has a unique identifier and a
+// name.
+func (c *Catalog) validate() error {
+	ids := map[string]bool{}
+	for _, cb := range c.Cannabinoids {
+		if cb.ID == "" || cb.Name == "" {
+			return fmt.Errorf("cannabinoid %q requires an id and a name", cb.ID+cb.Name)
+		}
+		if ids["cannabinoid:"+cb.ID] {
+			return fmt.Errorf("duplicate cannabinoid %q", cb.ID)
+		}
+		ids["cannabinoid:"+cb.ID] = true
+	}
+	for _, t := range c.Terpenes {
+		if t.ID == "" || t.Name == "" {
+			return fmt.Errorf("terpene %q requires an id and a name", t.ID+t.Name)
+		}
+		if ids["terpene:"+t.ID] {
+			return fmt.Errorf("duplicate terpene %q", t.ID)
+		}
+		ids["terpene:"+t.ID] = true
+	}
+	return nil
+}
+
+// MergeCatalog adds the entries of the given catalog to the known cannabinoids
+// and terpenes. An entry with the identifier of a known entry replaces it, any
+// other entry is added as a new type. Nothing is merged if the catalog is
+// invalid.
+func MergeCatalog(c *Catalog) error {
+	if err := c.validate(); err != nil {
+		return err
+	}
+	for _, cb := range c.Cannabinoids {
+		ct, err := ParseCannabinoidType(cb.ID)
+		if err != nil {
+			ct = CannabinoidType(len(Cannabinoids))
+		}
+		Cannabinoids[ct] = cb
+	}
+	for _, t := range c.Terpenes {
+		tt, err := ParseTerpeneType(t.ID)
+		if err != nil {
+			tt = TerpeneType(len(Terpenes))
+		}
+		Terpenes[tt] = t
+	}
+	return nil
+}
+
+// LoadCatalog merges the user catalog in the given directory into the built-in
+// one. A missing user catalog is no error.
+func LoadCatalog(dir string) error {
+	data, err := os.ReadFile(filepath.Join(dir, CatalogFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	c, err := ParseCatalog(data)
+	if err != nil {
+		return fmt.Errorf("invalid catalog %s: %w", CatalogFile, err)
+	}
+	if err := MergeCatalog(c); err != nil {
+		return fmt.Errorf("invalid catalog %s: %w", CatalogFile, err)
+	}
+	return nil
+}
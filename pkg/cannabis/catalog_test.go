@@ -0,0 +1,86 @@
+package cannabis
+
+import (
+	"maps"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// restoreCatalog restores the built-in catalog after the test.
+func restoreCatalog(t *testing.T) {
+	terpenes := maps.Clone(Terpenes)
+	cannabinoids := maps.Clone(Cannabinoids)
+	t.Cleanup(func() {
+		Terpenes = terpenes
+		Cannabinoids = cannabinoids
+	})
+}
+
+func TestBuiltinCatalog(t *testing.T) {
+	// The entries of the catalog file are bound to the constants by their order
+	assert.Equal(t, "thca", THCA.ID())
+	assert.Equal(t, "delta-9-thc", Delta9THC.ID())
+	assert.Equal(t, "benzene", Benzene.ID())
+	assert.Equal(t, "cbg", CBG.ID())
+	assert.Equal(t, "beta-caryophyllene", BetaCaryophyllene.ID())
+	assert.Equal(t, "limonene", Limonene.ID())
+	assert.Equal(t, "quercetin", Quercetin.ID())
+	assert.Equal(t, "terpinolene", Terpinolene.ID())
+	assert.Len(t, Cannabinoids, int(CBG)+1)
+	assert.Len(t, Terpenes, int(Terpinolene)+1)
+}
+
+func TestLoadCatalog(t *testing.T) {
+	t.Run("Missing", func(t *testing.T) {
+		restoreCatalog(t)
+		require.NoError(t, LoadCatalog(t.TempDir()))
+		assert.Len(t, Terpenes, int(Terpinolene)+1)
+	})
+
+	t.Run("Merge", func(t *testing.T) {
+		restoreCatalog(t)
+		dir := t.TempDir()
+		data := `terpenes:
+  - id: limonene
+    name: Limonene
+    effects: [uplifting]
+    boiling_point: 176
+  - id: geraniol
+    name: Geraniol
+    flavors: [rose]
+    boiling_point: 230
+cannabinoids:
+  - id: cbdv
+    short_name: CBDV
+    name: Cannabidivarin
+`
+		require.NoError(t, os.WriteFile(filepath.Join(dir, CatalogFile), []byte(data), 0644))
+
+		require.NoError(t, LoadCatalog(dir))
+
+		assert.Equal(t, 176, Terpenes[Limonene].BoilingPoint)
+		assert.Equal(t, []string{"uplifting"}, Terpenes[Limonene].Effects)
+		geraniol, err := ParseTerpeneType("geraniol")
+		require.NoError(t, err)
+		assert.Equal(t, Terpinolene+1, geraniol)
+		assert.Equal(t, "Geraniol", Terpenes[geraniol].Name)
+		cbdv, err := ParseCannabinoidType("cbdv")
+		require.NoError(t, err)
+		assert.Equal(t, "CBDV", Cannabinoids[cbdv].ShortName)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		restoreCatalog(t)
+		dir := t.TempDir()
+		data := "terpenes:\n  - id: geraniol\n  - id: nerol\n    name: Nerol\n"
+		require.NoError(t, os.WriteFile(filepath.Join(dir, CatalogFile), []byte(data), 0644))
+
+		assert.Error(t, LoadCatalog(dir))
+		_, err := ParseTerpeneType("nerol")
+		assert.Error(t, err, "nothing is merged from an invalid catalog")
+	})
+}
@@ -0,0 +1,49 @@
+package cannabis
+
+// Spent returns the total price paid for all batches of the strain.
+func (s *Strain) Spent() float64 {
+	var spent float64
+	for _, b := range s.Batches {
+		spent += b.Price
+	}
+	return spent
+}
+
+// CostPerGram returns the average price paid per gram over all priced batches
+// of the strain. It reports false if no batch has a price.
+func (s *Strain) CostPerGram() (float64, bool) {
+	var price, grams float64
+	for _, b := range s.Batches {
+		if b.Price > 0 && b.Grams > 0 {
+			price += b.Price
+			grams += b.Grams
+		}
+	}
+	if grams == 0 {
+		return 0, false
+	}
+	return price / grams, true
+}
+
+// CostPerMgTHC returns the average price paid per milligram of THC over all
+// priced batches of the strain, based on the tested THC content of each
+// batch. It reports false if no priced batch contains THC.
+func (s *Strain) CostPerMgTHC() (float64, bool) {
+	var price, mg float64
+	for _, b := range s.Batches {
+		if b.Price > 0 && b.Grams > 0 && b.THC > 0 {
+			price += b.Price
+			mg += MgPerGram(b.THC) * b.Grams
+		}
+	}
+	if mg == 0 {
+		return 0, false
+	}
+	return price / mg, true
+}
+
+// MgPerGram returns the milligrams of a cannabinoid per gram of flower for the
+// given content in %.
+func MgPerGram(percent float64) float64 {
+	return percent * 10
+}
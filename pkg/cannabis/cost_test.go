@@ -0,0 +1,40 @@
+package cannabis
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStrain_Cost(t *testing.T) {
+	t.Run("WithoutPrices", func(t *testing.T) {
+		s := &Strain{Batches: []*Batch{{Grams: 10, THC: 20}}}
+
+		_, ok := s.CostPerGram()
+		assert.False(t, ok)
+		_, ok = s.CostPerMgTHC()
+		assert.False(t, ok)
+		assert.Zero(t, s.Spent())
+	})
+
+	t.Run("PricedBatches", func(t *testing.T) {
+		s := &Strain{Batches: []*Batch{
+			{Grams: 10, Price: 100, THC: 20},
+			{Grams: 5, Price: 65, THC: 25},
+			{Grams: 3, THC: 18}, // Legacy stock without price
+		}}
+
+		assert.InDelta(t, 165, s.Spent(), 1e-9)
+		perGram, ok := s.CostPerGram()
+		assert.True(t, ok)
+		assert.InDelta(t, 11, perGram, 1e-9)
+		// 10 g × 200 mg + 5 g × 250 mg = 3250 mg THC
+		perMg, ok := s.CostPerMgTHC()
+		assert.True(t, ok)
+		assert.InDelta(t, 165.0/3250, perMg, 1e-9)
+	})
+}
+
+func TestMgPerGram(t *testing.T) {
+	assert.InDelta(t, 225, MgPerGram(22.5), 1e-9)
+}
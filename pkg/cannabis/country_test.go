@@ -0,0 +1,36 @@
+package cannabis
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCountry(t *testing.T) {
+	tests := []struct {
+		input string
+		code  string
+		ok    bool
+	}{
+		{"CA", "CA", true},
+		{" de ", "DE", true},
+		{"Canada", "CA", true},
+		{"the netherlands", "NL", true},
+		{"USA", "US", true},
+		{"XX", "XX", false},
+		{"Atlantis", "", false},
+		{"", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			code, ok := ParseCountry(tt.input)
+			assert.Equal(t, tt.code, code)
+			assert.Equal(t, tt.ok, ok)
+		})
+	}
+}
+
+func TestCountryName(t *testing.T) {
+	assert.Equal(t, "Portugal", CountryName("PT"))
+	assert.Equal(t, "Atlantis", CountryName("Atlantis"))
+}
@@ -0,0 +1,97 @@
+package cannabis
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Cultivar is the type for a cultivar (breed), shared by the strains of
+// different manufacturers. Strains refer to their cultivar by its name.
+type Cultivar struct {
+	ID        uuid.UUID // The unique identifier
+	Name      string    // The name, unique ignoring case
+	Breeder   string    `yaml:",omitempty"` // The breeder who created the cultivar
+	Indica    int       `yaml:",omitempty"` // The indica share of the genetics in %
+	Sativa    int       `yaml:",omitempty"` // The sativa share of the genetics in %
+	Parents   []string  `yaml:",omitempty"` // The names of the parent cultivars
+	Notes     string    `yaml:",omitempty"` // Free text notes
+	CreatedAt time.Time // The creation timestamp
+	UpdatedAt time.Time // The last update timestamp
+}
+
+// RatioKnown reports whether the indica/sativa ratio of the cultivar is known.
+func (c *Cultivar) RatioKnown() bool {
+	return c.Indica+c.Sativa > 0
+}
+
+// Ratio returns the indica/sativa ratio of the cultivar (e.g. 70/30), or an
+// empty string if it is unknown.
+func (c *Cultivar) Ratio() string {
+	if !c.RatioKnown() {
+		return ""
+	}
+	return fmt.Sprintf("%d/%d", c.Indica, c.Sativa)
+}
+
+// Genetic returns the genetic type of the cultivar: a pure indica or sativa,
+// or a hybrid of both. It returns false if the ratio is unknown.
+func (c *Cultivar) Genetic() (GeneticType, bool) {
+	switch {
+	case !c.RatioKnown():
+		return Hybrid, false
+	case c.Sativa == 0:
+		return Indica, true
+	case c.Indica == 0:
+		return Sativa, true
+	}
+	return Hybrid, true
+}
+
+// Dominant returns the genetic type dominating a hybrid cultivar. It returns
+// false if the ratio is unknown or balanced.
+func (c *Cultivar) Dominant() (GeneticType, bool) {
+	switch {
+	case !c.RatioKnown() || c.Indica == c.Sativa:
+		return Hybrid, false
+	case c.Indica > c.Sativa:
+		return Indica, true
+	}
+	return Sativa, true
+}
+
+// HasParent reports whether the cultivar with the given name is a parent of
+// the cultivar, ignoring case.
+func (c *Cultivar) HasParent(name string) bool {
+	return slices.ContainsFunc(c.Parents, func(p string) bool { return strings.EqualFold(p, name) })
+}
+
+// ParseRatio parses the given indica/sativa ratio (e.g. 70/30), or the indica
+// share alone (e.g. 70) with sativa making up the rest. An empty ratio is
+// unknown. The shares may add up to less than 100, e.g. for ruderalis
+// genetics.
+func ParseRatio(s string) (int, int, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, 0, nil
+	}
+	indicaText, sativaText, split := strings.Cut(s, "/")
+	indica, err := strconv.Atoi(strings.TrimSpace(indicaText))
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid ratio %q, expected indica/sativa like 70/30", s)
+	}
+	sativa := 100 - indica
+	if split {
+		if sativa, err = strconv.Atoi(strings.TrimSpace(sativaText)); err != nil {
+			return 0, 0, fmt.Errorf("invalid ratio %q, expected indica/sativa like 70/30", s)
+		}
+	}
+	if indica < 0 || sativa < 0 || indica+sativa > 100 {
+		return 0, 0, fmt.Errorf("invalid ratio %q, the shares must add up to at most 100", s)
+	}
+	return indica, sativa, nil
+}
@@ -0,0 +1,57 @@
+package cannabis
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCultivar_Genetic(t *testing.T) {
+	tests := []struct {
+		name           string
+		indica, sativa int
+		ratio          string
+		genetic        GeneticType
+		known          bool
+		dominant       GeneticType
+		dominates      bool
+	}{
+		{"Unknown", 0, 0, "", Hybrid, false, Hybrid, false},
+		{"PureIndica", 100, 0, "100/0", Indica, true, Indica, true},
+		{"PureSativa", 0, 100, "0/100", Sativa, true, Sativa, true},
+		{"IndicaDominant", 70, 30, "70/30", Hybrid, true, Indica, true},
+		{"SativaDominant", 20, 80, "20/80", Hybrid, true, Sativa, true},
+		{"Balanced", 50, 50, "50/50", Hybrid, true, Hybrid, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Cultivar{Indica: tt.indica, Sativa: tt.sativa}
+			assert.Equal(t, tt.ratio, c.Ratio())
+			genetic, known := c.Genetic()
+			assert.Equal(t, tt.genetic, genetic)
+			assert.Equal(t, tt.known, known)
+			dominant, dominates := c.Dominant()
+			assert.Equal(t, tt.dominant, dominant)
+			assert.Equal(t, tt.dominates, dominates)
+		})
+	}
+}
+
+func TestCultivar_HasParent(t *testing.T) {
+	c := &Cultivar{Name: "Pink Kush", Parents: []string{"OG Kush"}}
+	assert.True(t, c.HasParent("og kush"))
+	assert.False(t, c.HasParent("Hindu Kush"))
+}
+
+func TestParseRatio(t *testing.T) {
+	for s, want := range map[string][2]int{"": {0, 0}, "70/30": {70, 30}, " 60 / 20 ": {60, 20}, "80": {80, 20}} {
+		indica, sativa, err := ParseRatio(s)
+		require.NoError(t, err, s)
+		assert.Equal(t, want, [2]int{indica, sativa}, s)
+	}
+	for _, s := range []string{"indica", "70/x", "70/40", "-10/50", "120"} {
+		_, _, err := ParseRatio(s)
+		assert.Error(t, err, s)
+	}
+}
@@ -0,0 +1,59 @@
+package cannabis
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Manufacturer is the type for a producing company or importer of strains.
+// Strains refer to their manufacturer by its name.
+type Manufacturer struct {
+	ID        uuid.UUID // The unique identifier
+	Name      string    // The name, unique ignoring case
+	Aliases   []string  `yaml:",omitempty"` // Other names the manufacturer is known by
+	Country   string    `yaml:",omitempty"` // The ISO 3166-1 alpha-2 code of the country
+	Website   string    `yaml:",omitempty"` // The URL of the website
+	Notes     string    `yaml:",omitempty"` // Free text notes
+	CreatedAt time.Time // The creation timestamp
+	UpdatedAt time.Time // The last update timestamp
+}
+
+// Matches reports whether the manufacturer is known by the given name or
+// alias, ignoring case and surrounding whitespace.
+func (m *Manufacturer) Matches(name string) bool {
+	return matchesName(m.Name, m.Aliases, name)
+}
+
+// Pharmacy is the type for a pharmacy dispensing batches. Batches refer to
+// their pharmacy by its name.
+type Pharmacy struct {
+	ID        uuid.UUID // The unique identifier
+	Name      string    // The name, unique ignoring case
+	Aliases   []string  `yaml:",omitempty"` // Other names the pharmacy is known by
+	Country   string    `yaml:",omitempty"` // The ISO 3166-1 alpha-2 code of the country
+	Website   string    `yaml:",omitempty"` // The URL of the website
+	Notes     string    `yaml:",omitempty"` // Free text notes
+	CreatedAt time.Time // The creation timestamp
+	UpdatedAt time.Time // The last update timestamp
+}
+
+// Matches reports whether the pharmacy is known by the given name or alias,
+// ignoring case and surrounding whitespace.
+func (p *Pharmacy) Matches(name string) bool {
+	return matchesName(p.Name, p.Aliases, name)
+}
+
+// matchesName reports whether the given name equals the given name or one of
+// the given aliases of a directory entry, ignoring case and surrounding
+// whitespace.
+func matchesName(entry string, aliases []string, name string) bool {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return false
+	}
+	equal := func(s string) bool { return strings.EqualFold(strings.TrimSpace(s), name) }
+	return equal(entry) || slices.ContainsFunc(aliases, equal)
+}
@@ -0,0 +1,24 @@
+package cannabis
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestManufacturer_Matches(t *testing.T) {
+	m := &Manufacturer{Name: "Aurora", Aliases: []string{"Aurora Cannabis", "Pedanios"}}
+
+	assert.True(t, m.Matches("aurora"))
+	assert.True(t, m.Matches(" AURORA CANNABIS "))
+	assert.True(t, m.Matches("pedanios"))
+	assert.False(t, m.Matches("Aurora Deutschland"))
+	assert.False(t, m.Matches(""))
+}
+
+func TestPharmacy_Matches(t *testing.T) {
+	p := &Pharmacy{Name: "Linden-Apotheke", Aliases: []string{"Linden Apotheke"}}
+
+	assert.True(t, p.Matches("linden apotheke"))
+	assert.False(t, p.Matches("Bären-Apotheke"))
+}
@@ -0,0 +1,139 @@
+package cannabis
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DecarboxylationFactor is the mass ratio of THC to THCA (and of CBD to CBDA).
+// When heated, the acids lose their carboxyl group, so that only 87.7% of
+// their weight remains as the active cannabinoid.
+const DecarboxylationFactor = 0.877
+
+// ConsumptionMethod is the enum for the ways of consuming cannabis.
+type ConsumptionMethod int
+
+const (
+	// Vaporizing heats the flower below its combustion point
+	Vaporizing ConsumptionMethod = iota
+	// Smoking burns the flower
+	Smoking
+	// Oral is the consumption of edibles, oils or capsules
+	Oral
+)
+
+// ConsumptionMethods is a collection of all known consumption methods.
+var ConsumptionMethods = map[ConsumptionMethod]string{
+	Vaporizing: "Vaporizing",
+	Smoking:    "Smoking",
+	Oral:       "Oral"}
+
+// DefaultBioavailability is the share of the consumed cannabinoids reaching
+// the bloodstream by consumption method, based on average values reported in
+// the literature.
+var DefaultBioavailability = map[ConsumptionMethod]float64{
+	Vaporizing: 0.35,
+	Smoking:    0.25,
+	Oral:       0.10}
+
+// ParseConsumptionMethod returns the consumption method with the given name,
+// ignoring case.
+func ParseConsumptionMethod(name string) (ConsumptionMethod, error) {
+	for m, n := range ConsumptionMethods {
+		if strings.EqualFold(n, name) {
+			return m, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown consumption method %q", name)
+}
+
+// Potency is the cannabinoid content of flower in %. Flower mostly contains
+// the acids THCA and CBDA, which are only converted to THC and CBD when heated.
+type Potency struct {
+	THC  float64 // The THC content in %
+	THCA float64 // The THCA content in %
+	CBD  float64 // The CBD content in %
+	CBDA float64 // The CBDA content in %
+}
+
+// Potency returns the potency of the strain. The THC and CBD content of a
+// strain is given as total THC and CBD, with the acids already converted. If
+// the cannabinoid profile contains THCA or CBDA, these are taken from the
+// profile and only the remainder of the total counts as THC or CBD, so that
+// the acids are not decarboxylated twice.
+func (s *Strain) Potency() Potency {
+	thc, thca := s.potency(s.THC, Delta9THC, THCA)
+	cbd, cbda := s.potency(s.CBD, CBD, CBDA)
+	return Potency{THC: thc, THCA: thca, CBD: cbd, CBDA: cbda}
+}
+
+// potency returns the content in % of the given neutral cannabinoid and its
+// acid, given their total content. Without a total, the neutral content of the
+// profile is used.
+func (s *Strain) potency(total float64, neutral, acid CannabinoidType) (float64, float64) {
+	a, ok := s.Cannabinoids[acid]
+	if !ok {
+		return total, 0
+	}
+	if total == 0 {
+		return s.Cannabinoids[neutral], a
+	}
+	return max(total-a*DecarboxylationFactor, 0), a
+}
+
+// Milligrams returns the milligrams of THC and CBD contained in the given
+// grams of flower with the given potency, after full decarboxylation.
+func Milligrams(grams float64, p Potency) (thc, cbd float64) {
+	thc = grams * MgPerGram(p.THC+p.THCA*DecarboxylationFactor)
+	cbd = grams * MgPerGram(p.CBD+p.CBDA*DecarboxylationFactor)
+	return thc, cbd
+}
+
+// Dose is the amount of cannabinoids taken with a consumption method.
+type Dose struct {
+	Method      ConsumptionMethod // The consumption method
+	THC         float64           // The consumed THC in mg
+	CBD         float64           // The consumed CBD in mg
+	AbsorbedTHC float64           // The THC in mg reaching the bloodstream
+	AbsorbedCBD float64           // The CBD in mg reaching the bloodstream
+}
+
+// DosageCalculator converts grams of flower into milligrams of cannabinoids,
+// applying the bioavailability of the consumption method.
+type DosageCalculator struct {
+	bioavailability map[ConsumptionMethod]float64
+}
+
+// NewDosageCalculator creates a new calculator with the given bioavailability
+// by consumption method. Methods without a given bioavailability fall back to
+// the DefaultBioavailability.
+func NewDosageCalculator(bioavailability map[ConsumptionMethod]float64) *DosageCalculator {
+	c := &DosageCalculator{bioavailability: map[ConsumptionMethod]float64{}}
+	for m, f := range DefaultBioavailability {
+		c.bioavailability[m] = f
+	}
+	for m, f := range bioavailability {
+		c.bioavailability[m] = f
+	}
+	return c
+}
+
+// Bioavailability returns the share of the consumed cannabinoids reaching the
+// bloodstream with the given consumption method.
+func (c *DosageCalculator) Bioavailability(m ConsumptionMethod) float64 {
+	return c.bioavailability[m]
+}
+
+// Dose returns the dose taken by consuming the given grams of flower with the
+// given potency and consumption method.
+func (c *DosageCalculator) Dose(grams float64, p Potency, m ConsumptionMethod) Dose {
+	thc, cbd := Milligrams(grams, p)
+	return c.Absorb(thc, cbd, m)
+}
+
+// Absorb returns the dose taken by consuming the given milligrams of THC and
+// CBD with the given consumption method.
+func (c *DosageCalculator) Absorb(thc, cbd float64, m ConsumptionMethod) Dose {
+	f := c.Bioavailability(m)
+	return Dose{Method: m, THC: thc, CBD: cbd, AbsorbedTHC: thc * f, AbsorbedCBD: cbd * f}
+}
@@ -0,0 +1,79 @@
+package cannabis
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMilligrams(t *testing.T) {
+	t.Run("Total", func(t *testing.T) {
+		thc, cbd := Milligrams(0.5, Potency{THC: 20, CBD: 1})
+		assert.InDelta(t, 100, thc, 1e-9)
+		assert.InDelta(t, 5, cbd, 1e-9)
+	})
+
+	t.Run("Decarboxylation", func(t *testing.T) {
+		thc, cbd := Milligrams(1, Potency{THC: 1, THCA: 20, CBDA: 10})
+		assert.InDelta(t, 10+200*0.877, thc, 1e-9)
+		assert.InDelta(t, 100*0.877, cbd, 1e-9)
+	})
+}
+
+func TestStrain_Potency(t *testing.T) {
+	tests := []struct {
+		name   string
+		strain *Strain
+		want   Potency
+	}{
+		{"Total", &Strain{THC: 20, CBD: 1}, Potency{THC: 20, CBD: 1}},
+		{"AcidProfile", &Strain{THC: 20, CBD: 1, Cannabinoids: map[CannabinoidType]float64{THCA: 20}}, Potency{THC: 20 - 20*0.877, THCA: 20, CBD: 1}},
+		{"ProfileOnly", &Strain{Cannabinoids: map[CannabinoidType]float64{THCA: 20, Delta9THC: 1, CBDA: 2}}, Potency{THC: 1, THCA: 20, CBDA: 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.strain.Potency()
+			assert.InDelta(t, tt.want.THC, got.THC, 1e-9)
+			assert.InDelta(t, tt.want.THCA, got.THCA, 1e-9)
+			assert.InDelta(t, tt.want.CBD, got.CBD, 1e-9)
+			assert.InDelta(t, tt.want.CBDA, got.CBDA, 1e-9)
+
+			thc, _ := Milligrams(1, got)
+			assert.InDelta(t, MgPerGram(tt.want.THC+tt.want.THCA*DecarboxylationFactor), thc, 1e-9)
+		})
+	}
+}
+
+func TestDosageCalculator_Dose(t *testing.T) {
+	t.Run("DefaultBioavailability", func(t *testing.T) {
+		c := NewDosageCalculator(nil)
+		d := c.Dose(0.2, Potency{THC: 22, CBD: 1}, Vaporizing)
+
+		assert.Equal(t, Vaporizing, d.Method)
+		assert.InDelta(t, 44, d.THC, 1e-9)
+		assert.InDelta(t, 2, d.CBD, 1e-9)
+		assert.InDelta(t, 44*0.35, d.AbsorbedTHC, 1e-9)
+		assert.InDelta(t, 2*0.35, d.AbsorbedCBD, 1e-9)
+	})
+
+	t.Run("ConfiguredBioavailability", func(t *testing.T) {
+		c := NewDosageCalculator(map[ConsumptionMethod]float64{Oral: 0.2})
+		d := c.Dose(1, Potency{THCA: 10}, Oral)
+
+		assert.InDelta(t, 0.2, c.Bioavailability(Oral), 1e-9)
+		assert.InDelta(t, DefaultBioavailability[Smoking], c.Bioavailability(Smoking), 1e-9)
+		assert.InDelta(t, 87.7, d.THC, 1e-9)
+		assert.InDelta(t, 17.54, d.AbsorbedTHC, 1e-9)
+	})
+}
+
+func TestParseConsumptionMethod(t *testing.T) {
+	m, err := ParseConsumptionMethod("smoking")
+	require.NoError(t, err)
+	assert.Equal(t, Smoking, m)
+
+	_, err = ParseConsumptionMethod("dabbing")
+	assert.Error(t, err)
+}
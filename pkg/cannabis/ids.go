@@ -0,0 +1,108 @@
+package cannabis
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// Terpene and cannabinoid types are stored by their stable string identifiers
+// instead of their position in the enum, so reordering or extending the
+// catalogs never changes the meaning of stored data. The types implement
+// encoding.TextMarshaler and encoding.TextUnmarshaler, which both yaml.v3 and
+// encoding/json use for values as well as map keys.
+
+// ID returns the stable identifier of the terpene type, or an empty string if
+// the type is not in the catalog.
+func (t TerpeneType) ID() string {
+	if tp, ok := Terpenes[t]; ok {
+		return tp.ID
+	}
+	return ""
+}
+
+// ParseTerpeneType returns the terpene type with the given identifier.
+func ParseTerpeneType(id string) (TerpeneType, error) {
+	for t, tp := range Terpenes {
+		if tp.ID == id {
+			return t, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown terpene %q", id)
+}
+
+// terpeneTypeByName returns the terpene type with the given name. It resolves
+// strains stored with the full terpene.
+func terpeneTypeByName(name string) (TerpeneType, error) {
+	for t, tp := range Terpenes {
+		if tp.Name == name {
+			return t, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown terpene %q", name)
+}
+
+// MarshalText encodes the terpene type as its identifier.
+func (t TerpeneType) MarshalText() ([]byte, error) {
+	id := t.ID()
+	if id == "" {
+		return nil, fmt.Errorf("unknown terpene type %d", int(t))
+	}
+	return []byte(id), nil
+}
+
+// UnmarshalText decodes the terpene type from its identifier.
+func (t *TerpeneType) UnmarshalText(text []byte) error {
+	parsed, err := ParseTerpeneType(string(text))
+	if err != nil {
+		return err
+	}
+	*t = parsed
+	return nil
+}
+
+// ID returns the stable identifier of the cannabinoid type, or an empty string
+// if the type is not in the catalog.
+func (c CannabinoidType) ID() string {
+	if cb, ok := Cannabinoids[c]; ok {
+		return cb.ID
+	}
+	return ""
+}
+
+// ParseCannabinoidType returns the cannabinoid type with the given identifier.
+func ParseCannabinoidType(id string) (CannabinoidType, error) {
+	for c, cb := range Cannabinoids {
+		if cb.ID == id {
+			return c, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown cannabinoid %q", id)
+}
+
+// MarshalText encodes the cannabinoid type as its identifier.
+func (c CannabinoidType) MarshalText() ([]byte, error) {
+	id := c.ID()
+	if id == "" {
+		return nil, fmt.Errorf("unknown cannabinoid type %d", int(c))
+	}
+	return []byte(id), nil
+}
+
+// UnmarshalText decodes the cannabinoid type from its identifier. Profiles
+// stored before the identifiers were introduced are keyed by the enum value,
+// which is still accepted.
+func (c *CannabinoidType) UnmarshalText(text []byte) error {
+	parsed, err := ParseCannabinoidType(string(text))
+	if err != nil {
+		n, convErr := strconv.Atoi(string(text))
+		if convErr != nil {
+			return err
+		}
+		if _, ok := Cannabinoids[CannabinoidType(n)]; !ok {
+			return err
+		}
+		parsed = CannabinoidType(n)
+	}
+	*c = parsed
+	return nil
+}
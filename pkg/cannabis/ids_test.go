@@ -0,0 +1,87 @@
+package cannabis
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestCatalogIDs_Unique(t *testing.T) {
+	terpenes := map[string]bool{}
+	for tt, tp := range Terpenes {
+		require.NotEmpty(t, tp.ID, tp.Name)
+		assert.False(t, terpenes[tp.ID], tp.ID)
+		terpenes[tp.ID] = true
+		parsed, err := ParseTerpeneType(tp.ID)
+		require.NoError(t, err)
+		assert.Equal(t, tt, parsed)
+	}
+
+	cannabinoids := map[string]bool{}
+	for c, cb := range Cannabinoids {
+		require.NotEmpty(t, cb.ID, cb.Name)
+		assert.False(t, cannabinoids[cb.ID], cb.ID)
+		cannabinoids[cb.ID] = true
+		parsed, err := ParseCannabinoidType(cb.ID)
+		require.NoError(t, err)
+		assert.Equal(t, c, parsed)
+	}
+}
+
+func TestStrainProfile_Marshal(t *testing.T) {
+	s := &Strain{
+		Cannabinoids: map[CannabinoidType]float64{THCA: 22.1, CBG: 0.8},
+		Terpenes:     []*StrainTerpene{{Type: BetaMyrcene, Percent: 0.8}, {Type: Limonene}},
+	}
+
+	t.Run("JSON", func(t *testing.T) {
+		data, err := json.Marshal(s)
+		require.NoError(t, err)
+		assert.Contains(t, string(data), `"Cannabinoids":{"cbg":0.8,"thca":22.1}`)
+		assert.Contains(t, string(data), `"Terpenes":[{"id":"beta-myrcene","percent":0.8},{"id":"limonene"}]`)
+
+		var decoded Strain
+		require.NoError(t, json.Unmarshal(data, &decoded))
+		assert.Equal(t, s.Cannabinoids, decoded.Cannabinoids)
+		assert.Equal(t, s.Terpenes, decoded.Terpenes)
+	})
+
+	t.Run("YAML", func(t *testing.T) {
+		data, err := yaml.Marshal(s)
+		require.NoError(t, err)
+		assert.Contains(t, string(data), "cbg: 0.8")
+		assert.Contains(t, string(data), "- id: beta-myrcene\n      percent: 0.8")
+
+		var decoded Strain
+		require.NoError(t, yaml.Unmarshal(data, &decoded))
+		assert.Equal(t, s.Cannabinoids, decoded.Cannabinoids)
+		assert.Equal(t, s.Terpenes, decoded.Terpenes)
+		assert.Equal(t, "β-Myrcene", decoded.Terpenes[0].Terpene().Name)
+	})
+
+	t.Run("Unknown", func(t *testing.T) {
+		var decoded Strain
+		require.NoError(t, yaml.Unmarshal([]byte("terpenes:\n  - id: unobtainium\n    percent: 0.3\n"), &decoded))
+		require.Len(t, decoded.Terpenes, 1)
+		assert.Nil(t, decoded.Terpenes[0].Terpene())
+		assert.Equal(t, "unobtainium", decoded.Terpenes[0].Name())
+		data, err := yaml.Marshal(decoded.Terpenes)
+		require.NoError(t, err)
+		assert.Equal(t, "- id: unobtainium\n  percent: 0.3\n", string(data))
+
+		var profiled Strain
+		require.NoError(t, yaml.Unmarshal([]byte("cannabinoids:\n  thca: 20\n  unobtainium: 1.5\n"), &profiled))
+		assert.Equal(t, map[CannabinoidType]float64{THCA: 20}, profiled.Cannabinoids)
+		assert.Equal(t, []string{"unobtainium"}, profiled.MissingCatalogIDs())
+		data, err = yaml.Marshal(profiled)
+		require.NoError(t, err)
+		assert.Contains(t, string(data), "cannabinoids:\n    thca: 20\n    unobtainium: 1.5\n")
+
+		assert.Error(t, json.Unmarshal([]byte(`{"Cannabinoids":{"unobtainium":1}}`), &decoded))
+		_, err = TerpeneType(-1).MarshalText()
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,89 @@
+package cannabis
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DefaultQuotaPeriod is the length of a quota period in days, if none is given.
+const DefaultQuotaPeriod = 30
+
+// Prescription is the type for a medical cannabis prescription, allowing a
+// maximum amount of grams per quota period while it is valid.
+type Prescription struct {
+	ID         uuid.UUID // The unique identifier
+	Doctor     string    // The prescribing doctor
+	IssuedAt   time.Time // The issue date
+	ValidUntil time.Time // The last day the prescription can be redeemed
+	Grams      float64   // The allowed grams per quota period
+	PeriodDays int       // The length of a quota period in days
+	Strains    []string  // The product names of the allowed strains, empty for any
+	CreatedAt  time.Time // The creation timestamp
+	UpdatedAt  time.Time // The last update timestamp
+}
+
+// Valid reports whether the prescription can be redeemed at the given time.
+// The prescription is valid from its issue date until the end of its last
+// valid day.
+func (p *Prescription) Valid(at time.Time) bool {
+	return !at.Before(p.IssuedAt) && at.Before(p.ValidUntil.AddDate(0, 0, 1))
+}
+
+// Allows reports whether the strain with the given product name may be
+// purchased on the prescription.
+func (p *Prescription) Allows(product string) bool {
+	if len(p.Strains) == 0 {
+		return true
+	}
+	for _, s := range p.Strains {
+		if strings.EqualFold(s, product) {
+			return true
+		}
+	}
+	return false
+}
+
+// Period returns the start and end of the quota period containing the given
+// time. Quota periods start at the issue date of the prescription.
+func (p *Prescription) Period(at time.Time) (time.Time, time.Time) {
+	days := p.PeriodDays
+	if days <= 0 {
+		days = DefaultQuotaPeriod
+	}
+	start := p.IssuedAt
+	for !at.Before(start.AddDate(0, 0, days)) {
+		start = start.AddDate(0, 0, days)
+	}
+	return start, start.AddDate(0, 0, days)
+}
+
+// Used returns the grams of all batches of the given strains which were
+// dispensed on the prescription within the quota period containing the given
+// time.
+func (p *Prescription) Used(strains []*Strain, at time.Time) float64 {
+	start, end := p.Period(at)
+	var used float64
+	for _, s := range strains {
+		for _, b := range s.Batches {
+			if b.PrescriptionID == p.ID && !b.PurchasedAt.Before(start) && b.PurchasedAt.Before(end) {
+				used += b.Grams
+			}
+		}
+	}
+	return used
+}
+
+// HasQuota reports whether the prescription limits the grams per quota
+// period. Prescriptions without grams allow any amount.
+func (p *Prescription) HasQuota() bool {
+	return p.Grams > 0
+}
+
+// Remaining returns the grams which may still be dispensed on the prescription
+// within the quota period containing the given time. It is zero for
+// prescriptions without a quota, which are not limited (see HasQuota).
+func (p *Prescription) Remaining(strains []*Strain, at time.Time) float64 {
+	return max(p.Grams-p.Used(strains, at), 0)
+}
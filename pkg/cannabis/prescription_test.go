@@ -0,0 +1,68 @@
+package cannabis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// testPrescription returns a prescription issued on 2024-01-10 allowing 30 g
+// per 30 days until 2024-04-09.
+func testPrescription() *Prescription {
+	return &Prescription{
+		ID:         uuid.New(),
+		IssuedAt:   time.Date(2024, time.January, 10, 0, 0, 0, 0, time.UTC),
+		ValidUntil: time.Date(2024, time.April, 9, 0, 0, 0, 0, time.UTC),
+		Grams:      30,
+		PeriodDays: 30,
+	}
+}
+
+func TestPrescription_Valid(t *testing.T) {
+	p := testPrescription()
+
+	assert.False(t, p.Valid(time.Date(2024, time.January, 9, 23, 0, 0, 0, time.UTC)))
+	assert.True(t, p.Valid(p.IssuedAt))
+	assert.True(t, p.Valid(time.Date(2024, time.April, 9, 23, 0, 0, 0, time.UTC)))
+	assert.False(t, p.Valid(time.Date(2024, time.April, 10, 0, 0, 0, 0, time.UTC)))
+}
+
+func TestPrescription_Allows(t *testing.T) {
+	p := testPrescription()
+	assert.True(t, p.Allows("Pink Kush"))
+
+	p.Strains = []string{"Pink Kush"}
+	assert.True(t, p.Allows("pink kush"))
+	assert.False(t, p.Allows("Wedding Cake"))
+}
+
+func TestPrescription_Period(t *testing.T) {
+	p := testPrescription()
+
+	start, end := p.Period(time.Date(2024, time.February, 15, 0, 0, 0, 0, time.UTC))
+
+	assert.Equal(t, time.Date(2024, time.February, 9, 0, 0, 0, 0, time.UTC), start)
+	assert.Equal(t, time.Date(2024, time.March, 10, 0, 0, 0, 0, time.UTC), end)
+
+	p.PeriodDays = 0
+	start, _ = p.Period(p.IssuedAt)
+	assert.Equal(t, p.IssuedAt, start)
+}
+
+func TestPrescription_Remaining(t *testing.T) {
+	p := testPrescription()
+	s := &Strain{Batches: []*Batch{
+		{PrescriptionID: p.ID, Grams: 10, PurchasedAt: time.Date(2024, time.January, 12, 0, 0, 0, 0, time.UTC)},
+		{PrescriptionID: p.ID, Grams: 15, PurchasedAt: time.Date(2024, time.February, 12, 0, 0, 0, 0, time.UTC)},
+		{Grams: 5, PurchasedAt: time.Date(2024, time.February, 13, 0, 0, 0, 0, time.UTC)}, // Self-paid
+	}}
+	at := time.Date(2024, time.February, 20, 0, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, 15.0, p.Used([]*Strain{s}, at))
+	assert.Equal(t, 15.0, p.Remaining([]*Strain{s}, at))
+
+	s.Batches = append(s.Batches, &Batch{PrescriptionID: p.ID, Grams: 20, PurchasedAt: at})
+	assert.Equal(t, 0.0, p.Remaining([]*Strain{s}, at))
+}
@@ -0,0 +1,120 @@
+package cannabis
+
+import (
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+// StrainTerpene is a terpene contained in a strain, together with its content.
+// Only the terpene type is stored, the terpene itself is resolved from the
+// catalog.
+type StrainTerpene struct {
+	Type    TerpeneType `yaml:"id" json:"id"`                               // The terpene type
+	Percent float64     `yaml:"percent,omitempty" json:"percent,omitempty"` // The terpene content in %, zero if unknown
+	missing string      // The identifier of a terpene missing from the catalog
+}
+
+// Terpene returns the terpene of the given type from the catalog, or nil if
+// the terpene is missing from the catalog.
+func (t *StrainTerpene) Terpene() *Terpene {
+	if t.missing != "" {
+		return nil
+	}
+	return Terpenes[t.Type]
+}
+
+// ID returns the stable identifier of the terpene, also if it is missing from
+// the catalog.
+func (t *StrainTerpene) ID() string {
+	if t.missing != "" {
+		return t.missing
+	}
+	return t.Type.ID()
+}
+
+// Name returns the name of the terpene, or its identifier if it is missing from
+// the catalog.
+func (t *StrainTerpene) Name() string {
+	if tp := t.Terpene(); tp != nil {
+		return tp.Name
+	}
+	return t.ID()
+}
+
+// MarshalYAML encodes a strain terpene. A terpene missing from the catalog is
+// stored with the identifier it was read with.
+func (t StrainTerpene) MarshalYAML() (any, error) {
+	type stored StrainTerpene
+	if t.missing == "" {
+		return stored(t), nil
+	}
+	return struct {
+		ID      string  `yaml:"id"`
+		Percent float64 `yaml:"percent,omitempty"`
+	}{t.missing, t.Percent}, nil
+}
+
+// UnmarshalYAML decodes a strain terpene. Strains stored before the terpene
+// identifiers were introduced contain the full terpene, which is resolved by
+// its name. A terpene whose identifier is missing from the catalog, e.g. after
+// it was removed from the user catalog, is kept as it is, so that storing the
+// strain again does not lose it.
+func (t *StrainTerpene) UnmarshalYAML(value *yaml.Node) error {
+	var stored struct {
+		ID      string  `yaml:"id"`
+		Name    string  `yaml:"name"`
+		Percent float64 `yaml:"percent"`
+	}
+	if err := value.Decode(&stored); err != nil {
+		return err
+	}
+	var err error
+	if stored.ID != "" {
+		if t.Type, err = ParseTerpeneType(stored.ID); err != nil {
+			t.missing, err = stored.ID, nil
+		}
+	} else {
+		t.Type, err = terpeneTypeByName(stored.Name)
+	}
+	t.Percent = stored.Percent
+	return err
+}
+
+// DominantTerpene returns the terpene with the highest content in the strain.
+// It returns nil if the content of no terpene is known.
+func (s *Strain) DominantTerpene() *StrainTerpene {
+	var dominant *StrainTerpene
+	for _, t := range s.Terpenes {
+		if t.Percent > 0 && (dominant == nil || t.Percent > dominant.Percent) {
+			dominant = t
+		}
+	}
+	return dominant
+}
+
+// SortedCannabinoids returns the cannabinoids of the profile of the strain,
+// ordered by their content, highest first.
+func (s *Strain) SortedCannabinoids() []CannabinoidType {
+	var types []CannabinoidType
+	for c := range s.Cannabinoids {
+		types = append(types, c)
+	}
+	sort.Slice(types, func(i, j int) bool {
+		if s.Cannabinoids[types[i]] != s.Cannabinoids[types[j]] {
+			return s.Cannabinoids[types[i]] > s.Cannabinoids[types[j]]
+		}
+		return types[i] < types[j]
+	})
+	return types
+}
+
+// SortedTerpenes returns the terpenes of the strain, ordered by their content,
+// highest first. Terpenes of unknown content keep their order at the end.
+func (s *Strain) SortedTerpenes() []*StrainTerpene {
+	terpenes := append([]*StrainTerpene(nil), s.Terpenes...)
+	sort.SliceStable(terpenes, func(i, j int) bool {
+		return terpenes[i].Percent > terpenes[j].Percent
+	})
+	return terpenes
+}
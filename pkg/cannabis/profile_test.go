@@ -0,0 +1,28 @@
+package cannabis
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStrain_DominantTerpene(t *testing.T) {
+	limonene := &StrainTerpene{Type: Limonene, Percent: 0.4}
+	myrcene := &StrainTerpene{Type: BetaMyrcene, Percent: 0.8}
+	linalool := &StrainTerpene{Type: Linalool}
+
+	assert.Nil(t, (&Strain{}).DominantTerpene())
+	assert.Nil(t, (&Strain{Terpenes: []*StrainTerpene{linalool}}).DominantTerpene())
+
+	s := &Strain{Terpenes: []*StrainTerpene{linalool, limonene, myrcene}}
+	assert.Equal(t, myrcene, s.DominantTerpene())
+	assert.Equal(t, []*StrainTerpene{myrcene, limonene, linalool}, s.SortedTerpenes())
+	// The terpenes of the strain keep their order
+	assert.Equal(t, linalool, s.Terpenes[0])
+}
+
+func TestStrain_SortedCannabinoids(t *testing.T) {
+	s := &Strain{Cannabinoids: map[CannabinoidType]float64{CBG: 0.8, THCA: 22.1, CBN: 0.8}}
+
+	assert.Equal(t, []CannabinoidType{THCA, CBN, CBG}, s.SortedCannabinoids())
+}
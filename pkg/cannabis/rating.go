@@ -0,0 +1,93 @@
+package cannabis
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaxRating is the number of stars of the best rating.
+const MaxRating = 5
+
+// Rating is a rating of a strain with tasting notes. Every aspect is rated
+// from 1 to MaxRating stars, 0 if it was not rated.
+type Rating struct {
+	ID      uuid.UUID // The unique identifier
+	Overall int       // The overall rating
+	Taste   int       // The rating of the taste and smell
+	Effect  int       // The rating of the effect
+	Value   int       // The rating of the value for money
+	Notes   string    // The free-form tasting notes
+	Tags    []string  // The tags describing the strain (e.g. smooth, harsh)
+	RatedAt time.Time // The timestamp of the rating
+}
+
+// RatingAverages are the average ratings of a strain per aspect. Aspects which
+// were never rated average 0.
+type RatingAverages struct {
+	Overall float64 // The average overall rating
+	Taste   float64 // The average rating of the taste
+	Effect  float64 // The average rating of the effect
+	Value   float64 // The average rating of the value
+	Count   int     // The number of ratings
+}
+
+// AddRating adds the given rating to the strain.
+func (s *Strain) AddRating(r *Rating) {
+	s.Ratings = append(s.Ratings, r)
+}
+
+// AverageRatings returns the average of all ratings of the strain per aspect.
+func (s *Strain) AverageRatings() RatingAverages {
+	avg := RatingAverages{Count: len(s.Ratings)}
+	var overall, taste, effect, value []int
+	for _, r := range s.Ratings {
+		overall = appendRated(overall, r.Overall)
+		taste = appendRated(taste, r.Taste)
+		effect = appendRated(effect, r.Effect)
+		value = appendRated(value, r.Value)
+	}
+	avg.Overall = average(overall)
+	avg.Taste = average(taste)
+	avg.Effect = average(effect)
+	avg.Value = average(value)
+	return avg
+}
+
+// Tags returns the distinct tags of all ratings of the strain, sorted
+// alphabetically.
+func (s *Strain) Tags() []string {
+	var tags []string
+	for _, r := range s.Ratings {
+		for _, t := range r.Tags {
+			t = strings.ToLower(strings.TrimSpace(t))
+			if t != "" && !slices.Contains(tags, t) {
+				tags = append(tags, t)
+			}
+		}
+	}
+	slices.Sort(tags)
+	return tags
+}
+
+// appendRated appends the given stars if the aspect was rated.
+func appendRated(stars []int, s int) []int {
+	if s <= 0 {
+		return stars
+	}
+	return append(stars, s)
+}
+
+// average returns the average of the given stars, or 0 if there are none.
+func average(stars []int) float64 {
+	if len(stars) == 0 {
+		return 0
+	}
+	sum := 0
+	for _, s := range stars {
+		sum += s
+	}
+	return float64(sum) / float64(len(stars))
+}
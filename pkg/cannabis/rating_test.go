@@ -0,0 +1,24 @@
+package cannabis
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStrain_AverageRatings(t *testing.T) {
+	s := &Strain{}
+	assert.Equal(t, RatingAverages{}, s.AverageRatings())
+
+	s.AddRating(&Rating{Overall: 4, Taste: 5, Effect: 3, Tags: []string{"Smooth", "citrus"}})
+	s.AddRating(&Rating{Overall: 3, Taste: 4, Value: 2, Tags: []string{" smooth ", ""}})
+
+	avg := s.AverageRatings()
+
+	assert.Equal(t, 2, avg.Count)
+	assert.InDelta(t, 3.5, avg.Overall, 1e-9)
+	assert.InDelta(t, 4.5, avg.Taste, 1e-9)
+	assert.InDelta(t, 3, avg.Effect, 1e-9, "aspects which were not rated are not averaged")
+	assert.InDelta(t, 2, avg.Value, 1e-9)
+	assert.Equal(t, []string{"citrus", "smooth"}, s.Tags())
+}
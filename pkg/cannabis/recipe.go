@@ -0,0 +1,160 @@
+package cannabis
+
+import (
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DecarbCurve describes the decarboxylation of a cannabinoid acid in the oven.
+// The acid converts to its neutral cannabinoid, which itself degrades when
+// heated further (e.g. THC to CBN). Both reactions are of first order, their
+// rates growing by the factor Q10 with every 10 °C above the reference
+// temperature.
+type DecarbCurve struct {
+	Acid                CannabinoidType // The cannabinoid acid
+	Product             CannabinoidType // The neutral cannabinoid the acid converts to
+	ConversionHalfLife  float64         // The minutes until half of the acid is converted at the reference temperature
+	DegradationHalfLife float64         // The minutes until half of the product is degraded at the reference temperature
+	Q10                 float64         // The factor the reaction rates grow by with every 10 °C
+}
+
+// DecarbCurves is a collection of the decarboxylation curves of the acids,
+// keyed by the acid. At the boiling point of the acid, 95% are converted
+// within the time given in its notes.
+var DecarbCurves = map[CannabinoidType]DecarbCurve{
+	THCA: {
+		Acid:                THCA,
+		Product:             Delta9THC,
+		ConversionHalfLife:  7,
+		DegradationHalfLife: 600,
+		Q10:                 2.5},
+	CBDA: {
+		Acid:                CBDA,
+		Product:             CBD,
+		ConversionHalfLife:  14,
+		DegradationHalfLife: 900,
+		Q10:                 2.5},
+}
+
+// ReferenceTemperature returns the temperature in °C the half-lives of the
+// curve are given for, which is the boiling point of the acid.
+func (c DecarbCurve) ReferenceTemperature() int {
+	return Cannabinoids[c.Acid].BoilingPoint
+}
+
+// Efficiency returns the share of the acid available as its neutral
+// cannabinoid after heating it at the given temperature in °C for the given
+// minutes.
+func (c DecarbCurve) Efficiency(temperature int, minutes float64) float64 {
+	if minutes <= 0 {
+		return 0
+	}
+	speedup := math.Pow(c.Q10, float64(temperature-c.ReferenceTemperature())/10)
+	k1 := math.Ln2 / c.ConversionHalfLife * speedup
+	k2 := math.Ln2 / c.DegradationHalfLife * speedup
+	if k1 == k2 {
+		return k1 * minutes * math.Exp(-k1*minutes)
+	}
+	// The consecutive reactions acid -> product -> degraded
+	return k1 / (k2 - k1) * (math.Exp(-k1*minutes) - math.Exp(-k2*minutes))
+}
+
+// CarrierType is the enum for the fats the cannabinoids are infused into.
+type CarrierType int
+
+const (
+	// Butter is (clarified) butter
+	Butter CarrierType = iota
+	// CoconutOil is coconut oil
+	CoconutOil
+	// OliveOil is olive oil
+	OliveOil
+)
+
+// Carrier is the type for a fat the cannabinoids are infused into.
+type Carrier struct {
+	Name       string  // The carriers name
+	Extraction float64 // The share of the cannabinoids extracted into the carrier
+}
+
+// Carriers is a collection of all known carriers.
+var Carriers = map[CarrierType]Carrier{
+	Butter:     {Name: "Butter", Extraction: 0.75},
+	CoconutOil: {Name: "Coconut oil", Extraction: 0.85},
+	OliveOil:   {Name: "Olive oil", Extraction: 0.8}}
+
+// Ingredient is the type for a strain used in a recipe.
+type Ingredient struct {
+	StrainID uuid.UUID // The unique identifier of the strain
+	Strain   string    // The product name of the strain
+	Grams    float64   // The amount of flower in grams
+	THC      float64   // The THC content of the strain in %
+	CBD      float64   // The CBD content of the strain in %
+}
+
+// Recipe is the type for an edibles recipe, decarboxylating flower in the oven
+// and infusing it into a carrier.
+type Recipe struct {
+	ID          uuid.UUID     // The unique identifier
+	Name        string        // The name of the recipe
+	Ingredients []*Ingredient // The strains used
+	Temperature int           // The oven temperature in °C
+	Minutes     int           // The time in the oven in minutes
+	Carrier     CarrierType   // The carrier the cannabinoids are infused into
+	Servings    int           // The number of servings
+	Notes       string        // Free text notes
+	CreatedAt   time.Time     // The creation timestamp
+	UpdatedAt   time.Time     // The last update timestamp
+}
+
+// RecipeYield is the expected amount of cannabinoids of a recipe.
+type RecipeYield struct {
+	THCEfficiency float64 // The share of the THCA available as THC after decarboxylation
+	CBDEfficiency float64 // The share of the CBDA available as CBD after decarboxylation
+	THC           float64 // The THC of all servings in mg
+	CBD           float64 // The CBD of all servings in mg
+	THCPerServing float64 // The THC per serving in mg
+	CBDPerServing float64 // The CBD per serving in mg
+}
+
+// NewIngredient returns the given grams of the given strain as an ingredient,
+// remembering the content of the strain.
+func NewIngredient(s *Strain, grams float64) *Ingredient {
+	return &Ingredient{StrainID: s.ID, Strain: s.Strain, Grams: grams, THC: s.THC, CBD: s.CBD}
+}
+
+// Uses reports whether the recipe uses the strain with the given ID.
+func (r *Recipe) Uses(id uuid.UUID) bool {
+	for _, i := range r.Ingredients {
+		if i.StrainID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// Yield returns the expected amount of cannabinoids of the recipe. Raw flower
+// contains its cannabinoids almost entirely as acids, so the THC and CBD
+// content of the strains, given as the total after full decarboxylation, is
+// scaled by the efficiency of the decarboxylation and the extraction into the
+// carrier.
+func (r *Recipe) Yield() RecipeYield {
+	minutes := float64(r.Minutes)
+	y := RecipeYield{
+		THCEfficiency: DecarbCurves[THCA].Efficiency(r.Temperature, minutes),
+		CBDEfficiency: DecarbCurves[CBDA].Efficiency(r.Temperature, minutes),
+	}
+	extraction := Carriers[r.Carrier].Extraction
+	for _, i := range r.Ingredients {
+		thc, cbd := Milligrams(i.Grams, Potency{THC: i.THC, CBD: i.CBD})
+		y.THC += thc * y.THCEfficiency * extraction
+		y.CBD += cbd * y.CBDEfficiency * extraction
+	}
+	if r.Servings > 0 {
+		y.THCPerServing = y.THC / float64(r.Servings)
+		y.CBDPerServing = y.CBD / float64(r.Servings)
+	}
+	return y
+}
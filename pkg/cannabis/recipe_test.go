@@ -0,0 +1,48 @@
+package cannabis
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecarbCurve_Efficiency(t *testing.T) {
+	thca := DecarbCurves[THCA]
+
+	t.Run("ReferenceTemperature", func(t *testing.T) {
+		assert.Equal(t, 120, thca.ReferenceTemperature())
+		assert.Equal(t, 130, DecarbCurves[CBDA].ReferenceTemperature())
+	})
+
+	t.Run("Curve", func(t *testing.T) {
+		assert.Zero(t, thca.Efficiency(120, 0))
+		// Most of the acid is converted within the 30 minutes of the notes
+		assert.InDelta(t, 0.925, thca.Efficiency(120, 30), 0.005)
+		// Lower temperatures need longer
+		assert.Less(t, thca.Efficiency(100, 30), thca.Efficiency(120, 30))
+		// Too long at too high temperatures degrades the THC
+		assert.Less(t, thca.Efficiency(160, 120), thca.Efficiency(120, 40))
+	})
+}
+
+func TestRecipe_Yield(t *testing.T) {
+	s := &Strain{ID: uuid.New(), Strain: "Pink Kush", THC: 20, CBD: 1}
+	r := &Recipe{
+		Ingredients: []*Ingredient{NewIngredient(s, 2)},
+		Temperature: 120,
+		Minutes:     30,
+		Carrier:     CoconutOil,
+		Servings:    10,
+	}
+
+	y := r.Yield()
+
+	eff := DecarbCurves[THCA].Efficiency(120, 30)
+	assert.InDelta(t, eff, y.THCEfficiency, 1e-9)
+	assert.InDelta(t, 400*eff*0.85, y.THC, 1e-9)
+	assert.InDelta(t, 40*eff*0.85, y.THCPerServing, 1e-9)
+	assert.InDelta(t, 2*y.CBDEfficiency*0.85, y.CBDPerServing, 1e-9)
+	assert.True(t, r.Uses(s.ID))
+	assert.False(t, r.Uses(uuid.New()))
+}
@@ -0,0 +1,105 @@
+package cannabis
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TimeOfDayLayout is the layout of the times of day of a reminder.
+const TimeOfDayLayout = "15:04"
+
+// ReminderKind is the type for the kinds of reminders.
+type ReminderKind string
+
+const (
+	// DoseReminder reminds to take a scheduled dose
+	DoseReminder ReminderKind = "dose"
+	// RefillReminder reminds to renew a prescription which is about to expire
+	// or whose quota is nearly used up
+	RefillReminder ReminderKind = "refill"
+	// ReorderReminder reminds to reorder a strain before the stock runs out
+	ReorderReminder ReminderKind = "reorder"
+	// CheckInReminder reminds of the daily check-in during a tolerance break
+	CheckInReminder ReminderKind = "check-in"
+)
+
+// ReminderKinds are all kinds of reminders, in the order they are offered.
+var ReminderKinds = []ReminderKind{DoseReminder, RefillReminder, ReorderReminder, CheckInReminder}
+
+// ParseReminderKind returns the reminder kind with the given name, or an error
+// if there is none.
+func ParseReminderKind(name string) (ReminderKind, error) {
+	if k := ReminderKind(name); slices.Contains(ReminderKinds, k) {
+		return k, nil
+	}
+	return "", fmt.Errorf("unknown reminder kind %q", name)
+}
+
+// Reminder is the type for a scheduled reminder. It is scheduled at the given
+// times of every day, or only of the given weekdays. Dose reminders are due at
+// every scheduled time, while the other kinds are only due if their condition
+// holds at the scheduled time.
+type Reminder struct {
+	ID         uuid.UUID      // The unique identifier
+	Kind       ReminderKind   // The kind of the reminder
+	Times      []string       // The times of day (HH:MM) the reminder is scheduled at
+	Weekdays   []time.Weekday `yaml:",omitempty"` // The scheduled weekdays, every day if empty
+	Strain     string         `yaml:",omitempty"` // The strain to dose or reorder, any strain if empty
+	Grams      float64        `yaml:",omitempty"` // The amount of a dose in grams
+	LeadDays   int            `yaml:",omitempty"` // The days ahead to refill or reorder
+	Notes      string         `yaml:",omitempty"` // Free text notes
+	NotifiedAt time.Time      // The timestamp of the last notification
+	CreatedAt  time.Time      // The creation timestamp
+}
+
+// ParseTimeOfDay parses the given time of day (HH:MM) and returns the hour
+// and minute.
+func ParseTimeOfDay(s string) (int, int, error) {
+	t, err := time.Parse(TimeOfDayLayout, s)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid time of day %q, expected HH:MM", s)
+	}
+	return t.Hour(), t.Minute(), nil
+}
+
+// LastOccurrence returns the latest scheduled time of the reminder at or before
+// the given time within the past week. It returns false if there is none, e.g.
+// if no valid times are scheduled.
+func (r *Reminder) LastOccurrence(at time.Time) (time.Time, bool) {
+	var last time.Time
+	day := dayOf(at)
+	for i := 0; i < 7 && last.IsZero(); i++ {
+		d := day.AddDate(0, 0, -i)
+		if len(r.Weekdays) > 0 && !slices.Contains(r.Weekdays, d.Weekday()) {
+			continue
+		}
+		for _, s := range r.Times {
+			h, m, err := ParseTimeOfDay(s)
+			if err != nil {
+				continue
+			}
+			t := time.Date(d.Year(), d.Month(), d.Day(), h, m, 0, 0, d.Location())
+			if !t.After(at) && t.After(last) {
+				last = t
+			}
+		}
+	}
+	return last, !last.IsZero()
+}
+
+// Pending reports whether a scheduled time of the reminder has passed at the
+// given time since its last notification or creation.
+func (r *Reminder) Pending(at time.Time) bool {
+	last, ok := r.LastOccurrence(at)
+	if !ok {
+		return false
+	}
+	since := r.CreatedAt
+	if r.NotifiedAt.After(since) {
+		since = r.NotifiedAt
+	}
+	return last.After(since)
+}
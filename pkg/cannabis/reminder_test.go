@@ -0,0 +1,54 @@
+package cannabis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseReminderKind(t *testing.T) {
+	k, err := ParseReminderKind("check-in")
+	require.NoError(t, err)
+	assert.Equal(t, CheckInReminder, k)
+
+	_, err = ParseReminderKind("nap")
+	assert.Error(t, err)
+}
+
+func TestReminder_LastOccurrence(t *testing.T) {
+	// 2024-03-13 is a Wednesday
+	at := time.Date(2024, time.March, 13, 12, 0, 0, 0, time.UTC)
+	r := &Reminder{Times: []string{"20:00", "08:00", "invalid"}}
+
+	last, ok := r.LastOccurrence(at)
+	require.True(t, ok)
+	assert.Equal(t, time.Date(2024, time.March, 13, 8, 0, 0, 0, time.UTC), last)
+
+	last, ok = r.LastOccurrence(time.Date(2024, time.March, 13, 7, 0, 0, 0, time.UTC))
+	require.True(t, ok)
+	assert.Equal(t, time.Date(2024, time.March, 12, 20, 0, 0, 0, time.UTC), last, "the previous day")
+
+	r.Weekdays = []time.Weekday{time.Monday}
+	last, ok = r.LastOccurrence(at)
+	require.True(t, ok)
+	assert.Equal(t, time.Date(2024, time.March, 11, 20, 0, 0, 0, time.UTC), last)
+
+	_, ok = (&Reminder{}).LastOccurrence(at)
+	assert.False(t, ok)
+}
+
+func TestReminder_Pending(t *testing.T) {
+	at := time.Date(2024, time.March, 13, 12, 0, 0, 0, time.UTC)
+	r := &Reminder{Times: []string{"08:00"}, CreatedAt: at.AddDate(0, 0, -1)}
+
+	assert.True(t, r.Pending(at))
+
+	r.NotifiedAt = time.Date(2024, time.March, 13, 8, 1, 0, 0, time.UTC)
+	assert.False(t, r.Pending(at), "already notified")
+	assert.True(t, r.Pending(at.AddDate(0, 0, 1)))
+
+	r = &Reminder{Times: []string{"08:00"}, CreatedAt: at}
+	assert.False(t, r.Pending(at), "scheduled before its creation")
+}
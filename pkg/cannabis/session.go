@@ -0,0 +1,60 @@
+package cannabis
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Session is the type for a consumption session of a strain.
+type Session struct {
+	ID        uuid.UUID         // The unique identifier
+	Strain    string            // The product name of the consumed strain
+	Grams     float64           // The consumed amount in grams
+	Method    ConsumptionMethod // The consumption method
+	THC       float64           // The consumed THC in mg
+	CBD       float64           // The consumed CBD in mg
+	StartedAt time.Time         // The start of the session
+	Notes     string            // Free text notes
+	CreatedAt time.Time         // The creation timestamp
+	// The journal of the session, omitted for sessions without one
+	Symptoms    []*SymptomScore `yaml:",omitempty"` // The rated symptoms
+	Effects     []string        `yaml:",omitempty"` // The perceived effects
+	SideEffects []string        `yaml:",omitempty"` // The perceived side effects
+}
+
+// MaxSymptomScore is the score of the worst severity of a symptom.
+const MaxSymptomScore = 10
+
+// SymptomScore rates the severity of a symptom before and after a session,
+// from 0 (none) to MaxSymptomScore (worst).
+type SymptomScore struct {
+	Symptom string // The name of the symptom (e.g. pain)
+	Before  int    // The severity before the session
+	After   int    // The severity after the session
+}
+
+// Relief returns the decrease of the severity of the symptom by the session,
+// negative if the symptom got worse.
+func (s SymptomScore) Relief() int {
+	return s.Before - s.After
+}
+
+// Consume removes the given grams from the stock of the strain. The grams are
+// taken from the batches in the order they were added, so that older stock is
+// used up first. The stock never drops below zero.
+func (s *Strain) Consume(grams float64) {
+	if len(s.Batches) == 0 {
+		s.Amount = max(s.Amount-grams, 0)
+		return
+	}
+	for _, b := range s.Batches {
+		if grams <= 0 {
+			break
+		}
+		taken := min(b.Remaining, grams)
+		b.Remaining -= taken
+		grams -= taken
+	}
+	s.Recalculate()
+}
@@ -0,0 +1,46 @@
+package cannabis
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStrain_Consume(t *testing.T) {
+	t.Run("WithoutBatches", func(t *testing.T) {
+		s := &Strain{Amount: 1}
+		s.Consume(0.4)
+		assert.InDelta(t, 0.6, s.Amount, 1e-9)
+
+		s.Consume(1)
+		assert.Zero(t, s.Amount)
+	})
+
+	t.Run("OldestBatchFirst", func(t *testing.T) {
+		s := &Strain{}
+		s.AddBatch(&Batch{Grams: 1, Remaining: 1, THC: 20})
+		s.AddBatch(&Batch{Grams: 5, Remaining: 5, THC: 26})
+
+		s.Consume(1.5)
+
+		assert.Zero(t, s.Batches[0].Remaining)
+		assert.InDelta(t, 4.5, s.Batches[1].Remaining, 1e-9)
+		assert.InDelta(t, 4.5, s.Amount, 1e-9)
+		assert.InDelta(t, 26, s.THC, 1e-9)
+	})
+
+	t.Run("MoreThanInStock", func(t *testing.T) {
+		s := &Strain{}
+		s.AddBatch(&Batch{Grams: 1, Remaining: 1})
+
+		s.Consume(3)
+
+		assert.Zero(t, s.Amount)
+		assert.Zero(t, s.Batches[0].Remaining)
+	})
+}
+
+func TestSymptomScore_Relief(t *testing.T) {
+	assert.Equal(t, 4, SymptomScore{Symptom: "pain", Before: 7, After: 3}.Relief())
+	assert.Equal(t, -2, SymptomScore{Symptom: "nausea", Before: 1, After: 3}.Relief())
+}
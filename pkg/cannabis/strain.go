@@ -2,42 +2,53 @@ package cannabis
 
 import (
 	"fmt"
+	"maps"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"gopkg.in/yaml.v3"
 )
 
 // Strain is the type for a cannabis strain.
 type Strain struct {
-	ID           uuid.UUID   // The unique identifier
-	Strain       string      // The product name
-	Cultivar     string      // The breed
-	Manufacturer string      // The producer / importer
-	Country      string      // The country of origin
-	Genetic      GeneticType // The genetic type
-	Radiated     bool        // If the strain was radiation treated
-	THC          float64     // The THC content in %
-	CBD          float64     // The CBD content in %
-	Terpenes     []*Terpene  // The terpenes in the strain
-	Amount       float64     // The amount in grams
-	CreatedAt    time.Time   // The creation timestamp
-	UpdatedAt    time.Time   // The last update timestamp
+	ID           uuid.UUID                   // The unique identifier
+	Strain       string                      // The product name
+	Cultivar     string                      // The name of the cultivar (breed)
+	Manufacturer string                      // The producer / importer
+	Country      string                      // The country of origin
+	Genetic      GeneticType                 // The genetic type
+	Treatment    Treatment                   // The treatment against germs, e.g. an irradiation
+	THC          float64                     // The THC content in %
+	CBD          float64                     // The CBD content in %
+	Cannabinoids map[CannabinoidType]float64 // The lab tested cannabinoid profile in %
+	Terpenes     []*StrainTerpene            // The terpenes in the strain
+	Amount       float64                     // The amount in grams
+	Batches      []*Batch                    // The purchased batches
+	Ratings      []*Rating                   `yaml:",omitempty"` // The ratings with tasting notes
+	Favorite     bool                        `yaml:",omitempty"` // If the strain is pinned to the top of the list
+	CreatedAt    time.Time                   // The creation timestamp
+	UpdatedAt    time.Time                   // The last update timestamp
+
+	missingCannabinoids map[string]float64 // The profile of cannabinoids missing from the catalog, by identifier
+	legacy              bool               // Whether the strain was read in the format of an older version
 }
 
 // String returns a formatted string representation of a Strain.
 func (s Strain) String() string {
 	var terpeneNames []string
 	for _, t := range s.Terpenes {
-		terpeneNames = append(terpeneNames, t.Name)
+		terpeneNames = append(terpeneNames, t.Name())
 	}
 
 	return fmt.Sprintf(
-		"ID: %s \nStrain: %s (%s)\nManufacturer: %s (%s)\nGenetic: %s | Radiated: %t\nTHC: %.2f%% | CBD: %.2f%%\nTerpenes: %s\nAmount: %.2fg\nCreatedAt: %s | UpdatedAt: %s\n",
+		"ID: %s \nStrain: %s (%s)\nManufacturer: %s (%s)\nGenetic: %s | Treatment: %s\nTHC: %.2f%% | CBD: %.2f%%\nTerpenes: %s\nAmount: %.2fg\nCreatedAt: %s | UpdatedAt: %s\n",
 		s.ID.String(),
 		s.Strain, s.Cultivar,
 		s.Manufacturer, s.Country,
-		Genetics[s.Genetic], s.Radiated,
+		Genetics[s.Genetic], Treatments[s.Treatment.Type],
 		s.THC, s.CBD,
 		strings.Join(terpeneNames, ", "),
 		s.Amount,
@@ -45,6 +56,154 @@ func (s Strain) String() string {
 	)
 }
 
+// Legacy reports whether the strain was read in the format of an older
+// version, so that it has to be stored again to migrate it to the current
+// format.
+func (s *Strain) Legacy() bool {
+	return s.legacy
+}
+
+// MissingCatalogIDs returns the identifiers of the cannabinoids and terpenes of
+// the strain which are missing from the catalog, sorted.
+func (s *Strain) MissingCatalogIDs() []string {
+	ids := slices.Collect(maps.Keys(s.missingCannabinoids))
+	for _, t := range s.Terpenes {
+		if t.Terpene() == nil {
+			ids = append(ids, t.ID())
+		}
+	}
+	slices.Sort(ids)
+	return slices.Compact(ids)
+}
+
+// MarshalYAML encodes a strain. Cannabinoids missing from the catalog are
+// stored in the profile with the identifier they were read with.
+func (s Strain) MarshalYAML() (any, error) {
+	type stored Strain
+	if len(s.missingCannabinoids) == 0 {
+		return stored(s), nil
+	}
+	var node yaml.Node
+	if err := node.Encode(stored(s)); err != nil {
+		return nil, err
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value != "cannabinoids" {
+			continue
+		}
+		profile := node.Content[i+1]
+		profile.Style = 0
+		for _, id := range slices.Sorted(maps.Keys(s.missingCannabinoids)) {
+			var k, v yaml.Node
+			if err := k.Encode(id); err != nil {
+				return nil, err
+			}
+			if err := v.Encode(s.missingCannabinoids[id]); err != nil {
+				return nil, err
+			}
+			profile.Content = append(profile.Content, &k, &v)
+		}
+	}
+	return &node, nil
+}
+
+// UnmarshalYAML decodes a strain. Strains stored before treatments were
+// introduced only state whether they were radiated, which is migrated to an
+// irradiation by an unspecified method. Not being radiated was the default of
+// the form and does not rule out other treatments, so it is migrated to an
+// unknown treatment. Cannabinoids missing from the catalog, e.g. after they
+// were removed from the user catalog, are kept aside, so that storing the
+// strain again does not lose them.
+func (s *Strain) UnmarshalYAML(value *yaml.Node) error {
+	type stored Strain
+	s.legacy = legacyFormat(value)
+	missing, err := missingProfile(value)
+	if err != nil {
+		return err
+	}
+	s.missingCannabinoids = missing
+	if err := value.Decode((*stored)(s)); err != nil {
+		return err
+	}
+	var legacy struct {
+		Radiated bool `yaml:"radiated"`
+	}
+	if err := value.Decode(&legacy); err != nil {
+		return err
+	}
+	if legacy.Radiated && s.Treatment.Type == UnknownTreatment {
+		s.Treatment.Type = Irradiated
+	}
+	return nil
+}
+
+// legacyFormat reports whether the given strain node was stored by an older
+// version: with the radiated flag instead of a treatment, with full terpenes
+// instead of their identifiers or with a profile keyed by the enum values of
+// the cannabinoids.
+func legacyFormat(value *yaml.Node) bool {
+	for i := 0; i+1 < len(value.Content); i += 2 {
+		key, field := value.Content[i].Value, value.Content[i+1]
+		switch {
+		case key == "radiated":
+			return true
+		case key == "terpenes" && field.Kind == yaml.SequenceNode:
+			for _, t := range field.Content {
+				if !hasKey(t, "id") {
+					return true
+				}
+			}
+		case key == "cannabinoids" && field.Kind == yaml.MappingNode:
+			for j := 0; j < len(field.Content); j += 2 {
+				if _, err := strconv.Atoi(field.Content[j].Value); err == nil {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// hasKey reports whether the given mapping node contains the given key.
+func hasKey(node *yaml.Node, key string) bool {
+	for i := 0; i < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return true
+		}
+	}
+	return false
+}
+
+// missingProfile removes the cannabinoids missing from the catalog from the
+// profile of the given strain node and returns their content by identifier.
+func missingProfile(value *yaml.Node) (map[string]float64, error) {
+	var missing map[string]float64
+	for i := 0; i+1 < len(value.Content); i += 2 {
+		if value.Content[i].Value != "cannabinoids" || value.Content[i+1].Kind != yaml.MappingNode {
+			continue
+		}
+		profile := value.Content[i+1]
+		var known []*yaml.Node
+		for j := 0; j+1 < len(profile.Content); j += 2 {
+			var c CannabinoidType
+			if c.UnmarshalText([]byte(profile.Content[j].Value)) == nil {
+				known = append(known, profile.Content[j], profile.Content[j+1])
+				continue
+			}
+			var percent float64
+			if err := profile.Content[j+1].Decode(&percent); err != nil {
+				return nil, err
+			}
+			if missing == nil {
+				missing = map[string]float64{}
+			}
+			missing[profile.Content[j].Value] = percent
+		}
+		profile.Content = known
+	}
+	return missing, nil
+}
+
 // GeneticType is the enum for the genetic types
 type GeneticType int
 
@@ -89,85 +248,25 @@ const (
 	THCV
 	// CBC is Cannabichromene, a non-psychoactive compound in cannabis
 	CBC
+	// CBGA is Cannabigerolic acid, the precursor of the other acids
+	CBGA
+	// CBG is Cannabigerol, a non-psychoactive compound in cannabis
+	CBG
 )
 
 // Cannabinoid is the type for a cannabinoid, which is a compound found in cannabis.
 type Cannabinoid struct {
-	ShortName    string   // The cannabinoids short name
-	Name         string   // The cannabinoids full name
-	Effects      []string // The cannabinoids subjective effects
-	Notes        string   // Additional notes
-	BoilingPoint int      // The cannabinoids boiling point in degrees Celsius
+	ID           string   `yaml:"id"`            // The stable identifier used in stored data
+	ShortName    string   `yaml:"short_name"`    // The cannabinoids short name
+	Name         string   `yaml:"name"`          // The cannabinoids full name
+	Effects      []string `yaml:"effects"`       // The cannabinoids subjective effects
+	Notes        string   `yaml:"notes"`         // Additional notes
+	BoilingPoint int      `yaml:"boiling_point"` // The cannabinoids boiling point in degrees Celsius
 }
 
-// Cannabinoids is a collection of all known cannabinoids.
-var Cannabinoids = map[CannabinoidType]Cannabinoid{
-	THCA: {
-		ShortName:    "THCA",
-		Name:         "Tetrahydrocannabinolic acid",
-		Effects:      []string{"anti-inflammatory", "anti-epileptic", "anti-proliferic"},
-		Notes:        "Acid Conversion. Requires 30 mins. in the oven",
-		BoilingPoint: 120},
-	CBDA: {
-		ShortName:    "CBDA",
-		Name:         "Cannabidiolic acid",
-		Effects:      []string{"anti-inflammatory", "anti-proliferic"},
-		Notes:        "Acid Conversion. Requires 60 mins. in the oven",
-		BoilingPoint: 130},
-	CBCA: {
-		ShortName:    "CBCA",
-		Name:         "Cannabichromene acid",
-		Effects:      []string{"anti-bacterial", "anti-fungal"},
-		Notes:        "Acid Conversion. Requires 60 mins. in the oven",
-		BoilingPoint: 140},
-	Delta9THC: {
-		ShortName:    "Δ-9-THC",
-		Name:         "Tetrahydrocannabinol",
-		Effects:      []string{"psychoactive", "anti-inflammatory", "anti-emetic", "appetite stimulant", "anti-proliferic", "anti-oxidant"},
-		Notes:        "Delta 9 (Δ-9)",
-		BoilingPoint: 157},
-	CBD: {
-		ShortName:    "CBD",
-		Name:         "Cannabidiol",
-		Effects:      []string{"non-psychoactive", "anti-inflammatory", "anti-anxiety"},
-		Notes:        "Excludes Δ-8",
-		BoilingPoint: 165},
-	Delta8THC: {
-		ShortName:    "Δ-8-THC",
-		Name:         "Tetrahydrocannabinol",
-		Effects:      []string{"non-psychoactive", "neuroprotective", "anti-emetic"},
-		Notes:        "Delta 8 (Δ-8)",
-		BoilingPoint: 175},
-	CBN: {
-		ShortName:    "CBN",
-		Name:         "Cannabinol",
-		Effects:      []string{"mildly psychoactive", "anti-spasmodic", "anti-insomnia", "analgesic"},
-		Notes:        "THC degredation",
-		BoilingPoint: 185},
-	CBE: {
-		ShortName:    "CBE",
-		Name:         "Cannabielsoin",
-		Effects:      []string{"sedative", "anti-depressant", "anxiolytic"},
-		Notes:        "CBD degredation",
-		BoilingPoint: 195},
-	Benzene: {
-		ShortName:    "Benzene",
-		Name:         "Benzene",
-		Effects:      []string{"toxic", "carcinogenic"},
-		Notes:        "Avoid harmful toxic vapours",
-		BoilingPoint: 205},
-	THCV: {
-		ShortName:    "THCV",
-		Name:         "Tetrahydrocannabivarin",
-		Effects:      []string{"psychoactive", "euphoriant", "anti-thc", "analgesic", "anti-diabetic", "anorectic", "bone stimulant"},
-		Notes:        "Blocks THC",
-		BoilingPoint: 220},
-	CBC: {
-		ShortName:    "CBC",
-		Name:         "Cannabichromene",
-		Effects:      []string{"non-psychoactive", "anti-proliferative", "anti-bacterial", "bone stimulant", "anti-inflammatory", "analgesic"},
-		Notes:        "Includes THCV",
-		BoilingPoint: 220}}
+// Cannabinoids is a collection of all known cannabinoids, loaded from the
+// catalog.
+var Cannabinoids = map[CannabinoidType]Cannabinoid{}
 
 // TerpeneType is the enum for the terpene keys.
 type TerpeneType int
@@ -205,95 +304,22 @@ const (
 	Pulegone
 	// Quercetin has anti-mutagenic, anti viral, anti-oxidant and anti-neoplastic properties
 	Quercetin
+	// BetaOcimene is β-Ocimene, with anti-viral, anti-fungal and decongestant properties
+	BetaOcimene
+	// AlphaHumulene is α-Humulene, with anti-inflammatory, anti-bacterial and anorectic properties
+	AlphaHumulene
+	// Terpinolene has sedative, anti-oxidant and anti-fungal properties
+	Terpinolene
 )
 
 // Terpene is the type for a terpene, which is a compound found in cannabis.
 type Terpene struct {
-	Name         string   // The terpenes name
-	Effects      []string // The terpenes subjective effects
-	Flavors      []string // The terpenes subjective flavors
-	BoilingPoint int      // The terpenes boiling point in degrees Celsius
+	ID           string   `yaml:"id"`            // The stable identifier used in stored data
+	Name         string   `yaml:"name"`          // The terpenes name
+	Effects      []string `yaml:"effects"`       // The terpenes subjective effects
+	Flavors      []string `yaml:"flavors"`       // The terpenes subjective flavors
+	BoilingPoint int      `yaml:"boiling_point"` // The terpenes boiling point in degrees Celsius
 }
 
-// Terpenes is a collection of all known terpenes.
-var Terpenes = map[TerpeneType]*Terpene{
-	BetaCaryophyllene: {
-		Name:         "β-Caryophyllene",
-		Effects:      []string{"anti-malarial", "cytoprotective", "anti-inflammatory"},
-		Flavors:      []string{"pepper", "spicy", "wood"},
-		BoilingPoint: 130},
-	BetaSitosterol: {
-		Name:         "β-Sitosterol",
-		Effects:      []string{"anti-inflammatory", "5-α-reductase inhibitor"},
-		Flavors:      []string{"herbal", "earthy"},
-		BoilingPoint: 140},
-	AlphaPinene: {
-		Name:         "α-Pinene",
-		Effects:      []string{"anti-inflammatory", "bone stimulant", "anti-biotic", "bronchodilator", "anti-neoplatic"},
-		Flavors:      []string{"pine", "rosemary", "sage"},
-		BoilingPoint: 157},
-	BetaMyrcene: {
-		Name:         "β-Myrcene",
-		Effects:      []string{"analgesic", "anti-biotic", "anti-mutagenic", "anti-inflammatory"},
-		Flavors:      []string{"musk", "earth", "herbal"},
-		BoilingPoint: 165},
-	Delta3Carene: {
-		Name:         "Δ-3-Carene",
-		Effects:      []string{"anti-inflammatory"},
-		Flavors:      []string{"sweet", "pine", "cedar"},
-		BoilingPoint: 165},
-	Eucalyptol: {
-		Name:         "Eucalyptol",
-		Effects:      []string{"blood flow stimulant"},
-		Flavors:      []string{"mint", "spicy", "cool"},
-		BoilingPoint: 175},
-	Limonene: {
-		Name:         "Limonene",
-		Effects:      []string{"anti-depressant", "agonist"},
-		Flavors:      []string{"citrus", "lemon", "orange"},
-		BoilingPoint: 175},
-	PeCymene: {
-		Name:         "P-Cymene",
-		Effects:      []string{"anti-biotic", "anti-candidal"},
-		Flavors:      []string{"citrus", "herbal", "spicy"},
-		BoilingPoint: 175},
-	Apigenin: {
-		Name:         "Apigenin",
-		Effects:      []string{"estrogenic", "anxiolytic"},
-		Flavors:      []string{"herbal", "spicy", "sweet"},
-		BoilingPoint: 175},
-	CannaflavinA: {
-		Name:         "Cannaflavin A",
-		Effects:      []string{"COX inhibitor", "LO inhibitor"},
-		Flavors:      []string{"herbal", "spicy", "sweet"},
-		BoilingPoint: 185},
-	Linalool: {
-		Name:         "Linalool",
-		Effects:      []string{"sedative", "anti-depressant", "anxiolytic", "immune potentiator"},
-		Flavors:      []string{"floral", "lavender", "citrus"},
-		BoilingPoint: 195},
-	Terpinen4Ol: {
-		Name:         "Terpinen-4-ol",
-		Effects:      []string{"anti-biotic", "AChE inhibitor"},
-		Flavors:      []string{"herbal", "spicy", "sweet"},
-		BoilingPoint: 205},
-	Borneol: {
-		Name:         "Borneol",
-		Effects:      []string{"anti-biotic"},
-		Flavors:      []string{"mint", "camphor", "spicy"},
-		BoilingPoint: 205},
-	AlphaTerpineol: {
-		Name:         "α-Terpineol",
-		Effects:      []string{"sedative", "anti-biotic", "anti-oxidant", "anti-malarial"},
-		Flavors:      []string{"floral", "citrus", "apple"},
-		BoilingPoint: 220},
-	Pulegone: {
-		Name:         "Pulegone",
-		Effects:      []string{"sedative", "anti-pyretic"},
-		Flavors:      []string{"mint", "camphor", "spicy"},
-		BoilingPoint: 220},
-	Quercetin: {
-		Name:         "Quercetin",
-		Effects:      []string{"anti-mutagenic", "anti-viral", "anti-oxidant", "anti-neoplastic"},
-		Flavors:      []string{"herbal", "spicy", "sweet"},
-		BoilingPoint: 220}}
+// Terpenes is a collection of all known terpenes, loaded from the catalog.
+var Terpenes = map[TerpeneType]*Terpene{}
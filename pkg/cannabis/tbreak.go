@@ -0,0 +1,108 @@
+package cannabis
+
+import (
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ToleranceBreak is the type for a planned tolerance break (T-break), a period
+// of abstinence to lower the tolerance. It can be preceded by a tapering
+// period, in which the daily THC is reduced step by step.
+type ToleranceBreak struct {
+	ID          uuid.UUID  // The unique identifier
+	StartsAt    time.Time  // The first day of abstinence
+	Days        int        // The target length in days
+	TaperDays   int        // The number of tapering days before the start
+	BaselineTHC float64    // The absorbed THC in mg per day the tapering starts from
+	CheckIns    []*CheckIn // The daily check-ins, ordered by day
+	CreatedAt   time.Time  // The creation timestamp
+}
+
+// CheckIn is the daily check-in during a tolerance break.
+type CheckIn struct {
+	Day       time.Time // The day checked in
+	Abstained bool      // Whether nothing was consumed on the day
+	Craving   int       // The craving from 0 (none) to 10 (strong)
+	Notes     string    // Free text notes
+}
+
+// EndsAt returns the day after the last day of the break.
+func (b *ToleranceBreak) EndsAt() time.Time {
+	return dayOf(b.StartsAt).AddDate(0, 0, b.Days)
+}
+
+// TaperStartsAt returns the first day of the tapering period, which is the
+// start of the break if there is none.
+func (b *ToleranceBreak) TaperStartsAt() time.Time {
+	return dayOf(b.StartsAt).AddDate(0, 0, -b.TaperDays)
+}
+
+// Active reports whether the given time is within the break, excluding the
+// tapering period.
+func (b *ToleranceBreak) Active(at time.Time) bool {
+	return !at.Before(dayOf(b.StartsAt)) && at.Before(b.EndsAt())
+}
+
+// Tapering reports whether the given time is within the tapering period.
+func (b *ToleranceBreak) Tapering(at time.Time) bool {
+	return !at.Before(b.TaperStartsAt()) && at.Before(dayOf(b.StartsAt))
+}
+
+// Day returns the day of the break at the given time, starting at 1 on its
+// first day. Days before the start are zero or negative.
+func (b *ToleranceBreak) Day(at time.Time) int {
+	return daysBetween(dayOf(b.StartsAt), dayOf(at)) + 1
+}
+
+// TaperLimit returns the absorbed THC in mg allowed on the day of the given
+// time within the tapering period. The baseline is reduced linearly, so that
+// the last tapering day allows the smallest step before the break. It returns
+// false outside of the tapering period.
+func (b *ToleranceBreak) TaperLimit(at time.Time) (float64, bool) {
+	if !b.Tapering(at) {
+		return 0, false
+	}
+	left := daysBetween(dayOf(at), dayOf(b.StartsAt))
+	return b.BaselineTHC * float64(left) / float64(b.TaperDays+1), true
+}
+
+// CheckIn records the given check-in, replacing an earlier check-in of the
+// same day.
+func (b *ToleranceBreak) CheckIn(c *CheckIn) {
+	c.Day = dayOf(c.Day)
+	for i, existing := range b.CheckIns {
+		if existing.Day.Equal(c.Day) {
+			b.CheckIns[i] = c
+			return
+		}
+	}
+	b.CheckIns = append(b.CheckIns, c)
+	sort.Slice(b.CheckIns, func(i, j int) bool {
+		return b.CheckIns[i].Day.Before(b.CheckIns[j].Day)
+	})
+}
+
+// CheckInOn returns the check-in of the day of the given time, or nil if there
+// is none.
+func (b *ToleranceBreak) CheckInOn(at time.Time) *CheckIn {
+	day := dayOf(at)
+	for _, c := range b.CheckIns {
+		if dayOf(c.Day).Equal(day) {
+			return c
+		}
+	}
+	return nil
+}
+
+// dayOf returns the start of the day of the given time.
+func dayOf(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// daysBetween returns the number of calendar days from one day to another.
+func daysBetween(from, to time.Time) int {
+	// Rounding keeps days with a daylight saving time change whole
+	return int(to.Sub(from).Round(24*time.Hour) / (24 * time.Hour))
+}
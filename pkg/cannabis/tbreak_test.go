@@ -0,0 +1,64 @@
+package cannabis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testBreak returns a 14 day break starting on 2024-03-10 with 3 tapering
+// days from a baseline of 40 mg THC per day.
+func testBreak() *ToleranceBreak {
+	return &ToleranceBreak{
+		StartsAt:    time.Date(2024, time.March, 10, 0, 0, 0, 0, time.UTC),
+		Days:        14,
+		TaperDays:   3,
+		BaselineTHC: 40,
+	}
+}
+
+func TestToleranceBreak_Period(t *testing.T) {
+	b := testBreak()
+
+	assert.Equal(t, time.Date(2024, time.March, 24, 0, 0, 0, 0, time.UTC), b.EndsAt())
+	assert.Equal(t, time.Date(2024, time.March, 7, 0, 0, 0, 0, time.UTC), b.TaperStartsAt())
+	assert.False(t, b.Active(time.Date(2024, time.March, 9, 23, 0, 0, 0, time.UTC)))
+	assert.True(t, b.Active(time.Date(2024, time.March, 10, 8, 0, 0, 0, time.UTC)))
+	assert.True(t, b.Active(time.Date(2024, time.March, 23, 23, 0, 0, 0, time.UTC)))
+	assert.False(t, b.Active(b.EndsAt()))
+	assert.True(t, b.Tapering(time.Date(2024, time.March, 7, 8, 0, 0, 0, time.UTC)))
+	assert.False(t, b.Tapering(time.Date(2024, time.March, 6, 23, 0, 0, 0, time.UTC)))
+	assert.False(t, b.Tapering(b.StartsAt))
+	assert.Equal(t, 1, b.Day(time.Date(2024, time.March, 10, 20, 0, 0, 0, time.UTC)))
+	assert.Equal(t, 14, b.Day(time.Date(2024, time.March, 23, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, -1, b.Day(time.Date(2024, time.March, 8, 0, 0, 0, 0, time.UTC)))
+}
+
+func TestToleranceBreak_TaperLimit(t *testing.T) {
+	b := testBreak()
+
+	for day, want := range map[int]float64{7: 30, 8: 20, 9: 10} {
+		limit, ok := b.TaperLimit(time.Date(2024, time.March, day, 12, 0, 0, 0, time.UTC))
+		require.True(t, ok, "March %d", day)
+		assert.InDelta(t, want, limit, 1e-9, "March %d", day)
+	}
+	_, ok := b.TaperLimit(b.StartsAt)
+	assert.False(t, ok)
+}
+
+func TestToleranceBreak_CheckIn(t *testing.T) {
+	b := testBreak()
+	second := time.Date(2024, time.March, 11, 21, 0, 0, 0, time.UTC)
+
+	b.CheckIn(&CheckIn{Day: second, Abstained: true, Craving: 7})
+	b.CheckIn(&CheckIn{Day: b.StartsAt, Abstained: true, Craving: 8})
+	b.CheckIn(&CheckIn{Day: second, Abstained: false, Craving: 9})
+
+	require.Len(t, b.CheckIns, 2, "a check-in of the same day replaces the earlier one")
+	assert.Equal(t, b.StartsAt, b.CheckIns[0].Day)
+	assert.False(t, b.CheckInOn(second).Abstained)
+	assert.Equal(t, time.Date(2024, time.March, 11, 0, 0, 0, 0, time.UTC), b.CheckInOn(second).Day)
+	assert.Nil(t, b.CheckInOn(time.Date(2024, time.March, 12, 0, 0, 0, 0, time.UTC)))
+}
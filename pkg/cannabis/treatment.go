@@ -0,0 +1,96 @@
+package cannabis
+
+import "fmt"
+
+// TreatmentType is the enum for the treatments against germs a product
+// underwent after the harvest.
+type TreatmentType int
+
+const (
+	// UnknownTreatment is the treatment of products which was not recorded
+	UnknownTreatment TreatmentType = iota
+	// Untreated products underwent no treatment
+	Untreated
+	// Irradiated products were irradiated by an unspecified method
+	Irradiated
+	// GammaIrradiated products were irradiated with gamma rays
+	GammaIrradiated
+	// EBeamIrradiated products were irradiated with an electron beam
+	EBeamIrradiated
+	// ColdPlasma products were treated with cold plasma, which is no irradiation
+	ColdPlasma
+)
+
+// treatmentIDs are the stable identifiers of the treatment types, used in
+// stored data and queries.
+var treatmentIDs = map[TreatmentType]string{
+	UnknownTreatment: "unknown",
+	Untreated:        "none",
+	Irradiated:       "irradiated",
+	GammaIrradiated:  "gamma",
+	EBeamIrradiated:  "e-beam",
+	ColdPlasma:       "cold-plasma",
+}
+
+// Treatments is a collection of the names of all known treatment types.
+var Treatments = map[TreatmentType]string{
+	UnknownTreatment: "Unknown",
+	Untreated:        "Untreated",
+	Irradiated:       "Irradiated (unspecified)",
+	GammaIrradiated:  "Gamma irradiation",
+	EBeamIrradiated:  "E-beam irradiation",
+	ColdPlasma:       "Cold plasma",
+}
+
+// Treatment is the treatment of a product, as declared on its package or
+// certificate.
+type Treatment struct {
+	Type       TreatmentType `yaml:"type"`                  // The treatment type
+	DeclaredBy string        `yaml:"declared_by,omitempty"` // Who declared the treatment (e.g. the manufacturer)
+}
+
+// Irradiated reports whether the treatment is an irradiation of any method.
+func (t Treatment) Irradiated() bool {
+	return t.Type.Irradiated()
+}
+
+// Irradiated reports whether the treatment type is an irradiation of any
+// method.
+func (t TreatmentType) Irradiated() bool {
+	return t == Irradiated || t == GammaIrradiated || t == EBeamIrradiated
+}
+
+// ID returns the stable identifier of the treatment type, or an empty string
+// if the type is unknown.
+func (t TreatmentType) ID() string {
+	return treatmentIDs[t]
+}
+
+// ParseTreatmentType returns the treatment type with the given identifier.
+func ParseTreatmentType(id string) (TreatmentType, error) {
+	for t, tid := range treatmentIDs {
+		if tid == id {
+			return t, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown treatment %q", id)
+}
+
+// MarshalText encodes the treatment type as its identifier.
+func (t TreatmentType) MarshalText() ([]byte, error) {
+	id := t.ID()
+	if id == "" {
+		return nil, fmt.Errorf("unknown treatment type %d", int(t))
+	}
+	return []byte(id), nil
+}
+
+// UnmarshalText decodes the treatment type from its identifier.
+func (t *TreatmentType) UnmarshalText(text []byte) error {
+	parsed, err := ParseTreatmentType(string(text))
+	if err != nil {
+		return err
+	}
+	*t = parsed
+	return nil
+}
@@ -0,0 +1,56 @@
+package cannabis
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestTreatment_Irradiated(t *testing.T) {
+	assert.False(t, Treatment{}.Irradiated())
+	assert.False(t, Treatment{Type: Untreated}.Irradiated())
+	assert.True(t, Treatment{Type: Irradiated}.Irradiated())
+	assert.True(t, Treatment{Type: GammaIrradiated}.Irradiated())
+	assert.True(t, Treatment{Type: EBeamIrradiated}.Irradiated())
+	assert.False(t, Treatment{Type: ColdPlasma}.Irradiated(), "cold plasma is no irradiation")
+}
+
+func TestTreatmentTypeIDs(t *testing.T) {
+	for tt := range Treatments {
+		parsed, err := ParseTreatmentType(tt.ID())
+		require.NoError(t, err)
+		assert.Equal(t, tt, parsed)
+	}
+	_, err := ParseTreatmentType("microwave")
+	assert.Error(t, err)
+	_, err = TreatmentType(-1).MarshalText()
+	assert.Error(t, err)
+}
+
+func TestStrain_UnmarshalTreatment(t *testing.T) {
+	t.Run("RoundTrip", func(t *testing.T) {
+		s := Strain{Strain: "Pink Kush", Treatment: Treatment{Type: EBeamIrradiated, DeclaredBy: "Manufacturer"}}
+		data, err := yaml.Marshal(s)
+		require.NoError(t, err)
+		assert.Contains(t, string(data), "treatment:\n    type: e-beam\n    declared_by: Manufacturer")
+
+		var decoded Strain
+		require.NoError(t, yaml.Unmarshal(data, &decoded))
+		assert.Equal(t, s.Treatment, decoded.Treatment)
+		assert.False(t, decoded.Legacy())
+	})
+
+	t.Run("Legacy", func(t *testing.T) {
+		var radiated, untreated Strain
+		require.NoError(t, yaml.Unmarshal([]byte("strain: Pink Kush\nradiated: true\n"), &radiated))
+		require.NoError(t, yaml.Unmarshal([]byte("strain: Pink Kush\nradiated: false\n"), &untreated))
+
+		assert.Equal(t, "Pink Kush", radiated.Strain)
+		assert.Equal(t, Treatment{Type: Irradiated}, radiated.Treatment)
+		assert.Equal(t, Treatment{Type: UnknownTreatment}, untreated.Treatment, "not being radiated does not rule out other treatments")
+		assert.True(t, radiated.Legacy())
+		assert.True(t, untreated.Legacy())
+	})
+}
@@ -0,0 +1,2 @@
+// Package i18n provides the translation of messages and the locale-aware formatting of numbers.
+package i18n // import "github.com/TheDonDope/wits-tui/pkg/i18n"
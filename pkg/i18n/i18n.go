@@ -0,0 +1,197 @@
+package i18n
+
+import (
+	"embed"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Locale is the identifier of a language, e.g. `en` or `de`.
+type Locale string
+
+const (
+	// English is the source language of all messages.
+	English Locale = "en"
+	// German is the language of the German medical cannabis patients.
+	German Locale = "de"
+)
+
+//go:embed locales/*.yml
+var localesFS embed.FS
+
+// Catalog contains the translations of a locale. Messages are keyed by their
+// English source text, terms are the vocabulary of the cannabis package, e.g.
+// names, effects and flavors of terpenes.
+type Catalog struct {
+	Name     string            `yaml:"name"`
+	Decimal  string            `yaml:"decimal"`
+	Messages map[string]string `yaml:"messages"`
+	Terms    map[string]string `yaml:"terms"`
+}
+
+var (
+	mu       sync.RWMutex
+	current  = English
+	catalogs = mustLoadCatalogs()
+)
+
+// mustLoadCatalogs loads all embedded catalogs, keyed by their locale.
+func mustLoadCatalogs() map[Locale]*Catalog {
+	files, err := localesFS.ReadDir("locales")
+	if err != nil {
+		panic(err)
+	}
+	cs := make(map[Locale]*Catalog, len(files))
+	for _, f := range files {
+		data, err := localesFS.ReadFile("locales/" + f.Name())
+		if err != nil {
+			panic(err)
+		}
+		c := &Catalog{}
+		if err := yaml.Unmarshal(data, c); err != nil {
+			panic(fmt.Sprintf("invalid catalog %s: %v", f.Name(), err))
+		}
+		cs[Locale(strings.TrimSuffix(f.Name(), ".yml"))] = c
+	}
+	return cs
+}
+
+// Locales returns all available locales, sorted by their identifier.
+func Locales() []Locale {
+	var ls []Locale
+	for l := range catalogs {
+		ls = append(ls, l)
+	}
+	sort.Slice(ls, func(i, j int) bool { return ls[i] < ls[j] })
+	return ls
+}
+
+// Name returns the display name of the given locale.
+func Name(l Locale) string {
+	if c, ok := catalogs[l]; ok {
+		return c.Name
+	}
+	return string(l)
+}
+
+// SetLocale sets the locale used for all translations. It returns an error if
+// no catalog exists for the given locale.
+func SetLocale(l Locale) error {
+	if _, ok := catalogs[l]; !ok {
+		return fmt.Errorf("unknown locale %q", l)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	current = l
+	log.Printf("✅ 🌍  (pkg/i18n/i18n.go) SetLocale(l Locale: %v)\n", l)
+	return nil
+}
+
+// Current returns the locale used for all translations.
+func Current() Locale {
+	mu.RLock()
+	defer mu.RUnlock()
+	return current
+}
+
+// Detect returns the locale matching the language of the environment, as
+// configured by the LC_ALL, LC_MESSAGES and LANG variables (e.g.
+// `de_DE.UTF-8`), in this order. Variables naming an unknown language are
+// skipped, and it falls back to English if none names a known one.
+func Detect() Locale {
+	for _, env := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		fields := strings.FieldsFunc(os.Getenv(env), func(r rune) bool {
+			return r == '_' || r == '.' || r == '-' || r == '@'
+		})
+		if len(fields) == 0 {
+			continue
+		}
+		l := Locale(strings.ToLower(fields[0]))
+		if _, ok := catalogs[l]; ok {
+			return l
+		}
+	}
+	return English
+}
+
+// UseLocale sets the given locale for all translations, or the locale
+// detected from the environment if the given one is empty.
+func UseLocale(l Locale) error {
+	if l == "" {
+		l = Detect()
+	}
+	return SetLocale(l)
+}
+
+// catalog returns the catalog of the current locale.
+func catalog() *Catalog {
+	return catalogs[Current()]
+}
+
+// T returns the translation of the given English message in the current
+// locale, formatted with the given arguments. Messages without translation are
+// returned as is.
+func T(msg string, args ...any) string {
+	if t, ok := catalog().Messages[msg]; ok && t != "" {
+		msg = t
+	}
+	if len(args) > 0 {
+		return fmt.Sprintf(msg, args...)
+	}
+	return msg
+}
+
+// Term returns the translation of the given term of the cannabis vocabulary
+// in the current locale. Terms without translation are returned as is.
+func Term(term string) string {
+	if t, ok := catalog().Terms[term]; ok && t != "" {
+		return t
+	}
+	return term
+}
+
+// Terms returns the translations of all given terms.
+func Terms(terms []string) []string {
+	ts := make([]string, len(terms))
+	for i, t := range terms {
+		ts[i] = Term(t)
+	}
+	return ts
+}
+
+// decimal returns the decimal separator of the current locale.
+func decimal() string {
+	if d := catalog().Decimal; d != "" {
+		return d
+	}
+	return "."
+}
+
+// FormatFloat formats the given number with the given precision, using the
+// decimal separator of the current locale.
+func FormatFloat(f float64, prec int) string {
+	return strings.Replace(strconv.FormatFloat(f, 'f', prec, 64), ".", decimal(), 1)
+}
+
+// FormatMoney formats the given amount with two decimals and the given
+// currency code, using the decimal separator of the current locale.
+func FormatMoney(amount float64, currency string) string {
+	return FormatFloat(amount, 2) + " " + currency
+}
+
+// ParseFloat parses the given number, accepting the decimal separator of the
+// current locale as well as the `.`.
+func ParseFloat(s string) (float64, error) {
+	s = strings.TrimSpace(s)
+	if d := decimal(); d != "." {
+		s = strings.Replace(s, d, ".", 1)
+	}
+	return strconv.ParseFloat(s, 64)
+}
@@ -0,0 +1,161 @@
+package i18n
+
+import (
+	"io"
+	"log"
+	"os"
+	"testing"
+
+	can "github.com/TheDonDope/wits-tui/pkg/cannabis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestMain handles global test setup
+func TestMain(m *testing.M) {
+	// Disable log output during tests
+	log.SetOutput(io.Discard)
+	os.Exit(m.Run())
+}
+
+// withLocale sets the given locale for the duration of the test.
+func withLocale(t *testing.T, l Locale) {
+	prev := Current()
+	require.NoError(t, SetLocale(l))
+	t.Cleanup(func() { _ = SetLocale(prev) })
+}
+
+func TestLocales(t *testing.T) {
+	assert.Equal(t, []Locale{German, English}, Locales())
+	assert.Equal(t, "Deutsch", Name(German))
+	assert.Error(t, SetLocale("xx"))
+}
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		name     string
+		lcAll    string
+		lang     string
+		expected Locale
+	}{
+		{"Empty", "", "", English},
+		{"German", "", "de_DE.UTF-8", German},
+		{"English", "", "en_US.UTF-8", English},
+		{"Unknown", "", "fr_FR.UTF-8", English},
+		{"POSIX", "", "C", English},
+		{"LCAllPrecedence", "de_AT.UTF-8", "en_GB.UTF-8", German},
+		{"UnknownFallsThrough", "fr_FR.UTF-8", "de_DE.UTF-8", German},
+		{"OnlySeparators", ".", "@", English},
+		{"SeparatorsFallThrough", "_", "de", German},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("LC_ALL", tt.lcAll)
+			t.Setenv("LC_MESSAGES", "")
+			t.Setenv("LANG", tt.lang)
+			assert.Equal(t, tt.expected, Detect())
+		})
+	}
+}
+
+func TestUseLocale(t *testing.T) {
+	t.Cleanup(func() { _ = SetLocale(English) })
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LC_MESSAGES", "")
+	t.Setenv("LANG", "de_DE.UTF-8")
+
+	require.NoError(t, UseLocale(""))
+	assert.Equal(t, German, Current())
+	require.NoError(t, UseLocale(English))
+	assert.Equal(t, English, Current())
+	assert.Error(t, UseLocale("xx"))
+}
+
+func TestT(t *testing.T) {
+	t.Run("English", func(t *testing.T) {
+		withLocale(t, English)
+		assert.Equal(t, "Amount (g)", T("Amount (g)"))
+		assert.Equal(t, "press x", T("press %s", "x"))
+	})
+
+	t.Run("German", func(t *testing.T) {
+		withLocale(t, German)
+		assert.Equal(t, "Menge (g)", T("Amount (g)"))
+		assert.Equal(t, "Der Phänotyp", T("The phenotype"))
+		assert.Equal(t, "Untranslated", T("Untranslated"))
+	})
+}
+
+func TestTerm(t *testing.T) {
+	withLocale(t, German)
+	assert.Equal(t, "β-Myrcen", Term("β-Myrcene"))
+	assert.Equal(t, []string{"Zitrus", "Zitrone"}, Terms([]string{"citrus", "lemon"}))
+	assert.Equal(t, "unknown", Term("unknown"))
+}
+
+func TestGermanTermsComplete(t *testing.T) {
+	terms := catalogs[German].Terms
+	for _, tp := range can.Terpenes {
+		assert.Contains(t, terms, tp.Name)
+		for _, e := range tp.Effects {
+			assert.Contains(t, terms, e)
+		}
+		for _, f := range tp.Flavors {
+			assert.Contains(t, terms, f)
+		}
+	}
+	for _, c := range can.Cannabinoids {
+		assert.Contains(t, terms, c.Name)
+		for _, e := range c.Effects {
+			assert.Contains(t, terms, e)
+		}
+	}
+}
+
+func TestFormatFloat(t *testing.T) {
+	withLocale(t, English)
+	assert.Equal(t, "22.5", FormatFloat(22.5, 1))
+
+	withLocale(t, German)
+	assert.Equal(t, "22,5", FormatFloat(22.5, 1))
+	assert.Equal(t, "3,50", FormatFloat(3.5, 2))
+}
+
+func TestFormatMoney(t *testing.T) {
+	withLocale(t, English)
+	assert.Equal(t, "9.90 EUR", FormatMoney(9.9, "EUR"))
+
+	withLocale(t, German)
+	assert.Equal(t, "120,00 CHF", FormatMoney(120, "CHF"))
+}
+
+func TestParseFloat(t *testing.T) {
+	tests := []struct {
+		name     string
+		locale   Locale
+		input    string
+		expected float64
+		wantErr  bool
+	}{
+		{"EnglishDot", English, "22.5", 22.5, false},
+		{"EnglishComma", English, "22,5", 0, true},
+		{"GermanComma", German, "22,5", 22.5, false},
+		{"GermanDot", German, "22.5", 22.5, false},
+		{"Whitespace", German, " 3,5 ", 3.5, false},
+		{"Invalid", German, "abc", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			withLocale(t, tt.locale)
+			val, err := ParseFloat(tt.input)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, val)
+		})
+	}
+}
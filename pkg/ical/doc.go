@@ -0,0 +1,2 @@
+// Package ical provides the encoding of calendars in the iCalendar format (RFC 5545).
+package ical // import "github.com/TheDonDope/wits-tui/pkg/ical"
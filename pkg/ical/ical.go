@@ -0,0 +1,150 @@
+package ical
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// dateLayout is the layout of DATE values.
+	dateLayout = "20060102"
+	// dateTimeLayout is the layout of DATE-TIME values in local ("floating")
+	// time, UTC values append a "Z".
+	dateTimeLayout = "20060102T150405"
+	// maxLineLength is the maximum length of a content line in octets,
+	// excluding the line break. Longer lines are folded.
+	maxLineLength = 75
+)
+
+// Calendar is the type for an iCalendar object.
+type Calendar struct {
+	ProdID string   // The identifier of the product which created the calendar
+	Events []*Event // The events of the calendar
+}
+
+// Event is the type for an event of a calendar. Events are timed in UTC,
+// unless they are floating, taking place at the same wall clock time in every
+// time zone, or last all day.
+type Event struct {
+	UID         string    // The globally unique identifier, kept across exports
+	Summary     string    // The title
+	Description string    // The details, optional
+	Start       time.Time // The start
+	AllDay      bool      // Whether the event lasts the whole day of its start
+	Floating    bool      // Whether the event starts at the wall clock time of its start
+	RRule       string    // The recurrence rule (e.g. FREQ=DAILY), optional
+	Alarms      []Alarm   // The alarms of the event
+}
+
+// Alarm is the type for a display alarm of an event.
+type Alarm struct {
+	Trigger     time.Duration // The offset to the start of the event, negative if before
+	Description string        // The displayed text
+}
+
+// Encode writes the calendar in the iCalendar format to the given writer. The
+// given time is recorded as the creation time of every event.
+func (c *Calendar) Encode(w io.Writer, stamp time.Time) error {
+	var b strings.Builder
+	line := func(name, value string) {
+		writeLine(&b, name+":"+value)
+	}
+	line("BEGIN", "VCALENDAR")
+	line("VERSION", "2.0")
+	line("PRODID", c.ProdID)
+	line("CALSCALE", "GREGORIAN")
+	for _, e := range c.Events {
+		line("BEGIN", "VEVENT")
+		line("UID", e.UID)
+		line("DTSTAMP", stamp.UTC().Format(dateTimeLayout)+"Z")
+		switch {
+		case e.AllDay:
+			line("DTSTART;VALUE=DATE", e.Start.Format(dateLayout))
+			line("DTEND;VALUE=DATE", e.Start.AddDate(0, 0, 1).Format(dateLayout))
+		case e.Floating:
+			line("DTSTART", e.Start.Format(dateTimeLayout))
+		default:
+			line("DTSTART", e.Start.UTC().Format(dateTimeLayout)+"Z")
+		}
+		if e.RRule != "" {
+			line("RRULE", e.RRule)
+		}
+		line("SUMMARY", EscapeText(e.Summary))
+		if e.Description != "" {
+			line("DESCRIPTION", EscapeText(e.Description))
+		}
+		for _, a := range e.Alarms {
+			line("BEGIN", "VALARM")
+			line("ACTION", "DISPLAY")
+			line("TRIGGER", FormatDuration(a.Trigger))
+			line("DESCRIPTION", EscapeText(a.Description))
+			line("END", "VALARM")
+		}
+		line("END", "VEVENT")
+	}
+	line("END", "VCALENDAR")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// writeLine writes the given content line terminated by CRLF, folding it into
+// continuation lines starting with a space if it exceeds the maximum line
+// length. Lines are never folded within a multi-octet UTF-8 character.
+func writeLine(b *strings.Builder, s string) {
+	limit := maxLineLength
+	for len(s) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+		b.WriteString(s[:cut])
+		b.WriteString("\r\n ")
+		s = s[cut:]
+		// The leading space of the continuation line counts towards its length
+		limit = maxLineLength - 1
+	}
+	b.WriteString(s)
+	b.WriteString("\r\n")
+}
+
+// textEscaper escapes the characters with a special meaning in TEXT values.
+var textEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+
+// EscapeText escapes the given text for a TEXT value.
+func EscapeText(s string) string {
+	return textEscaper.Replace(s)
+}
+
+// FormatDuration formats the given duration as a DURATION value, e.g. -P14D
+// or PT1H30M. Fractions of seconds are dropped.
+func FormatDuration(d time.Duration) string {
+	sign := ""
+	if d < 0 {
+		sign = "-"
+		d = -d
+	}
+	days := d / (24 * time.Hour)
+	d -= days * 24 * time.Hour
+	h, m, s := d/time.Hour, d%time.Hour/time.Minute, d%time.Minute/time.Second
+	var b strings.Builder
+	b.WriteString(sign + "P")
+	if days > 0 {
+		fmt.Fprintf(&b, "%dD", days)
+	}
+	if h > 0 || m > 0 || s > 0 || days == 0 {
+		b.WriteString("T")
+		if h > 0 {
+			fmt.Fprintf(&b, "%dH", h)
+		}
+		if m > 0 {
+			fmt.Fprintf(&b, "%dM", m)
+		}
+		if s > 0 || (h == 0 && m == 0) {
+			fmt.Fprintf(&b, "%dS", s)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,98 @@
+package ical
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncode(t *testing.T) {
+	stamp := time.Date(2024, time.March, 13, 12, 0, 0, 0, time.UTC)
+	berlin := time.FixedZone("CET", 3600)
+	c := &Calendar{
+		ProdID: "-//Test//EN",
+		Events: []*Event{
+			{UID: "a@test", Summary: "Session: Pink Kush", Description: "Vaporizer, 12 mg THC", Start: time.Date(2024, time.March, 12, 21, 30, 0, 0, berlin)},
+			{UID: "b@test", Summary: "Dose", Start: time.Date(2024, time.March, 1, 8, 0, 0, 0, berlin), Floating: true,
+				RRule: "FREQ=DAILY", Alarms: []Alarm{{Description: "Take your dose."}}},
+			{UID: "c@test", Summary: "Expiry", Start: time.Date(2024, time.June, 30, 0, 0, 0, 0, berlin), AllDay: true,
+				Alarms: []Alarm{{Trigger: -14 * 24 * time.Hour, Description: "Renew"}}},
+		},
+	}
+
+	var b strings.Builder
+	require.NoError(t, c.Encode(&b, stamp))
+
+	assert.Equal(t, strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//Test//EN",
+		"CALSCALE:GREGORIAN",
+		"BEGIN:VEVENT",
+		"UID:a@test",
+		"DTSTAMP:20240313T120000Z",
+		"DTSTART:20240312T203000Z",
+		"SUMMARY:Session: Pink Kush",
+		`DESCRIPTION:Vaporizer\, 12 mg THC`,
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:b@test",
+		"DTSTAMP:20240313T120000Z",
+		"DTSTART:20240301T080000",
+		"RRULE:FREQ=DAILY",
+		"SUMMARY:Dose",
+		"BEGIN:VALARM",
+		"ACTION:DISPLAY",
+		"TRIGGER:PT0S",
+		"DESCRIPTION:Take your dose.",
+		"END:VALARM",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:c@test",
+		"DTSTAMP:20240313T120000Z",
+		"DTSTART;VALUE=DATE:20240630",
+		"DTEND;VALUE=DATE:20240701",
+		"SUMMARY:Expiry",
+		"BEGIN:VALARM",
+		"ACTION:DISPLAY",
+		"TRIGGER:-P14D",
+		"DESCRIPTION:Renew",
+		"END:VALARM",
+		"END:VEVENT",
+		"END:VCALENDAR",
+		"",
+	}, "\r\n"), b.String())
+}
+
+func TestWriteLine(t *testing.T) {
+	var b strings.Builder
+	writeLine(&b, "DESCRIPTION:"+strings.Repeat("ä", 40))
+
+	lines := strings.Split(strings.TrimSuffix(b.String(), "\r\n"), "\r\n")
+	require.Len(t, lines, 2)
+	for _, l := range lines {
+		assert.LessOrEqual(t, len(l), maxLineLength)
+		assert.True(t, strings.ToValidUTF8(l, "") == l, "folded within a character: %q", l)
+	}
+	assert.True(t, strings.HasPrefix(lines[1], " "))
+	assert.Equal(t, "DESCRIPTION:"+strings.Repeat("ä", 40), lines[0]+lines[1][1:])
+}
+
+func TestEscapeText(t *testing.T) {
+	assert.Equal(t, `a\\b\; c\, d\ne`, EscapeText("a\\b; c, d\ne"))
+}
+
+func TestFormatDuration(t *testing.T) {
+	for d, want := range map[time.Duration]string{
+		0:                             "PT0S",
+		-14 * 24 * time.Hour:          "-P14D",
+		90 * time.Minute:              "PT1H30M",
+		-(24*time.Hour + time.Second): "-P1DT1S",
+		-15 * time.Minute:             "-PT15M",
+	} {
+		assert.Equal(t, want, FormatDuration(d), "%v", d)
+	}
+}
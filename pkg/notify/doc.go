@@ -0,0 +1,2 @@
+// Package notify provides the notifiers sending the notifications of due reminders.
+package notify // import "github.com/TheDonDope/wits-tui/pkg/notify"
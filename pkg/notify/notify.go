@@ -0,0 +1,114 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/exec"
+	"time"
+
+	"github.com/TheDonDope/wits-tui/pkg/settings"
+)
+
+// commandTimeout is the time a notification command may run before it is
+// killed.
+const commandTimeout = 30 * time.Second
+
+// Notification is a notification about a due reminder.
+type Notification struct {
+	Title string // The short title, e.g. the kind of the reminder
+	Body  string // The message
+}
+
+// Notifier sends notifications.
+type Notifier interface {
+	Notify(n Notification) error
+}
+
+// TerminalNotifier notifies by ringing the terminal bell and writing a banner.
+type TerminalNotifier struct {
+	out    io.Writer
+	bell   bool
+	banner bool
+}
+
+// NewTerminalNotifier creates a notifier writing to the given writer, ringing
+// the bell and writing a banner line as requested.
+func NewTerminalNotifier(out io.Writer, bell, banner bool) *TerminalNotifier {
+	return &TerminalNotifier{out: out, bell: bell, banner: banner}
+}
+
+// Notify rings the bell and writes the banner of the given notification.
+func (tn *TerminalNotifier) Notify(n Notification) error {
+	if tn.bell {
+		if _, err := fmt.Fprint(tn.out, "\a"); err != nil {
+			return err
+		}
+	}
+	if tn.banner {
+		if _, err := fmt.Fprintf(tn.out, "🔔 %s: %s\n", n.Title, n.Body); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CommandNotifier notifies by running a command hook, e.g. notify-send. The
+// title and body of the notification are appended to the arguments of the
+// command and passed in the WITS_NOTIFICATION_TITLE and
+// WITS_NOTIFICATION_BODY environment variables.
+type CommandNotifier struct {
+	command []string
+}
+
+// NewCommandNotifier creates a notifier running the given command with its
+// arguments.
+func NewCommandNotifier(command []string) *CommandNotifier {
+	return &CommandNotifier{command: command}
+}
+
+// Notify runs the command for the given notification.
+func (cn *CommandNotifier) Notify(n Notification) error {
+	if len(cn.command) == 0 {
+		return errors.New("no notification command configured")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), commandTimeout)
+	defer cancel()
+	args := append(cn.command[1:len(cn.command):len(cn.command)], n.Title, n.Body)
+	cmd := exec.CommandContext(ctx, cn.command[0], args...)
+	cmd.Env = append(os.Environ(), "WITS_NOTIFICATION_TITLE="+n.Title, "WITS_NOTIFICATION_BODY="+n.Body)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		log.Printf("🚨 🔔  (pkg/notify/notify.go) 🗒️  Notification command failed with error: %v, output: %s \n", err, out)
+		return err
+	}
+	return nil
+}
+
+// Notifiers notifies through all of its notifiers.
+type Notifiers []Notifier
+
+// Notify sends the given notification through all notifiers, even if some of
+// them fail. It returns the joined errors.
+func (ns Notifiers) Notify(n Notification) error {
+	var errs []error
+	for _, notifier := range ns {
+		if err := notifier.Notify(n); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// New returns the notifiers configured by the given reminder settings: the
+// terminal notifier writing to the given writer, with a banner if requested,
+// and the command hook if one is configured.
+func New(s settings.Reminders, out io.Writer, banner bool) Notifier {
+	ns := Notifiers{NewTerminalNotifier(out, !s.Silent, banner)}
+	if len(s.Command) > 0 {
+		ns = append(ns, NewCommandNotifier(s.Command))
+	}
+	return ns
+}
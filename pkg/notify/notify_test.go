@@ -0,0 +1,61 @@
+package notify
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/TheDonDope/wits-tui/pkg/settings"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testNotification = Notification{Title: "Dose", Body: "Take 0.1 g of Pink Kush"}
+
+func TestTerminalNotifier(t *testing.T) {
+	var out bytes.Buffer
+
+	require.NoError(t, NewTerminalNotifier(&out, true, true).Notify(testNotification))
+	assert.Equal(t, "\a🔔 Dose: Take 0.1 g of Pink Kush\n", out.String())
+
+	out.Reset()
+	require.NoError(t, NewTerminalNotifier(&out, false, false).Notify(testNotification))
+	assert.Empty(t, out.String())
+}
+
+func TestCommandNotifier(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "notification")
+	script := `printf '%s|%s|%s' "$1" "$2" "$WITS_NOTIFICATION_TITLE" > "$0"`
+
+	require.NoError(t, NewCommandNotifier([]string{"sh", "-c", script, file}).Notify(testNotification))
+
+	data, err := os.ReadFile(file)
+	require.NoError(t, err)
+	assert.Equal(t, "Dose|Take 0.1 g of Pink Kush|Dose", string(data))
+	assert.Error(t, NewCommandNotifier([]string{"false"}).Notify(testNotification))
+	assert.Error(t, NewCommandNotifier(nil).Notify(testNotification))
+}
+
+type failingNotifier struct{}
+
+func (failingNotifier) Notify(Notification) error { return errors.New("failed") }
+
+func TestNotifiers(t *testing.T) {
+	var out bytes.Buffer
+	ns := Notifiers{failingNotifier{}, NewTerminalNotifier(&out, false, true)}
+
+	assert.Error(t, ns.Notify(testNotification))
+	assert.Contains(t, out.String(), "Dose", "the other notifiers are still notified")
+}
+
+func TestNew(t *testing.T) {
+	var out bytes.Buffer
+	n := New(settings.Reminders{Silent: true, Command: []string{"true"}}, &out, true)
+
+	require.IsType(t, Notifiers{}, n)
+	assert.Len(t, n.(Notifiers), 2)
+	require.NoError(t, n.Notify(testNotification))
+	assert.Equal(t, "🔔 Dose: Take 0.1 g of Pink Kush\n", out.String())
+}
@@ -0,0 +1,2 @@
+// Package report provides the rendering of consumption reports in Markdown and HTML.
+package report // import "github.com/TheDonDope/wits-tui/pkg/report"
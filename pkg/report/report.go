@@ -0,0 +1,221 @@
+package report
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/TheDonDope/wits-tui/pkg/i18n"
+	"github.com/TheDonDope/wits-tui/pkg/service"
+)
+
+const (
+	// TemplatesDir is the folder within the WITS_DIR containing the user
+	// templates, which override the sections of the built-in ones.
+	TemplatesDir = "reports"
+	// reportTemplate is the name of the template rendering a whole report.
+	reportTemplate = "report"
+	// chartWidth is the length of the longest bar of an ASCII chart in
+	// characters, and of an SVG chart in pixels divided by 8.
+	chartWidth = 40
+)
+
+// Format is the type for the output formats of a report.
+type Format string
+
+const (
+	// Markdown renders the report as Markdown with ASCII charts
+	Markdown Format = "md"
+	// HTML renders the report as a HTML document with SVG charts
+	HTML Format = "html"
+)
+
+// Sections are the names of the sections of a report, in the order they are
+// rendered by the built-in templates.
+var Sections = []string{"summary", "strains", "dosage", "symptoms", "prescriptions", "side-effects"}
+
+//go:embed templates/*.tmpl
+var builtinTemplates embed.FS
+
+// ParseFormat returns the format with the given name, or an error if there is
+// none.
+func ParseFormat(name string) (Format, error) {
+	switch f := Format(strings.ToLower(name)); f {
+	case Markdown, HTML:
+		return f, nil
+	}
+	return "", fmt.Errorf("unknown report format %q, expected md or html", name)
+}
+
+// file returns the name of the template file of the format.
+func (f Format) file() string {
+	return "report." + string(f) + ".tmpl"
+}
+
+// executor executes the named templates of a text or HTML template set.
+type executor interface {
+	ExecuteTemplate(w io.Writer, name string, data any) error
+}
+
+// Render writes the given report in the given format to the given writer. The
+// template file of the format in the given directory, if any, is parsed after
+// the built-in one, so that it can redefine single sections or the whole
+// report.
+func Render(w io.Writer, f Format, r *service.Report, dir string) error {
+	return RenderSection(w, f, reportTemplate, r, dir)
+}
+
+// RenderSection writes the given section of the report in the given format to
+// the given writer, using the templates of Render.
+func RenderSection(w io.Writer, f Format, section string, r *service.Report, dir string) error {
+	t, err := load(f, dir)
+	if err != nil {
+		return err
+	}
+	return t.ExecuteTemplate(w, section, r)
+}
+
+// load parses the built-in templates of the given format and the user template
+// in the given directory, if any.
+func load(f Format, dir string) (executor, error) {
+	var user string
+	if dir != "" {
+		path := filepath.Join(dir, f.file())
+		if _, err := os.Stat(path); err == nil {
+			user = path
+		} else if !errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+	}
+	switch f {
+	case Markdown:
+		t, err := texttemplate.New(f.file()).Funcs(funcs(asciiChart)).ParseFS(builtinTemplates, "templates/"+f.file())
+		if err == nil && user != "" {
+			t, err = t.ParseFiles(user)
+		}
+		return t, err
+	case HTML:
+		t, err := htmltemplate.New(f.file()).Funcs(funcs(svgChart)).ParseFS(builtinTemplates, "templates/"+f.file())
+		if err == nil && user != "" {
+			t, err = t.ParseFiles(user)
+		}
+		return t, err
+	}
+	return nil, fmt.Errorf("unknown report format %q, expected md or html", f)
+}
+
+// funcs returns the functions available to the templates, rendering charts
+// with the given function.
+func funcs(chart any) map[string]any {
+	return map[string]any{
+		"chart":   chart,
+		"date":    func(t time.Time) string { return t.Format(time.DateOnly) },
+		"lastDay": func(t time.Time) time.Time { return t.AddDate(0, 0, -1) },
+		"float":   i18n.FormatFloat,
+		"signed": func(f float64, prec int) string {
+			s := i18n.FormatFloat(f, prec)
+			if f > 0 {
+				s = "+" + s
+			}
+			return s
+		},
+		"cell": func(s string) string { return strings.ReplaceAll(s, "|", `\|`) },
+		"dailyTHC": func(days []*service.DailyDose) []Bar {
+			bars := make([]Bar, len(days))
+			for i, d := range days {
+				bars[i] = Bar{Label: d.Day.Format(time.DateOnly), Value: d.THC}
+			}
+			return bars
+		},
+		"dailyCBD": func(days []*service.DailyDose) []Bar {
+			bars := make([]Bar, len(days))
+			for i, d := range days {
+				bars[i] = Bar{Label: d.Day.Format(time.DateOnly), Value: d.CBD}
+			}
+			return bars
+		},
+		"weekly": func(weeks []service.WeeklySymptom) []Bar {
+			bars := make([]Bar, len(weeks))
+			for i, w := range weeks {
+				bars[i] = Bar{Label: w.Week.Format(time.DateOnly), Value: w.Before}
+			}
+			return bars
+		},
+	}
+}
+
+// Bar is a labeled bar of a chart.
+type Bar struct {
+	Label string  // The label shown left of the bar
+	Value float64 // The length of the bar
+}
+
+// barLength returns the length of the bar with the given value in a chart of
+// the given width, whose longest bar has the given maximum value.
+func barLength(value, maximum float64, width int) int {
+	if maximum <= 0 || value <= 0 {
+		return 0
+	}
+	return int(math.Round(value / maximum * float64(width)))
+}
+
+// maxValue returns the maximum value of the given bars.
+func maxValue(bars []Bar) float64 {
+	var m float64
+	for _, b := range bars {
+		m = max(m, b.Value)
+	}
+	return m
+}
+
+// asciiChart renders the given bars as lines of block characters, each
+// terminated by a newline.
+func asciiChart(bars []Bar) string {
+	labelWidth := 0
+	for _, b := range bars {
+		labelWidth = max(labelWidth, len([]rune(b.Label)))
+	}
+	m := maxValue(bars)
+	var sb strings.Builder
+	for _, b := range bars {
+		fmt.Fprintf(&sb, "%-*s │%s %s\n", labelWidth, b.Label,
+			strings.Repeat("█", barLength(b.Value, m, chartWidth)), i18n.FormatFloat(b.Value, 1))
+	}
+	return sb.String()
+}
+
+const (
+	// svgRowHeight is the height of a bar of a SVG chart including its gap.
+	svgRowHeight = 20
+	// svgLabelWidth is the width of the labels of a SVG chart.
+	svgLabelWidth = 90
+	// svgBarWidth is the width of the longest bar of a SVG chart.
+	svgBarWidth = chartWidth * 8
+)
+
+// svgChart renders the given bars as an inline SVG image of horizontal bars.
+func svgChart(bars []Bar) htmltemplate.HTML {
+	m := maxValue(bars)
+	var sb strings.Builder
+	fmt.Fprintf(&sb, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" font-family="sans-serif" font-size="12" role="img">`,
+		svgLabelWidth+svgBarWidth+60, len(bars)*svgRowHeight)
+	sb.WriteString("\n")
+	for i, b := range bars {
+		y := i * svgRowHeight
+		length := barLength(b.Value, m, svgBarWidth)
+		fmt.Fprintf(&sb, `<text x="0" y="%d">%s</text>`, y+14, htmltemplate.HTMLEscapeString(b.Label))
+		fmt.Fprintf(&sb, `<rect x="%d" y="%d" width="%d" height="14" fill="#4c9a2a"/>`, svgLabelWidth, y+3, length)
+		fmt.Fprintf(&sb, `<text x="%d" y="%d">%s</text>`, svgLabelWidth+length+4, y+14, i18n.FormatFloat(b.Value, 1))
+		sb.WriteString("\n")
+	}
+	sb.WriteString("</svg>")
+	return htmltemplate.HTML(sb.String())
+}
@@ -0,0 +1,123 @@
+package report
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	can "github.com/TheDonDope/wits-tui/pkg/cannabis"
+	"github.com/TheDonDope/wits-tui/pkg/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var update = flag.Bool("update", false, "update the golden files")
+
+// testReport returns a report over two weeks with two strains, a symptom
+// rated in both weeks and a prescription.
+func testReport() *service.Report {
+	from := time.Date(2024, time.March, 4, 0, 0, 0, 0, time.UTC)
+	return &service.Report{
+		From:     from,
+		To:       from.AddDate(0, 0, 14),
+		Sessions: 3,
+		Grams:    0.8,
+		THC:      160,
+		CBD:      1.5,
+		Strains: []service.StrainConsumption{
+			{Strain: "Bedrocan", Sessions: 1, Grams: 0.5, THC: 100},
+			{Strain: "Pink | Kush", Sessions: 2, Grams: 0.3, THC: 60, CBD: 1.5},
+		},
+		Daily: []*service.DailyDose{
+			{Day: from, Sessions: 2, Grams: 0.3, THC: 60, CBD: 1.5},
+			{Day: from.AddDate(0, 0, 8), Sessions: 1, Grams: 0.5, THC: 100},
+		},
+		Symptoms: []service.SymptomTrend{{
+			Symptom: "pain", Sessions: 2, Before: 7, After: 3,
+			Weeks: []service.WeeklySymptom{
+				{Week: from, Sessions: 1, Before: 8, After: 4},
+				{Week: from.AddDate(0, 0, 7), Sessions: 1, Before: 6, After: 2},
+			},
+		}},
+		Prescriptions: []service.PrescriptionUsage{{
+			Prescription: &can.Prescription{Doctor: "Dr. <Test>", ValidUntil: from.AddDate(0, 2, 0), Grams: 30},
+			Batches:      1, Dispensed: 10, Remaining: 20, Valid: true,
+		}},
+		SideEffects: []service.EffectCount{{Effect: "dry mouth", Sessions: 2}, {Effect: "dizziness", Sessions: 1}},
+	}
+}
+
+// assertGolden compares the given output with the golden file of the given
+// name, or updates the golden file if requested.
+func assertGolden(t *testing.T, name string, got []byte) {
+	t.Helper()
+	path := filepath.Join("testdata", name+".golden")
+	if *update {
+		require.NoError(t, os.WriteFile(path, got, 0o644))
+	}
+	want, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, string(want), string(got))
+}
+
+func TestRenderSection(t *testing.T) {
+	for _, f := range []Format{Markdown, HTML} {
+		for _, section := range Sections {
+			t.Run(string(f)+"/"+section, func(t *testing.T) {
+				var b bytes.Buffer
+				require.NoError(t, RenderSection(&b, f, section, testReport(), ""))
+				assertGolden(t, section+"."+string(f), b.Bytes())
+			})
+		}
+	}
+}
+
+func TestRenderSectionEmpty(t *testing.T) {
+	from := time.Date(2024, time.March, 4, 0, 0, 0, 0, time.UTC)
+	empty := &service.Report{From: from, To: from.AddDate(0, 0, 7)}
+	for _, f := range []Format{Markdown, HTML} {
+		var b bytes.Buffer
+		require.NoError(t, Render(&b, f, empty, ""))
+		assertGolden(t, "empty."+string(f), b.Bytes())
+	}
+}
+
+func TestRender(t *testing.T) {
+	for _, f := range []Format{Markdown, HTML} {
+		var b bytes.Buffer
+		require.NoError(t, Render(&b, f, testReport(), ""))
+		assertGolden(t, "report."+string(f), b.Bytes())
+	}
+}
+
+func TestRenderUserTemplate(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "report.md.tmpl"),
+		[]byte(`{{define "side-effects"}}Side effects: {{len .SideEffects}}{{end}}`), 0o644))
+
+	var b bytes.Buffer
+	require.NoError(t, Render(&b, Markdown, testReport(), dir))
+	assert.Contains(t, b.String(), "## Summary")
+	assert.Contains(t, b.String(), "Side effects: 2")
+	assert.NotContains(t, b.String(), "## Side Effects")
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "report.html.tmpl"), []byte(`{{define "summary"}`), 0o644))
+	assert.Error(t, Render(&b, HTML, testReport(), dir))
+}
+
+func TestParseFormat(t *testing.T) {
+	f, err := ParseFormat("HTML")
+	require.NoError(t, err)
+	assert.Equal(t, HTML, f)
+
+	_, err = ParseFormat("pdf")
+	assert.Error(t, err)
+}
+
+func TestAsciiChart(t *testing.T) {
+	assert.Equal(t, "a  │████████████████████████████████████████ 2.0\nbb │████████████████████ 1.0\nc  │ 0.0\n",
+		asciiChart([]Bar{{"a", 2}, {"bb", 1}, {"c", 0}}))
+}
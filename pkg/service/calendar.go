@@ -0,0 +1,144 @@
+package service
+
+import (
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"time"
+
+	can "github.com/TheDonDope/wits-tui/pkg/cannabis"
+	"github.com/TheDonDope/wits-tui/pkg/i18n"
+	"github.com/TheDonDope/wits-tui/pkg/ical"
+)
+
+const (
+	// CalendarProdID identifies Wits as the creator of exported calendars.
+	CalendarProdID = "-//TheDonDope//wits-tui//EN"
+	// calendarDomain is the domain of the UIDs of exported events, making
+	// them globally unique.
+	calendarDomain = "wits-tui"
+)
+
+// Calendar returns a calendar of the given sessions before the given time,
+// reminders and prescription expiries. The UIDs of the events are derived
+// from the IDs of their origin, so that re-importing an updated export updates
+// the events instead of duplicating them:
+//   - sessions are timed events at their start
+//   - reminders recur at every scheduled time of day, with an alarm
+//   - prescription expiries last the last valid day, with an alarm
+//     ExpiryWarningDays ahead
+func Calendar(sessions []*can.Session, reminders []*can.Reminder, prescriptions []*can.Prescription, at time.Time) *ical.Calendar {
+	log.Printf("💬 🤝  (pkg/service/calendar.go) Calendar(len(sessions): %v, len(reminders): %v, len(prescriptions): %v)\n", len(sessions), len(reminders), len(prescriptions))
+	c := &ical.Calendar{ProdID: CalendarProdID}
+	for _, s := range sessions {
+		if s.StartedAt.After(at) {
+			continue
+		}
+		c.Events = append(c.Events, sessionEvent(s))
+	}
+	for _, r := range reminders {
+		c.Events = append(c.Events, reminderEvents(r)...)
+	}
+	for _, p := range prescriptions {
+		c.Events = append(c.Events, expiryEvent(p))
+	}
+	return c
+}
+
+// sessionEvent returns the event of the given session.
+func sessionEvent(s *can.Session) *ical.Event {
+	details := []string{
+		fmt.Sprintf("%s g, %s", i18n.FormatFloat(s.Grams, 2), can.ConsumptionMethods[s.Method]),
+		fmt.Sprintf("%s mg THC, %s mg CBD", i18n.FormatFloat(s.THC, 1), i18n.FormatFloat(s.CBD, 1)),
+	}
+	for _, sc := range s.Symptoms {
+		details = append(details, fmt.Sprintf("%s: %d → %d", sc.Symptom, sc.Before, sc.After))
+	}
+	if len(s.Effects) > 0 {
+		details = append(details, "Effects: "+strings.Join(s.Effects, ", "))
+	}
+	if len(s.SideEffects) > 0 {
+		details = append(details, "Side effects: "+strings.Join(s.SideEffects, ", "))
+	}
+	if s.Notes != "" {
+		details = append(details, s.Notes)
+	}
+	return &ical.Event{
+		UID:         uid(s.ID.String()),
+		Summary:     "Session: " + s.Strain,
+		Description: strings.Join(details, "\n"),
+		Start:       s.StartedAt,
+	}
+}
+
+// reminderEvents returns a recurring event per scheduled time of the given
+// reminder. The events are floating, so that they ring at the scheduled time
+// of day wherever the calendar is used. Times which are not valid are skipped.
+func reminderEvents(r *can.Reminder) []*ical.Event {
+	rule := "FREQ=DAILY"
+	if len(r.Weekdays) > 0 {
+		days := make([]string, len(r.Weekdays))
+		for i, d := range r.Weekdays {
+			days[i] = strings.ToUpper(d.String()[:2])
+		}
+		rule = "FREQ=WEEKLY;BYDAY=" + strings.Join(days, ",")
+	}
+	// The start is the first occurrence, as it always counts as one
+	first := startOfDay(r.CreatedAt)
+	for i := 0; i < 7 && len(r.Weekdays) > 0 && !slices.Contains(r.Weekdays, first.Weekday()); i++ {
+		first = first.AddDate(0, 0, 1)
+	}
+	summary, description := ReminderKindText(r.Kind), ReminderDescription(r)
+	var events []*ical.Event
+	for _, t := range r.Times {
+		h, m, err := can.ParseTimeOfDay(t)
+		if err != nil {
+			continue
+		}
+		events = append(events, &ical.Event{
+			UID:         uid(fmt.Sprintf("%s-%02d%02d", r.ID, h, m)),
+			Summary:     summary,
+			Description: description,
+			Start:       time.Date(first.Year(), first.Month(), first.Day(), h, m, 0, 0, first.Location()),
+			Floating:    true,
+			RRule:       rule,
+			Alarms:      []ical.Alarm{{Description: summary}},
+		})
+	}
+	return events
+}
+
+// expiryEvent returns the all day event of the last valid day of the given
+// prescription.
+func expiryEvent(p *can.Prescription) *ical.Event {
+	summary := fmt.Sprintf("Prescription of %s expires", p.Doctor)
+	days := p.PeriodDays
+	if days <= 0 {
+		days = can.DefaultQuotaPeriod
+	}
+	description := fmt.Sprintf("Issued on %s, %s g per %d days.", p.IssuedAt.Format(time.DateOnly), i18n.FormatFloat(p.Grams, 1), days)
+	if !p.HasQuota() {
+		description = fmt.Sprintf("Issued on %s, without quota.", p.IssuedAt.Format(time.DateOnly))
+	}
+	if len(p.Strains) > 0 {
+		description += " Strains: " + strings.Join(p.Strains, ", ")
+	}
+	return &ical.Event{
+		UID:         uid(p.ID.String() + "-expiry"),
+		Summary:     summary,
+		Description: description,
+		Start:       p.ValidUntil,
+		AllDay:      true,
+		Alarms: []ical.Alarm{{
+			Trigger:     -ExpiryWarningDays * 24 * time.Hour,
+			Description: fmt.Sprintf("%s in %d days", summary, ExpiryWarningDays),
+		}},
+	}
+}
+
+// uid returns the globally unique identifier of an event with the given local
+// identifier.
+func uid(id string) string {
+	return id + "@" + calendarDomain
+}
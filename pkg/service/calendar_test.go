@@ -0,0 +1,73 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	can "github.com/TheDonDope/wits-tui/pkg/cannabis"
+	"github.com/TheDonDope/wits-tui/pkg/ical"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalendar(t *testing.T) {
+	at := time.Date(2024, time.March, 13, 12, 0, 0, 0, time.UTC)
+	past := &can.Session{ID: uuid.New(), Strain: "Pink Kush", Grams: 0.1, Method: can.Vaporizing, THC: 22, CBD: 0.1,
+		StartedAt: at.Add(-time.Hour), Effects: []string{"relaxed"}}
+	future := &can.Session{ID: uuid.New(), Strain: "Pink Kush", StartedAt: at.Add(time.Hour)}
+	// Created on a Wednesday, scheduled on Fridays and Mondays
+	r := &can.Reminder{ID: uuid.New(), Kind: can.DoseReminder, Times: []string{"08:00", "20:30", "invalid"},
+		Weekdays: []time.Weekday{time.Friday, time.Monday}, Strain: "Pink Kush", Grams: 0.1, CreatedAt: at}
+	p := &can.Prescription{ID: uuid.New(), Doctor: "Dr. Test", IssuedAt: at.AddDate(0, -1, 0),
+		ValidUntil: time.Date(2024, time.June, 30, 0, 0, 0, 0, time.UTC), Grams: 30}
+
+	c := Calendar([]*can.Session{past, future}, []*can.Reminder{r}, []*can.Prescription{p}, at)
+
+	assert.Equal(t, CalendarProdID, c.ProdID)
+	require.Len(t, c.Events, 4)
+
+	session := c.Events[0]
+	assert.Equal(t, past.ID.String()+"@wits-tui", session.UID)
+	assert.Equal(t, "Session: Pink Kush", session.Summary)
+	assert.Equal(t, "0.10 g, Vaporizing\n22.0 mg THC, 0.1 mg CBD\nEffects: relaxed", session.Description)
+	assert.Equal(t, past.StartedAt, session.Start)
+	assert.Empty(t, session.Alarms)
+
+	for i, want := range []string{"0800", "2030"} {
+		e := c.Events[1+i]
+		assert.Equal(t, r.ID.String()+"-"+want+"@wits-tui", e.UID)
+		assert.Equal(t, "Dose", e.Summary)
+		assert.Equal(t, "Take 0.10 g of Pink Kush.", e.Description)
+		assert.Equal(t, "FREQ=WEEKLY;BYDAY=FR,MO", e.RRule)
+		assert.True(t, e.Floating)
+		assert.Equal(t, time.Date(2024, time.March, 15, 0, 0, 0, 0, time.UTC), startOfDay(e.Start), "first Friday")
+		assert.Equal(t, []ical.Alarm{{Description: "Dose"}}, e.Alarms)
+	}
+	assert.Equal(t, 20, c.Events[2].Start.Hour())
+	assert.Equal(t, 30, c.Events[2].Start.Minute())
+
+	expiry := c.Events[3]
+	assert.Equal(t, p.ID.String()+"-expiry@wits-tui", expiry.UID)
+	assert.Equal(t, "Prescription of Dr. Test expires", expiry.Summary)
+	assert.True(t, expiry.AllDay)
+	assert.Equal(t, p.ValidUntil, expiry.Start)
+	require.Len(t, expiry.Alarms, 1)
+	assert.Equal(t, -ExpiryWarningDays*24*time.Hour, expiry.Alarms[0].Trigger)
+}
+
+func TestCalendarStableUIDs(t *testing.T) {
+	at := time.Date(2024, time.March, 13, 12, 0, 0, 0, time.UTC)
+	r := &can.Reminder{ID: uuid.New(), Kind: can.RefillReminder, Times: []string{"09:00"}, CreatedAt: at}
+
+	first := Calendar(nil, []*can.Reminder{r}, nil, at)
+	r.Notes = "Call the practice"
+	second := Calendar(nil, []*can.Reminder{r}, nil, at.AddDate(0, 1, 0))
+
+	require.Len(t, first.Events, 1)
+	require.Len(t, second.Events, 1)
+	assert.Equal(t, first.Events[0].UID, second.Events[0].UID)
+	assert.Equal(t, "FREQ=DAILY", second.Events[0].RRule)
+	assert.Equal(t, "Renew your prescription if it expires within 14 days or its quota is nearly used up. Call the practice",
+		second.Events[0].Description)
+}
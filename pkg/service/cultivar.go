@@ -0,0 +1,161 @@
+package service
+
+import (
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	can "github.com/TheDonDope/wits-tui/pkg/cannabis"
+	"github.com/TheDonDope/wits-tui/pkg/storage"
+	"github.com/google/uuid"
+)
+
+// CultivarService provides operations on cultivars.
+type CultivarService interface {
+	SaveCultivar(c *can.Cultivar) error
+	GetCultivars() []*can.Cultivar
+	FindCultivar(name string) (*can.Cultivar, error)
+	SyncStrains(strains []*can.Strain) (int, error)
+	Lineage(name string, strains []*can.Strain) *LineageNode
+}
+
+// CultivarServiceType provides operations on cultivars, accessing a store.
+type CultivarServiceType struct {
+	store storage.CultivarStore
+}
+
+// NewCultivarService creates a new service layer for cultivars.
+func NewCultivarService(s storage.CultivarStore) *CultivarServiceType {
+	log.Println("✅ 🤝  (pkg/service/cultivar.go) NewCultivarService(s storage.CultivarStore)")
+	return &CultivarServiceType{store: s}
+}
+
+// SaveCultivar adds the cultivar to the store, or replaces the cultivar with
+// the same name.
+func (svc *CultivarServiceType) SaveCultivar(c *can.Cultivar) error {
+	log.Printf("💬 🤝  (pkg/service/cultivar.go) SaveCultivar(c *can.Cultivar: %v)\n", c.ID)
+	c.UpdatedAt = time.Now()
+	if existing, err := svc.store.FindCultivar(c.Name); err == nil {
+		c.ID, c.CreatedAt = existing.ID, existing.CreatedAt
+		return svc.store.UpdateCultivar(c)
+	}
+	if c.ID == uuid.Nil {
+		c.ID = uuid.New()
+	}
+	if c.CreatedAt.IsZero() {
+		c.CreatedAt = c.UpdatedAt
+	}
+	return svc.store.AddCultivar(c)
+}
+
+// GetCultivars retrieves all cultivars from the store.
+func (svc *CultivarServiceType) GetCultivars() []*can.Cultivar {
+	log.Println("💬 🤝  (pkg/service/cultivar.go) GetCultivars()")
+	return svc.store.GetCultivars()
+}
+
+// FindCultivar finds a cultivar by its name, ignoring case.
+func (svc *CultivarServiceType) FindCultivar(name string) (*can.Cultivar, error) {
+	log.Printf("💬 🤝  (pkg/service/cultivar.go) FindCultivar(name string: %v)\n", name)
+	return svc.store.FindCultivar(name)
+}
+
+// SyncStrains adds a cultivar for every cultivar name of the given strains
+// which is not in the store yet, so that the free text cultivars of strains
+// stored before cultivars were introduced become shared entities. It returns
+// the number of added cultivars.
+func (svc *CultivarServiceType) SyncStrains(strains []*can.Strain) (int, error) {
+	log.Printf("💬 🤝  (pkg/service/cultivar.go) SyncStrains(len(strains): %v)\n", len(strains))
+	added := 0
+	for _, s := range strains {
+		name := strings.TrimSpace(s.Cultivar)
+		if name == "" {
+			continue
+		}
+		if _, err := svc.store.FindCultivar(name); !errors.Is(err, storage.ErrCultivarNotFound) {
+			continue
+		}
+		c := &can.Cultivar{ID: uuid.New(), Name: name, CreatedAt: s.CreatedAt, UpdatedAt: s.CreatedAt}
+		if err := svc.store.AddCultivar(c); err != nil {
+			return added, err
+		}
+		added++
+	}
+	return added, nil
+}
+
+// Lineage returns the lineage of the cultivar with the given name, with the
+// given strains of every cultivar of it.
+func (svc *CultivarServiceType) Lineage(name string, strains []*can.Strain) *LineageNode {
+	log.Printf("💬 🤝  (pkg/service/cultivar.go) Lineage(name string: %v)\n", name)
+	return BuildLineage(name, svc.store.GetCultivars(), strains)
+}
+
+// LineageNode is a cultivar within the lineage of another one.
+type LineageNode struct {
+	Name     string         // The name of the cultivar
+	Cultivar *can.Cultivar  // The cultivar, nil if it is only known as a parent
+	Strains  []*can.Strain  // The strains of the cultivar in the inventory
+	Parents  []*LineageNode // The parents, for the cultivar and its ancestors
+	Children []*LineageNode // The children, for the cultivar and its descendants
+}
+
+// BuildLineage returns the lineage of the cultivar with the given name among
+// the given cultivars: its ancestors as the tree of parents and its
+// descendants as the tree of children, in the order of the given cultivars.
+// Every node lists the given strains of
+// the cultivar. Cultivars repeating along a branch, i.e. backcrosses or
+// erroneous cycles, are not descended into again.
+func BuildLineage(name string, cultivars []*can.Cultivar, strains []*can.Strain) *LineageNode {
+	byName := map[string]*can.Cultivar{}
+	for _, c := range cultivars {
+		byName[strings.ToLower(c.Name)] = c
+	}
+	node := func(name string) *LineageNode {
+		n := &LineageNode{Name: name, Cultivar: byName[strings.ToLower(name)]}
+		if n.Cultivar != nil {
+			n.Name = n.Cultivar.Name
+		}
+		for _, s := range strains {
+			if strings.EqualFold(strings.TrimSpace(s.Cultivar), n.Name) {
+				n.Strains = append(n.Strains, s)
+			}
+		}
+		return n
+	}
+	var ancestors func(n *LineageNode, branch map[string]bool)
+	ancestors = func(n *LineageNode, branch map[string]bool) {
+		if n.Cultivar == nil {
+			return
+		}
+		for _, p := range n.Cultivar.Parents {
+			parent := node(p)
+			n.Parents = append(n.Parents, parent)
+			if key := strings.ToLower(parent.Name); !branch[key] {
+				branch[key] = true
+				ancestors(parent, branch)
+				delete(branch, key)
+			}
+		}
+	}
+	var descendants func(n *LineageNode, branch map[string]bool)
+	descendants = func(n *LineageNode, branch map[string]bool) {
+		for _, c := range cultivars {
+			if !c.HasParent(n.Name) {
+				continue
+			}
+			child := node(c.Name)
+			n.Children = append(n.Children, child)
+			if key := strings.ToLower(child.Name); !branch[key] {
+				branch[key] = true
+				descendants(child, branch)
+				delete(branch, key)
+			}
+		}
+	}
+	root := node(name)
+	ancestors(root, map[string]bool{strings.ToLower(root.Name): true})
+	descendants(root, map[string]bool{strings.ToLower(root.Name): true})
+	return root
+}
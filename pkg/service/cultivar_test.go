@@ -0,0 +1,86 @@
+package service
+
+import (
+	"testing"
+
+	can "github.com/TheDonDope/wits-tui/pkg/cannabis"
+	"github.com/TheDonDope/wits-tui/pkg/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCultivarService_SaveCultivar(t *testing.T) {
+	t.Setenv("STORAGE_MODE", storage.StoreInMemory)
+	svc := NewCultivarService(storage.NewCultivarStore())
+
+	require.NoError(t, svc.SaveCultivar(&can.Cultivar{Name: "Gelato"}))
+	first, err := svc.FindCultivar("gelato")
+	require.NoError(t, err)
+
+	require.NoError(t, svc.SaveCultivar(&can.Cultivar{Name: "GELATO", Indica: 55, Sativa: 45}))
+	second, err := svc.FindCultivar("Gelato")
+	require.NoError(t, err)
+
+	assert.Equal(t, first.ID, second.ID, "saving a cultivar of the same name updates it")
+	assert.Equal(t, first.CreatedAt, second.CreatedAt)
+	assert.Equal(t, "55/45", second.Ratio())
+	assert.Len(t, svc.GetCultivars(), 1)
+}
+
+func TestCultivarService_SyncStrains(t *testing.T) {
+	t.Setenv("STORAGE_MODE", storage.StoreInMemory)
+	svc := NewCultivarService(storage.NewCultivarStore())
+	require.NoError(t, svc.SaveCultivar(&can.Cultivar{Name: "Gelato", Indica: 55, Sativa: 45}))
+	a, b, c, d := testStrain(), testStrain(), testStrain(), testStrain()
+	a.Cultivar, b.Cultivar, c.Cultivar, d.Cultivar = "Ghost Train Haze", " ghost train haze", "gelato", ""
+
+	added, err := svc.SyncStrains([]*can.Strain{a, b, c, d})
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, added)
+	haze, err := svc.FindCultivar("Ghost Train Haze")
+	require.NoError(t, err)
+	assert.False(t, haze.RatioKnown(), "migrated cultivars have an unknown ratio")
+	gelato, err := svc.FindCultivar("Gelato")
+	require.NoError(t, err)
+	assert.Equal(t, "55/45", gelato.Ratio(), "existing cultivars are kept")
+
+	added, err = svc.SyncStrains([]*can.Strain{a, b, c, d})
+	require.NoError(t, err)
+	assert.Zero(t, added)
+}
+
+func TestBuildLineage(t *testing.T) {
+	cultivars := []*can.Cultivar{
+		{Name: "Gelato", Parents: []string{"Sunset Sherbet", "Thin Mint GSC"}},
+		{Name: "Thin Mint GSC", Parents: []string{"OG Kush", "Durban Poison"}},
+		{Name: "Gelato 41", Parents: []string{"gelato"}},
+		{Name: "Loop", Parents: []string{"Gelato 41"}},
+		{Name: "Gelato 41 BX", Parents: []string{"Gelato 41", "Loop"}},
+	}
+	// A cycle, which is erroneous, but must not hang the lineage.
+	cultivars[1].Parents = append(cultivars[1].Parents, "Gelato")
+	strain := testStrain()
+	strain.Cultivar = "GELATO"
+
+	root := BuildLineage("gelato", cultivars, []*can.Strain{strain})
+
+	assert.Equal(t, "Gelato", root.Name)
+	assert.Equal(t, []*can.Strain{strain}, root.Strains)
+	require.Len(t, root.Parents, 2)
+	assert.Nil(t, root.Parents[0].Cultivar, "parents may be unknown cultivars")
+	assert.Empty(t, root.Parents[0].Parents)
+	mint := root.Parents[1]
+	require.Len(t, mint.Parents, 3)
+	assert.Equal(t, "Gelato", mint.Parents[2].Name)
+	assert.Empty(t, mint.Parents[2].Parents, "cycles are not descended into")
+
+	require.Len(t, root.Children, 2)
+	assert.Equal(t, "Thin Mint GSC", root.Children[0].Name)
+	assert.Equal(t, "Gelato 41", root.Children[1].Name)
+	g41 := root.Children[1]
+	require.Len(t, g41.Children, 2)
+	assert.Equal(t, "Loop", g41.Children[0].Name)
+	assert.Equal(t, "Gelato 41 BX", g41.Children[0].Children[0].Name)
+	assert.Equal(t, "Gelato 41 BX", g41.Children[1].Name)
+}
@@ -0,0 +1,296 @@
+package service
+
+import (
+	"cmp"
+	"errors"
+	"log"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	can "github.com/TheDonDope/wits-tui/pkg/cannabis"
+	"github.com/TheDonDope/wits-tui/pkg/i18n"
+	"github.com/TheDonDope/wits-tui/pkg/storage"
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrNameMissing is returned when a manufacturer or pharmacy has no name.
+	ErrNameMissing = errors.New("Name of the manufacturer or pharmacy is missing")
+	// ErrUnknownCountry is returned when a country is no ISO 3166-1 country.
+	ErrUnknownCountry = errors.New("Country is no ISO 3166-1 country")
+)
+
+// DirectoryService provides operations on the directory of manufacturers and
+// pharmacies.
+type DirectoryService interface {
+	SaveManufacturer(m *can.Manufacturer) error
+	GetManufacturers() []*can.Manufacturer
+	FindManufacturer(name string) (*can.Manufacturer, error)
+	SavePharmacy(p *can.Pharmacy) error
+	GetPharmacies() []*can.Pharmacy
+	FindPharmacy(name string) (*can.Pharmacy, error)
+	Migrate(strains []*can.Strain) (*DirectoryMigration, error)
+}
+
+// DirectoryServiceType provides operations on the directory, accessing the
+// manufacturer and pharmacy stores.
+type DirectoryServiceType struct {
+	manufacturers storage.ManufacturerStore
+	pharmacies    storage.PharmacyStore
+}
+
+// NewDirectoryService creates a new service layer for the directory.
+func NewDirectoryService(m storage.ManufacturerStore, p storage.PharmacyStore) *DirectoryServiceType {
+	log.Println("✅ 🤝  (pkg/service/directory.go) NewDirectoryService(m storage.ManufacturerStore, p storage.PharmacyStore)")
+	return &DirectoryServiceType{manufacturers: m, pharmacies: p}
+}
+
+// SaveManufacturer adds the manufacturer to the store, or updates it if it is
+// stored already. A previous name of a renamed manufacturer is kept as an
+// alias, so strains still referring to it are found. Other manufacturers known
+// by the name or an alias of the manufacturer are merged into it, keeping their
+// names as aliases.
+func (svc *DirectoryServiceType) SaveManufacturer(m *can.Manufacturer) error {
+	log.Printf("💬 🤝  (pkg/service/directory.go) SaveManufacturer(m *can.Manufacturer: %v)\n", m.ID)
+	if err := normalizeEntry(&m.Name, &m.Aliases, &m.Country); err != nil {
+		return err
+	}
+	for _, other := range svc.manufacturers.GetManufacturers() {
+		if other.ID == m.ID {
+			m.Aliases = mergeAliases(m.Name, m.Aliases, other.Name, nil)
+			continue
+		}
+		if !overlaps(m.Name, m.Aliases, other.Name, other.Aliases) {
+			continue
+		}
+		m.Aliases = mergeAliases(m.Name, m.Aliases, other.Name, other.Aliases)
+		m.Country = cmp.Or(m.Country, other.Country)
+		m.Website = cmp.Or(m.Website, other.Website)
+		m.Notes = cmp.Or(m.Notes, other.Notes)
+		if err := svc.manufacturers.RemoveManufacturer(other.ID); err != nil {
+			return err
+		}
+	}
+	m.UpdatedAt = time.Now()
+	if m.ID != uuid.Nil {
+		if err := svc.manufacturers.UpdateManufacturer(m); !errors.Is(err, storage.ErrManufacturerNotFound) {
+			return err
+		}
+	} else {
+		m.ID = uuid.New()
+	}
+	if m.CreatedAt.IsZero() {
+		m.CreatedAt = m.UpdatedAt
+	}
+	return svc.manufacturers.AddManufacturer(m)
+}
+
+// GetManufacturers retrieves all manufacturers from the store.
+func (svc *DirectoryServiceType) GetManufacturers() []*can.Manufacturer {
+	log.Println("💬 🤝  (pkg/service/directory.go) GetManufacturers()")
+	return svc.manufacturers.GetManufacturers()
+}
+
+// FindManufacturer finds a manufacturer by its name or one of its aliases.
+func (svc *DirectoryServiceType) FindManufacturer(name string) (*can.Manufacturer, error) {
+	log.Printf("💬 🤝  (pkg/service/directory.go) FindManufacturer(name string: %v)\n", name)
+	return svc.manufacturers.FindManufacturer(name)
+}
+
+// SavePharmacy adds the pharmacy to the store, or updates it if it is stored
+// already. A previous name of a renamed pharmacy is kept as an alias, so
+// batches still referring to it are found. Other pharmacies known by the name
+// or an alias of the pharmacy are merged into it, keeping their names as
+// aliases.
+func (svc *DirectoryServiceType) SavePharmacy(p *can.Pharmacy) error {
+	log.Printf("💬 🤝  (pkg/service/directory.go) SavePharmacy(p *can.Pharmacy: %v)\n", p.ID)
+	if err := normalizeEntry(&p.Name, &p.Aliases, &p.Country); err != nil {
+		return err
+	}
+	for _, other := range svc.pharmacies.GetPharmacies() {
+		if other.ID == p.ID {
+			p.Aliases = mergeAliases(p.Name, p.Aliases, other.Name, nil)
+			continue
+		}
+		if !overlaps(p.Name, p.Aliases, other.Name, other.Aliases) {
+			continue
+		}
+		p.Aliases = mergeAliases(p.Name, p.Aliases, other.Name, other.Aliases)
+		p.Country = cmp.Or(p.Country, other.Country)
+		p.Website = cmp.Or(p.Website, other.Website)
+		p.Notes = cmp.Or(p.Notes, other.Notes)
+		if err := svc.pharmacies.RemovePharmacy(other.ID); err != nil {
+			return err
+		}
+	}
+	p.UpdatedAt = time.Now()
+	if p.ID != uuid.Nil {
+		if err := svc.pharmacies.UpdatePharmacy(p); !errors.Is(err, storage.ErrPharmacyNotFound) {
+			return err
+		}
+	} else {
+		p.ID = uuid.New()
+	}
+	if p.CreatedAt.IsZero() {
+		p.CreatedAt = p.UpdatedAt
+	}
+	return svc.pharmacies.AddPharmacy(p)
+}
+
+// GetPharmacies retrieves all pharmacies from the store.
+func (svc *DirectoryServiceType) GetPharmacies() []*can.Pharmacy {
+	log.Println("💬 🤝  (pkg/service/directory.go) GetPharmacies()")
+	return svc.pharmacies.GetPharmacies()
+}
+
+// FindPharmacy finds a pharmacy by its name or one of its aliases.
+func (svc *DirectoryServiceType) FindPharmacy(name string) (*can.Pharmacy, error) {
+	log.Printf("💬 🤝  (pkg/service/directory.go) FindPharmacy(name string: %v)\n", name)
+	return svc.pharmacies.FindPharmacy(name)
+}
+
+// DirectoryMigration is the result of mapping the free text manufacturers,
+// countries and pharmacies of strains onto the directory.
+type DirectoryMigration struct {
+	Strains          []*can.Strain // The strains whose values changed
+	Manufacturers    int           // The number of added manufacturers
+	Pharmacies       int           // The number of added pharmacies
+	UnknownCountries []string      // The countries which are no ISO 3166-1 country, sorted
+}
+
+// Migrate maps the manufacturers, countries and batch pharmacies of the given
+// strains onto the directory, changing the strains in place:
+//   - a manufacturer or pharmacy is replaced by the name of the entry known by
+//     it, which is added if there is none yet
+//   - a country is replaced by its ISO 3166-1 alpha-2 code, or by the country
+//     of the manufacturer if it is missing
+//
+// The caller is responsible for storing the returned changed strains. Unknown
+// countries are kept as they are.
+func (svc *DirectoryServiceType) Migrate(strains []*can.Strain) (*DirectoryMigration, error) {
+	log.Printf("💬 🤝  (pkg/service/directory.go) Migrate(len(strains): %v)\n", len(strains))
+	result := &DirectoryMigration{}
+	unknown := map[string]bool{}
+	for _, s := range strains {
+		changed := false
+		if s.Country != "" {
+			if code, ok := ParseCountry(s.Country); !ok {
+				unknown[s.Country] = true
+			} else if code != s.Country {
+				s.Country, changed = code, true
+			}
+		}
+		if name := strings.TrimSpace(s.Manufacturer); name != "" {
+			m, err := svc.manufacturers.FindManufacturer(name)
+			switch {
+			case errors.Is(err, storage.ErrManufacturerNotFound):
+				m = &can.Manufacturer{ID: uuid.New(), Name: name, CreatedAt: time.Now(), UpdatedAt: time.Now()}
+				if _, ok := can.Countries[s.Country]; ok {
+					m.Country = s.Country
+				}
+				if err := svc.manufacturers.AddManufacturer(m); err != nil {
+					return result, err
+				}
+				result.Manufacturers++
+			case err != nil:
+				return result, err
+			}
+			if s.Manufacturer != m.Name {
+				s.Manufacturer, changed = m.Name, true
+			}
+			if s.Country == "" && m.Country != "" {
+				s.Country, changed = m.Country, true
+			}
+		}
+		for _, b := range s.Batches {
+			name := strings.TrimSpace(b.Pharmacy)
+			if name == "" {
+				continue
+			}
+			p, err := svc.pharmacies.FindPharmacy(name)
+			switch {
+			case errors.Is(err, storage.ErrPharmacyNotFound):
+				p = &can.Pharmacy{ID: uuid.New(), Name: name, CreatedAt: time.Now(), UpdatedAt: time.Now()}
+				if err := svc.pharmacies.AddPharmacy(p); err != nil {
+					return result, err
+				}
+				result.Pharmacies++
+			case err != nil:
+				return result, err
+			}
+			if b.Pharmacy != p.Name {
+				b.Pharmacy, changed = p.Name, true
+			}
+		}
+		if changed {
+			result.Strains = append(result.Strains, s)
+		}
+	}
+	for c := range unknown {
+		result.UnknownCountries = append(result.UnknownCountries, c)
+	}
+	sort.Strings(result.UnknownCountries)
+	return result, nil
+}
+
+// ParseCountry returns the ISO 3166-1 alpha-2 code of the given country, which
+// is given by its code, its English name or its name in the current locale,
+// ignoring case. It returns false if the country is unknown.
+func ParseCountry(s string) (string, bool) {
+	if code, ok := can.ParseCountry(s); ok {
+		return code, true
+	}
+	s = strings.TrimSpace(s)
+	for code, name := range can.Countries {
+		if strings.EqualFold(i18n.Term(name), s) {
+			return code, true
+		}
+	}
+	return "", false
+}
+
+// normalizeEntry trims the name and aliases of a directory entry, drops empty
+// aliases and those repeating the name, and replaces its country by its code.
+func normalizeEntry(name *string, aliases *[]string, country *string) error {
+	*name = strings.TrimSpace(*name)
+	if *name == "" {
+		return ErrNameMissing
+	}
+	*aliases = mergeAliases(*name, *aliases, "", nil)
+	if *country = strings.TrimSpace(*country); *country != "" {
+		code, ok := ParseCountry(*country)
+		if !ok {
+			return ErrUnknownCountry
+		}
+		*country = code
+	}
+	return nil
+}
+
+// overlaps reports whether two directory entries, given by their names and
+// aliases, share a name or an alias, ignoring case.
+func overlaps(name string, aliases []string, otherName string, otherAliases []string) bool {
+	for _, n := range append([]string{name}, aliases...) {
+		if strings.EqualFold(n, otherName) || slices.ContainsFunc(otherAliases, func(a string) bool { return strings.EqualFold(a, n) }) {
+			return true
+		}
+	}
+	return false
+}
+
+// mergeAliases returns the given aliases of the entry of the given name,
+// followed by the name and aliases of another entry merged into it. Empty
+// aliases and those repeating the name or another alias are dropped.
+func mergeAliases(name string, aliases []string, otherName string, otherAliases []string) []string {
+	var merged []string
+	for _, a := range slices.Concat(aliases, []string{otherName}, otherAliases) {
+		a = strings.TrimSpace(a)
+		if a == "" || strings.EqualFold(a, name) || slices.ContainsFunc(merged, func(m string) bool { return strings.EqualFold(m, a) }) {
+			continue
+		}
+		merged = append(merged, a)
+	}
+	return merged
+}
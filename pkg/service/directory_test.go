@@ -0,0 +1,141 @@
+package service
+
+import (
+	"testing"
+
+	can "github.com/TheDonDope/wits-tui/pkg/cannabis"
+	"github.com/TheDonDope/wits-tui/pkg/storage"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testDirectoryService returns a directory service with in memory stores.
+func testDirectoryService(t *testing.T) *DirectoryServiceType {
+	t.Setenv("STORAGE_MODE", storage.StoreInMemory)
+	return NewDirectoryService(storage.NewManufacturerStore(), storage.NewPharmacyStore())
+}
+
+func TestDirectoryService_SaveManufacturer(t *testing.T) {
+	svc := testDirectoryService(t)
+
+	assert.ErrorIs(t, svc.SaveManufacturer(&can.Manufacturer{Name: " "}), ErrNameMissing)
+	assert.ErrorIs(t, svc.SaveManufacturer(&can.Manufacturer{Name: "Aurora", Country: "Atlantis"}), ErrUnknownCountry)
+
+	aurora := &can.Manufacturer{Name: " Aurora ", Aliases: []string{"aurora", " ", "Aurora Cannabis"}, Country: "canada"}
+	require.NoError(t, svc.SaveManufacturer(aurora))
+	assert.NotEqual(t, uuid.Nil, aurora.ID)
+	assert.Equal(t, "Aurora", aurora.Name)
+	assert.Equal(t, []string{"Aurora Cannabis"}, aurora.Aliases)
+	assert.Equal(t, "CA", aurora.Country)
+
+	aurora.Website = "https://example.com"
+	require.NoError(t, svc.SaveManufacturer(aurora))
+	assert.Len(t, svc.GetManufacturers(), 1, "saving a stored manufacturer updates it")
+
+	// Adding the name of another manufacturer as an alias merges it
+	pedanios := &can.Manufacturer{Name: "Pedanios", Aliases: []string{"Pedanios GmbH"}, Notes: "Importer"}
+	require.NoError(t, svc.SaveManufacturer(pedanios))
+	aurora.Aliases = append(aurora.Aliases, "pedanios")
+	require.NoError(t, svc.SaveManufacturer(aurora))
+
+	manufacturers := svc.GetManufacturers()
+	require.Len(t, manufacturers, 1)
+	assert.Equal(t, []string{"Aurora Cannabis", "pedanios", "Pedanios GmbH"}, manufacturers[0].Aliases)
+	assert.Equal(t, "Importer", manufacturers[0].Notes)
+	found, err := svc.FindManufacturer("PEDANIOS GMBH")
+	require.NoError(t, err)
+	assert.Equal(t, aurora.ID, found.ID)
+}
+
+func TestDirectoryService_SavePharmacy(t *testing.T) {
+	svc := testDirectoryService(t)
+	require.NoError(t, svc.SavePharmacy(&can.Pharmacy{Name: "Linden Apotheke"}))
+
+	linden := &can.Pharmacy{Name: "Linden-Apotheke", Aliases: []string{"Linden Apotheke"}, Country: "DE"}
+	require.NoError(t, svc.SavePharmacy(linden))
+
+	pharmacies := svc.GetPharmacies()
+	require.Len(t, pharmacies, 1)
+	assert.Equal(t, linden.ID, pharmacies[0].ID)
+	found, err := svc.FindPharmacy("linden apotheke")
+	require.NoError(t, err)
+	assert.Equal(t, "Linden-Apotheke", found.Name)
+}
+
+func TestDirectoryService_Migrate(t *testing.T) {
+	svc := testDirectoryService(t)
+	require.NoError(t, svc.SaveManufacturer(&can.Manufacturer{Name: "Aurora", Aliases: []string{"Aurora Cannabis"}}))
+	a, b, c, d := testStrain(), testStrain(), testStrain(), testStrain()
+	a.Manufacturer, a.Country = "aurora", "Canada"
+	b.Manufacturer, b.Country = "Aurora Cannabis", ""
+	c.Manufacturer, c.Country = "Bedrocan", "the netherlands"
+	d.Manufacturer, d.Country = "", "Atlantis"
+	a.AddBatch(&can.Batch{Pharmacy: "Linden-Apotheke"})
+	c.AddBatch(&can.Batch{Pharmacy: " linden-apotheke"})
+	c.AddBatch(&can.Batch{})
+
+	result, err := svc.Migrate([]*can.Strain{a, b, c, d})
+	require.NoError(t, err)
+
+	assert.Equal(t, []*can.Strain{a, b, c}, result.Strains)
+	assert.Equal(t, 1, result.Manufacturers)
+	assert.Equal(t, 1, result.Pharmacies)
+	assert.Equal(t, []string{"Atlantis"}, result.UnknownCountries)
+
+	assert.Equal(t, "Aurora", a.Manufacturer)
+	assert.Equal(t, "CA", a.Country)
+	assert.Equal(t, "Aurora", b.Manufacturer)
+	assert.Empty(t, b.Country, "the country of the manufacturer is unknown")
+	assert.Equal(t, "Bedrocan", c.Manufacturer)
+	assert.Equal(t, "NL", c.Country)
+	assert.ElementsMatch(t, []string{"Linden-Apotheke", ""}, []string{c.Batches[0].Pharmacy, c.Batches[1].Pharmacy})
+	assert.Equal(t, "Atlantis", d.Country)
+
+	bedrocan, err := svc.FindManufacturer("bedrocan")
+	require.NoError(t, err)
+	assert.Equal(t, "NL", bedrocan.Country, "added manufacturers take over the country")
+
+	result, err = svc.Migrate([]*can.Strain{a, b, c, d})
+	require.NoError(t, err)
+	assert.Empty(t, result.Strains, "the migration is idempotent")
+}
+
+func TestParseCountry(t *testing.T) {
+	code, ok := ParseCountry("Canada")
+	assert.True(t, ok)
+	assert.Equal(t, "CA", code)
+
+	_, ok = ParseCountry("Atlantis")
+	assert.False(t, ok)
+}
+
+func TestDirectoryService_Rename(t *testing.T) {
+	svc := testDirectoryService(t)
+	s := testStrain()
+	s.Manufacturer = "Aurora"
+	b := &can.Batch{Pharmacy: "Linden Apotheke"}
+	s.AddBatch(b)
+	_, err := svc.Migrate([]*can.Strain{s})
+	require.NoError(t, err)
+
+	// The forms edit copies, the stored entries keep the previous names
+	m := *svc.GetManufacturers()[0]
+	m.Name = "Aurora Cannabis"
+	require.NoError(t, svc.SaveManufacturer(&m))
+	p := *svc.GetPharmacies()[0]
+	p.Name = "Linden-Apotheke"
+	require.NoError(t, svc.SavePharmacy(&p))
+	assert.Equal(t, []string{"Aurora"}, m.Aliases, "the previous name is kept as an alias")
+
+	result, err := svc.Migrate([]*can.Strain{s})
+	require.NoError(t, err)
+
+	assert.Equal(t, []*can.Strain{s}, result.Strains)
+	assert.Zero(t, result.Manufacturers)
+	assert.Zero(t, result.Pharmacies)
+	assert.Len(t, svc.GetManufacturers(), 1)
+	assert.Len(t, svc.GetPharmacies(), 1)
+	assert.Equal(t, "Aurora Cannabis", s.Manufacturer)
+	assert.Equal(t, "Linden-Apotheke", b.Pharmacy)
+}
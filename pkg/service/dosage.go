@@ -0,0 +1,58 @@
+package service
+
+import (
+	"sort"
+	"time"
+
+	can "github.com/TheDonDope/wits-tui/pkg/cannabis"
+)
+
+// DailyDose is the summed dose of all sessions of a day.
+type DailyDose struct {
+	Day         time.Time // The start of the day
+	Sessions    int       // The number of sessions
+	Grams       float64   // The consumed amount in grams
+	THC         float64   // The consumed THC in mg
+	CBD         float64   // The consumed CBD in mg
+	AbsorbedTHC float64   // The THC in mg reaching the bloodstream
+	AbsorbedCBD float64   // The CBD in mg reaching the bloodstream
+}
+
+// TrackDosage sums the doses of the given sessions started in the time range
+// [from, to) per day, applying the bioavailability of the calculator to the
+// method of each session. A zero from or to leaves the respective bound open.
+// The days are ordered chronologically.
+func TrackDosage(sessions []*can.Session, c *can.DosageCalculator, from, to time.Time) []*DailyDose {
+	days := map[time.Time]*DailyDose{}
+	for _, s := range sessions {
+		if (!from.IsZero() && s.StartedAt.Before(from)) || (!to.IsZero() && !s.StartedAt.Before(to)) {
+			continue
+		}
+		day := startOfDay(s.StartedAt)
+		dd, ok := days[day]
+		if !ok {
+			dd = &DailyDose{Day: day}
+			days[day] = dd
+		}
+		d := c.Absorb(s.THC, s.CBD, s.Method)
+		dd.Sessions++
+		dd.Grams += s.Grams
+		dd.THC += d.THC
+		dd.CBD += d.CBD
+		dd.AbsorbedTHC += d.AbsorbedTHC
+		dd.AbsorbedCBD += d.AbsorbedCBD
+	}
+	var tracked []*DailyDose
+	for _, dd := range days {
+		tracked = append(tracked, dd)
+	}
+	sort.Slice(tracked, func(i, j int) bool {
+		return tracked[i].Day.Before(tracked[j].Day)
+	})
+	return tracked
+}
+
+// startOfDay returns the start of the day of the given time.
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
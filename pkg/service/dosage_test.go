@@ -0,0 +1,35 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	can "github.com/TheDonDope/wits-tui/pkg/cannabis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrackDosage(t *testing.T) {
+	day := time.Date(2024, time.March, 10, 0, 0, 0, 0, time.UTC)
+	sessions := []*can.Session{
+		{Grams: 0.2, THC: 40, CBD: 2, Method: can.Vaporizing, StartedAt: day.Add(20 * time.Hour)},
+		{Grams: 0.1, THC: 20, Method: can.Oral, StartedAt: day.Add(9 * time.Hour)},
+		{Grams: 0.3, THC: 60, Method: can.Smoking, StartedAt: day.AddDate(0, 0, -1)},
+		{Grams: 1, THC: 200, Method: can.Smoking, StartedAt: day.AddDate(0, 0, 1)}, // Outside the range
+	}
+	c := can.NewDosageCalculator(map[can.ConsumptionMethod]float64{can.Vaporizing: 0.5, can.Oral: 0.1, can.Smoking: 0.25})
+
+	tracked := TrackDosage(sessions, c, day.AddDate(0, 0, -1), day.AddDate(0, 0, 1))
+
+	require.Len(t, tracked, 2)
+	assert.Equal(t, day.AddDate(0, 0, -1), tracked[0].Day)
+	assert.InDelta(t, 15, tracked[0].AbsorbedTHC, 1e-9)
+
+	assert.Equal(t, day, tracked[1].Day)
+	assert.Equal(t, 2, tracked[1].Sessions)
+	assert.InDelta(t, 0.3, tracked[1].Grams, 1e-9)
+	assert.InDelta(t, 60, tracked[1].THC, 1e-9)
+	assert.InDelta(t, 2, tracked[1].CBD, 1e-9)
+	assert.InDelta(t, 22, tracked[1].AbsorbedTHC, 1e-9)
+	assert.InDelta(t, 1, tracked[1].AbsorbedCBD, 1e-9)
+}
@@ -0,0 +1,108 @@
+package service
+
+import (
+	"sort"
+
+	can "github.com/TheDonDope/wits-tui/pkg/cannabis"
+)
+
+// SymptomRelief is the average relief of a symptom by the sessions sharing a
+// strain, terpene or genetic.
+type SymptomRelief struct {
+	Symptom  string  // The name of the symptom
+	Group    string  // The product name, terpene name or genetic name
+	Relief   float64 // The average decrease of the severity
+	Sessions int     // The number of sessions the symptom was rated in
+}
+
+// ReliefReport correlates the relief of the rated symptoms with the strains,
+// terpenes and genetics of the sessions.
+type ReliefReport struct {
+	Journaled int             // The number of sessions with rated symptoms
+	Overall   []SymptomRelief // The relief per symptom over all sessions
+	ByStrain  []SymptomRelief // The relief per symptom and strain
+	ByTerpene []SymptomRelief // The relief per symptom and terpene of the strain
+	ByGenetic []SymptomRelief // The relief per symptom and genetic of the strain
+}
+
+// CorrelateRelief averages the relief of the symptoms rated in the given
+// sessions, overall and grouped by the strain consumed, its terpenes and its
+// genetic. Sessions of unknown strains only count overall. Every group is
+// sorted by symptom, best relief first.
+func CorrelateRelief(sessions []*can.Session, strains []*can.Strain) ReliefReport {
+	byProduct := map[string]*can.Strain{}
+	for _, s := range strains {
+		byProduct[s.Strain] = s
+	}
+	overall, byStrain, byTerpene, byGenetic := reliefSums{}, reliefSums{}, reliefSums{}, reliefSums{}
+	report := ReliefReport{}
+	for _, session := range sessions {
+		if len(session.Symptoms) == 0 {
+			continue
+		}
+		report.Journaled++
+		strain := byProduct[session.Strain]
+		for _, score := range session.Symptoms {
+			overall.add(score, "")
+			if strain == nil {
+				continue
+			}
+			byStrain.add(score, strain.Strain)
+			byGenetic.add(score, can.Genetics[strain.Genetic])
+			for _, t := range strain.Terpenes {
+				if t.Terpene() == nil {
+					continue
+				}
+				byTerpene.add(score, t.Terpene().Name)
+			}
+		}
+	}
+	report.Overall = overall.averages()
+	report.ByStrain = byStrain.averages()
+	report.ByTerpene = byTerpene.averages()
+	report.ByGenetic = byGenetic.averages()
+	return report
+}
+
+// reliefKey identifies the sum of the reliefs of a symptom within a group.
+type reliefKey struct {
+	symptom string
+	group   string
+}
+
+// reliefSums sums up the reliefs and their count per symptom and group.
+type reliefSums map[reliefKey]*SymptomRelief
+
+// add adds the relief of the given score to the given group.
+func (rs reliefSums) add(score *can.SymptomScore, group string) {
+	k := reliefKey{score.Symptom, group}
+	r, ok := rs[k]
+	if !ok {
+		r = &SymptomRelief{Symptom: score.Symptom, Group: group}
+		rs[k] = r
+	}
+	r.Relief += float64(score.Relief())
+	r.Sessions++
+}
+
+// averages returns the average reliefs, sorted by symptom and best relief
+// first.
+func (rs reliefSums) averages() []SymptomRelief {
+	reliefs := make([]SymptomRelief, 0, len(rs))
+	for _, r := range rs {
+		avg := *r
+		avg.Relief /= float64(avg.Sessions)
+		reliefs = append(reliefs, avg)
+	}
+	sort.Slice(reliefs, func(i, j int) bool {
+		a, b := reliefs[i], reliefs[j]
+		if a.Symptom != b.Symptom {
+			return a.Symptom < b.Symptom
+		}
+		if a.Relief != b.Relief {
+			return a.Relief > b.Relief
+		}
+		return a.Group < b.Group
+	})
+	return reliefs
+}
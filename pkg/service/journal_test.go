@@ -0,0 +1,50 @@
+package service
+
+import (
+	"testing"
+
+	can "github.com/TheDonDope/wits-tui/pkg/cannabis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCorrelateRelief(t *testing.T) {
+	kush := profiledStrain("Kush", can.Indica, 20, 0, &can.StrainTerpene{Type: can.BetaMyrcene}, &can.StrainTerpene{Type: can.Linalool})
+	haze := profiledStrain("Haze", can.Sativa, 18, 0, &can.StrainTerpene{Type: can.Limonene})
+	sessions := []*can.Session{
+		{Strain: "Kush", Symptoms: []*can.SymptomScore{{Symptom: "pain", Before: 8, After: 2}, {Symptom: "sleep", Before: 6, After: 2}}},
+		{Strain: "Kush", Symptoms: []*can.SymptomScore{{Symptom: "pain", Before: 7, After: 3}}},
+		{Strain: "Haze", Symptoms: []*can.SymptomScore{{Symptom: "pain", Before: 5, After: 6}}},
+		{Strain: "Gone", Symptoms: []*can.SymptomScore{{Symptom: "pain", Before: 9, After: 0}}},
+		{Strain: "Haze"}, // Without a journal
+	}
+
+	report := CorrelateRelief(sessions, []*can.Strain{kush, haze})
+
+	assert.Equal(t, 4, report.Journaled)
+	assert.Equal(t, []SymptomRelief{
+		{Symptom: "pain", Relief: 18.0 / 4, Sessions: 4},
+		{Symptom: "sleep", Relief: 4, Sessions: 1},
+	}, report.Overall)
+	assert.Equal(t, []SymptomRelief{
+		{Symptom: "pain", Group: "Kush", Relief: 5, Sessions: 2},
+		{Symptom: "pain", Group: "Haze", Relief: -1, Sessions: 1},
+		{Symptom: "sleep", Group: "Kush", Relief: 4, Sessions: 1},
+	}, report.ByStrain, "sessions of unknown strains only count overall")
+	assert.Equal(t, []SymptomRelief{
+		{Symptom: "pain", Group: "Indica", Relief: 5, Sessions: 2},
+		{Symptom: "pain", Group: "Sativa", Relief: -1, Sessions: 1},
+		{Symptom: "sleep", Group: "Indica", Relief: 4, Sessions: 1},
+	}, report.ByGenetic)
+	require.Len(t, report.ByTerpene, 5)
+	assert.Equal(t, SymptomRelief{Symptom: "pain", Group: "Linalool", Relief: 5, Sessions: 2}, report.ByTerpene[0])
+	assert.Equal(t, SymptomRelief{Symptom: "pain", Group: "Limonene", Relief: -1, Sessions: 1}, report.ByTerpene[2])
+}
+
+func TestCorrelateRelief_Empty(t *testing.T) {
+	report := CorrelateRelief(nil, nil)
+
+	assert.Zero(t, report.Journaled)
+	assert.Empty(t, report.Overall)
+	assert.Empty(t, report.ByStrain)
+}
@@ -0,0 +1,144 @@
+package service
+
+import (
+	"errors"
+	"log"
+	"math"
+	"time"
+
+	can "github.com/TheDonDope/wits-tui/pkg/cannabis"
+	"github.com/TheDonDope/wits-tui/pkg/storage"
+	"github.com/google/uuid"
+)
+
+const (
+	// ExpiryWarningDays is the number of days before the end of its validity
+	// from which on a prescription is about to expire.
+	ExpiryWarningDays = 14
+	// QuotaWarningRatio is the share of the quota from which on the quota of a
+	// prescription is nearly used up.
+	QuotaWarningRatio = 0.8
+)
+
+var (
+	// ErrPrescriptionNotValid is returned when a purchase is made outside of
+	// the validity of the prescription.
+	ErrPrescriptionNotValid = errors.New("Prescription is not valid at the purchase date")
+	// ErrStrainNotPrescribed is returned when a purchased strain is not
+	// allowed by the prescription.
+	ErrStrainNotPrescribed = errors.New("Strain is not allowed by the prescription")
+	// ErrQuotaExceeded is returned when a purchase exceeds the remaining quota
+	// of the prescription.
+	ErrQuotaExceeded = errors.New("Purchase exceeds the remaining quota of the prescription")
+)
+
+// PrescriptionWarningKind is the enum for the kinds of prescription warnings.
+type PrescriptionWarningKind int
+
+const (
+	// ExpiresSoon warns that a prescription is about to expire
+	ExpiresSoon PrescriptionWarningKind = iota
+	// QuotaNearlyUsed warns that the quota of a prescription is nearly used up
+	QuotaNearlyUsed
+)
+
+// PrescriptionWarning is a warning about a valid prescription.
+type PrescriptionWarning struct {
+	Prescription *can.Prescription       // The prescription warned about
+	Kind         PrescriptionWarningKind // The kind of the warning
+	DaysLeft     int                     // The days until the prescription expires
+	Remaining    float64                 // The grams left of the current quota
+}
+
+// PrescriptionService provides operations on prescriptions.
+type PrescriptionService interface {
+	AddPrescription(p *can.Prescription) error
+	GetPrescriptions() []*can.Prescription
+	FindPrescription(id uuid.UUID) (*can.Prescription, error)
+	CheckPurchase(product string, b *can.Batch, strains []*can.Strain) error
+	Warnings(strains []*can.Strain, at time.Time) []PrescriptionWarning
+}
+
+// PrescriptionServiceType provides operations on prescriptions, accessing a
+// store.
+type PrescriptionServiceType struct {
+	store storage.PrescriptionStore
+}
+
+// NewPrescriptionService creates a new service layer for prescriptions.
+func NewPrescriptionService(s storage.PrescriptionStore) *PrescriptionServiceType {
+	log.Println("✅ 🤝  (pkg/service/prescription.go) NewPrescriptionService(s storage.PrescriptionStore)")
+	return &PrescriptionServiceType{store: s}
+}
+
+// AddPrescription adds a prescription to the store.
+func (svc *PrescriptionServiceType) AddPrescription(p *can.Prescription) error {
+	log.Printf("💬 🤝  (pkg/service/prescription.go) AddPrescription(p *can.Prescription: %v)\n", p.ID)
+	return svc.store.AddPrescription(p)
+}
+
+// GetPrescriptions retrieves all prescriptions from the store.
+func (svc *PrescriptionServiceType) GetPrescriptions() []*can.Prescription {
+	log.Println("💬 🤝  (pkg/service/prescription.go) GetPrescriptions()")
+	return svc.store.GetPrescriptions()
+}
+
+// FindPrescription finds a prescription by its ID.
+func (svc *PrescriptionServiceType) FindPrescription(id uuid.UUID) (*can.Prescription, error) {
+	log.Printf("💬 🤝  (pkg/service/prescription.go) FindPrescription(id uuid.UUID: %v)\n", id)
+	return svc.store.FindPrescription(id)
+}
+
+// CheckPurchase checks the given batch of the strain with the given product
+// name against the prescription it is dispensed on. The remaining quota is
+// computed from the batches of the given strains. Batches without a
+// prescription are always allowed.
+func (svc *PrescriptionServiceType) CheckPurchase(product string, b *can.Batch, strains []*can.Strain) error {
+	log.Printf("💬 🤝  (pkg/service/prescription.go) CheckPurchase(product string: %v, b *can.Batch: %v)\n", product, b.ID)
+	if b.PrescriptionID == uuid.Nil {
+		return nil
+	}
+	p, err := svc.store.FindPrescription(b.PrescriptionID)
+	if err != nil {
+		return err
+	}
+	switch {
+	case !p.Valid(b.PurchasedAt):
+		return ErrPrescriptionNotValid
+	case !p.Allows(product):
+		return ErrStrainNotPrescribed
+	case p.HasQuota() && b.Grams > p.Remaining(strains, b.PurchasedAt):
+		return ErrQuotaExceeded
+	}
+	return nil
+}
+
+// Warnings returns the warnings about all prescriptions valid at the given
+// time which are about to expire or whose current quota is nearly used up by
+// the batches of the given strains.
+func (svc *PrescriptionServiceType) Warnings(strains []*can.Strain, at time.Time) []PrescriptionWarning {
+	log.Println("💬 🤝  (pkg/service/prescription.go) Warnings()")
+	var warnings []PrescriptionWarning
+	for _, p := range svc.store.GetPrescriptions() {
+		if !p.Valid(at) {
+			continue
+		}
+		days := daysUntil(at, p.ValidUntil)
+		remaining := p.Remaining(strains, at)
+		if days <= ExpiryWarningDays {
+			warnings = append(warnings, PrescriptionWarning{Prescription: p, Kind: ExpiresSoon, DaysLeft: days, Remaining: remaining})
+		}
+		if p.HasQuota() && p.Used(strains, at) >= p.Grams*QuotaWarningRatio {
+			warnings = append(warnings, PrescriptionWarning{Prescription: p, Kind: QuotaNearlyUsed, DaysLeft: days, Remaining: remaining})
+		}
+	}
+	return warnings
+}
+
+// daysUntil returns the number of calendar days from the given time until the
+// given day.
+func daysUntil(from, day time.Time) int {
+	start := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	end := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.UTC)
+	return int(math.Round(end.Sub(start).Hours() / 24))
+}
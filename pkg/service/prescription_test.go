@@ -0,0 +1,114 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	can "github.com/TheDonDope/wits-tui/pkg/cannabis"
+	"github.com/TheDonDope/wits-tui/pkg/storage"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testPrescriptionService returns a service with an in-memory store containing
+// a prescription issued on 2024-01-10 allowing 30 g of the test strain per 30
+// days until 2024-04-09.
+func testPrescriptionService(t *testing.T) (*PrescriptionServiceType, *can.Prescription) {
+	t.Helper()
+	t.Setenv("STORAGE_MODE", storage.StoreInMemory)
+	p := &can.Prescription{
+		ID:         uuid.New(),
+		Doctor:     "Dr. Test",
+		IssuedAt:   time.Date(2024, time.January, 10, 0, 0, 0, 0, time.UTC),
+		ValidUntil: time.Date(2024, time.April, 9, 0, 0, 0, 0, time.UTC),
+		Grams:      30,
+		PeriodDays: 30,
+		Strains:    []string{testStrain().Strain},
+	}
+	svc := NewPrescriptionService(storage.NewPrescriptionStore())
+	require.NoError(t, svc.AddPrescription(p))
+	return svc, p
+}
+
+func TestPrescriptionService(t *testing.T) {
+	t.Run("CheckPurchase", func(t *testing.T) {
+		svc, p := testPrescriptionService(t)
+		strain := testStrain()
+		strain.Batches = []*can.Batch{
+			{PrescriptionID: p.ID, Grams: 20, PurchasedAt: time.Date(2024, time.January, 15, 0, 0, 0, 0, time.UTC)},
+		}
+		strains := []*can.Strain{strain}
+		at := time.Date(2024, time.January, 20, 0, 0, 0, 0, time.UTC)
+
+		tests := []struct {
+			name    string
+			product string
+			batch   *can.Batch
+			wantErr error
+		}{
+			{"SelfPaid", strain.Strain, &can.Batch{Grams: 50, PurchasedAt: at}, nil},
+			{"WithinQuota", strain.Strain, &can.Batch{PrescriptionID: p.ID, Grams: 10, PurchasedAt: at}, nil},
+			{"QuotaExceeded", strain.Strain, &can.Batch{PrescriptionID: p.ID, Grams: 15, PurchasedAt: at}, ErrQuotaExceeded},
+			{"NextPeriod", strain.Strain, &can.Batch{PrescriptionID: p.ID, Grams: 30, PurchasedAt: at.AddDate(0, 1, 0)}, nil},
+			{"Expired", strain.Strain, &can.Batch{PrescriptionID: p.ID, Grams: 1, PurchasedAt: at.AddDate(1, 0, 0)}, ErrPrescriptionNotValid},
+			{"NotPrescribed", "Other Strain", &can.Batch{PrescriptionID: p.ID, Grams: 1, PurchasedAt: at}, ErrStrainNotPrescribed},
+			{"UnknownPrescription", strain.Strain, &can.Batch{PrescriptionID: uuid.New(), Grams: 1, PurchasedAt: at}, storage.ErrPrescriptionNotFound},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				err := svc.CheckPurchase(tt.product, tt.batch, strains)
+				if tt.wantErr == nil {
+					assert.NoError(t, err)
+				} else {
+					assert.ErrorIs(t, err, tt.wantErr)
+				}
+			})
+		}
+	})
+
+	t.Run("Warnings", func(t *testing.T) {
+		svc, p := testPrescriptionService(t)
+		strain := testStrain()
+		strains := []*can.Strain{strain}
+
+		assert.Empty(t, svc.Warnings(strains, time.Date(2024, time.January, 20, 0, 0, 0, 0, time.UTC)))
+
+		strain.Batches = []*can.Batch{
+			{PrescriptionID: p.ID, Grams: 25, PurchasedAt: time.Date(2024, time.March, 30, 0, 0, 0, 0, time.UTC)},
+		}
+		warnings := svc.Warnings(strains, time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC))
+
+		require.Len(t, warnings, 2)
+		assert.Equal(t, ExpiresSoon, warnings[0].Kind)
+		assert.Equal(t, 8, warnings[0].DaysLeft)
+		assert.Equal(t, QuotaNearlyUsed, warnings[1].Kind)
+		assert.Equal(t, 5.0, warnings[1].Remaining)
+
+		assert.Empty(t, svc.Warnings(strains, time.Date(2024, time.May, 1, 0, 0, 0, 0, time.UTC)))
+	})
+
+	t.Run("WithoutQuota", func(t *testing.T) {
+		t.Setenv("STORAGE_MODE", storage.StoreInMemory)
+		p := &can.Prescription{
+			ID:         uuid.New(),
+			IssuedAt:   time.Date(2024, time.January, 10, 0, 0, 0, 0, time.UTC),
+			ValidUntil: time.Date(2024, time.April, 9, 0, 0, 0, 0, time.UTC),
+			PeriodDays: 30,
+			Strains:    []string{testStrain().Strain},
+		}
+		svc := NewPrescriptionService(storage.NewPrescriptionStore())
+		require.NoError(t, svc.AddPrescription(p))
+		strain := testStrain()
+		strain.Batches = []*can.Batch{
+			{PrescriptionID: p.ID, Grams: 40, PurchasedAt: time.Date(2024, time.January, 15, 0, 0, 0, 0, time.UTC)},
+		}
+		strains := []*can.Strain{strain}
+		at := time.Date(2024, time.January, 20, 0, 0, 0, 0, time.UTC)
+
+		assert.False(t, p.HasQuota())
+		assert.NoError(t, svc.CheckPurchase(strain.Strain, &can.Batch{PrescriptionID: p.ID, Grams: 50, PurchasedAt: at}, strains))
+		assert.Empty(t, svc.Warnings(strains, at))
+	})
+}
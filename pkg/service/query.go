@@ -0,0 +1,254 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	can "github.com/TheDonDope/wits-tui/pkg/cannabis"
+	"github.com/TheDonDope/wits-tui/pkg/i18n"
+)
+
+// ErrInvalidQuery is returned when a strain query cannot be parsed.
+var ErrInvalidQuery = errors.New("Invalid strain query")
+
+// queryTermPattern matches a single qualified query term, e.g. `thc>20`.
+var queryTermPattern = regexp.MustCompile(`^([a-z]+)(>=|<=|:|=|>|<)(.+)$`)
+
+// queryField is the field of a strain a query term is matched against.
+type queryField string
+
+const (
+	textField         queryField = ""
+	productField      queryField = "product"
+	cultivarField     queryField = "cultivar"
+	manufacturerField queryField = "manu"
+	countryField      queryField = "country"
+	geneticField      queryField = "genetic"
+	terpeneField      queryField = "terp"
+	thcField          queryField = "thc"
+	cbdField          queryField = "cbd"
+	amountField       queryField = "amount"
+	ratingField       queryField = "rating"
+	tasteField        queryField = "taste"
+	effectField       queryField = "effect"
+	valueField        queryField = "value"
+	tagField          queryField = "tag"
+	treatmentField    queryField = "treat"
+)
+
+// queryFieldAliases maps all accepted field names to their field.
+var queryFieldAliases = map[string]queryField{
+	"product":      productField,
+	"name":         productField,
+	"cultivar":     cultivarField,
+	"manu":         manufacturerField,
+	"manufacturer": manufacturerField,
+	"country":      countryField,
+	"genetic":      geneticField,
+	"terp":         terpeneField,
+	"terpene":      terpeneField,
+	"thc":          thcField,
+	"cbd":          cbdField,
+	"amount":       amountField,
+	"rating":       ratingField,
+	"taste":        tasteField,
+	"effect":       effectField,
+	"value":        valueField,
+	"tag":          tagField,
+	"treat":        treatmentField,
+	"treatment":    treatmentField,
+}
+
+// queryTerm is a single condition of a StrainQuery.
+type queryTerm struct {
+	field  queryField
+	op     string
+	text   string
+	number float64
+}
+
+// StrainQuery is a parsed query to filter strains, consisting of terms which
+// all have to match.
+type StrainQuery struct {
+	terms []queryTerm
+}
+
+// ParseStrainQuery parses the given query. A query consists of whitespace
+// separated terms, which are either free text matched against all text fields
+// or qualified by a field, e.g. `thc>20 genetic:indica terp:limonene
+// manu:aurora`. Numeric fields (thc, cbd, amount and the average ratings
+// rating, taste, effect, value) support the operators `:`,
+// `=`, `>`, `>=`, `<` and `<=`, text fields only `:` and `=`. Values containing
+// whitespace can be enclosed in double quotes.
+func ParseStrainQuery(q string) (StrainQuery, error) {
+	var query StrainQuery
+	for _, token := range tokenizeQuery(q) {
+		m := queryTermPattern.FindStringSubmatch(strings.ToLower(token))
+		if m == nil {
+			query.terms = append(query.terms, queryTerm{field: textField, op: ":", text: strings.ToLower(token)})
+			continue
+		}
+		field, ok := queryFieldAliases[m[1]]
+		if !ok {
+			return StrainQuery{}, fmt.Errorf("%w: unknown field %q", ErrInvalidQuery, m[1])
+		}
+		term := queryTerm{field: field, op: m[2], text: m[3]}
+		switch field {
+		case thcField, cbdField, amountField, ratingField, tasteField, effectField, valueField:
+			n, err := strconv.ParseFloat(strings.Replace(m[3], ",", ".", 1), 64)
+			if err != nil {
+				return StrainQuery{}, fmt.Errorf("%w: %q is not a number", ErrInvalidQuery, m[3])
+			}
+			term.number = n
+		default:
+			if term.op != ":" && term.op != "=" {
+				return StrainQuery{}, fmt.Errorf("%w: operator %q is not supported for field %q", ErrInvalidQuery, term.op, m[1])
+			}
+		}
+		query.terms = append(query.terms, term)
+	}
+	return query, nil
+}
+
+// tokenizeQuery splits the given query at whitespace, keeping double quoted
+// values together.
+func tokenizeQuery(q string) []string {
+	var tokens []string
+	var b strings.Builder
+	quoted := false
+	for _, r := range q {
+		switch {
+		case r == '"':
+			quoted = !quoted
+		case !quoted && (r == ' ' || r == '\t'):
+			if b.Len() > 0 {
+				tokens = append(tokens, b.String())
+				b.Reset()
+			}
+		default:
+			b.WriteRune(r)
+		}
+	}
+	if b.Len() > 0 {
+		tokens = append(tokens, b.String())
+	}
+	return tokens
+}
+
+// Empty reports whether the query has no terms and thus matches all strains.
+func (q StrainQuery) Empty() bool {
+	return len(q.terms) == 0
+}
+
+// Matches reports whether the given strain matches all terms of the query.
+func (q StrainQuery) Matches(s *can.Strain) bool {
+	for _, t := range q.terms {
+		if !t.matches(s) {
+			return false
+		}
+	}
+	return true
+}
+
+// matches reports whether the given strain matches the term.
+func (t queryTerm) matches(s *can.Strain) bool {
+	switch t.field {
+	case productField:
+		return containsFold(s.Strain, t.text)
+	case cultivarField:
+		return containsFold(s.Cultivar, t.text)
+	case manufacturerField:
+		return containsFold(s.Manufacturer, t.text)
+	case countryField:
+		return containsFold(countryText(s.Country), t.text)
+	case treatmentField:
+		return treatmentMatches(s.Treatment.Type, t.text)
+	case geneticField:
+		return strings.HasPrefix(strings.ToLower(can.Genetics[s.Genetic]), t.text)
+	case terpeneField:
+		for _, tp := range s.Terpenes {
+			if containsFold(tp.Name(), t.text) {
+				return true
+			}
+		}
+		return false
+	case thcField:
+		return t.compare(s.THC)
+	case cbdField:
+		return t.compare(s.CBD)
+	case amountField:
+		return t.compare(s.Amount)
+	case ratingField:
+		return t.compare(s.AverageRatings().Overall)
+	case tasteField:
+		return t.compare(s.AverageRatings().Taste)
+	case effectField:
+		return t.compare(s.AverageRatings().Effect)
+	case valueField:
+		return t.compare(s.AverageRatings().Value)
+	case tagField:
+		for _, tag := range s.Tags() {
+			if containsFold(tag, t.text) {
+				return true
+			}
+		}
+		return false
+	}
+	return containsFold(StrainSearchText(s), t.text)
+}
+
+// compare compares the given value against the number of the term.
+func (t queryTerm) compare(v float64) bool {
+	switch t.op {
+	case ">":
+		return v > t.number
+	case ">=":
+		return v >= t.number
+	case "<":
+		return v < t.number
+	case "<=":
+		return v <= t.number
+	}
+	return v == t.number
+}
+
+// containsFold reports whether substr is within s, ignoring the case.
+func containsFold(s, substr string) bool {
+	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+}
+
+// countryText returns the given country code together with the English and
+// the translated name of the country, so that queries match any of them.
+func countryText(code string) string {
+	if name, ok := can.Countries[code]; ok {
+		return code + " " + name + " " + i18n.Term(name)
+	}
+	return code
+}
+
+// treatmentMatches reports whether the given treatment type matches the given
+// text: its identifier (e.g. `gamma`) or a prefix of it, or `irradiated` for
+// any irradiation.
+func treatmentMatches(tt can.TreatmentType, text string) bool {
+	if text == can.Irradiated.ID() {
+		return tt.Irradiated()
+	}
+	return strings.HasPrefix(tt.ID(), text)
+}
+
+// StrainSearchText returns all text fields of the given strain, which are
+// searched by free text query terms.
+func StrainSearchText(s *can.Strain) string {
+	fields := []string{s.Strain, s.Cultivar, s.Manufacturer, countryText(s.Country), can.Genetics[s.Genetic]}
+	for _, t := range s.Terpenes {
+		fields = append(fields, t.Name())
+	}
+	if s.Treatment.Type != can.UnknownTreatment && s.Treatment.Type != can.Untreated {
+		fields = append(fields, can.Treatments[s.Treatment.Type])
+	}
+	fields = append(fields, s.Tags()...)
+	return strings.Join(fields, " ")
+}
@@ -0,0 +1,130 @@
+package service
+
+import (
+	"testing"
+
+	can "github.com/TheDonDope/wits-tui/pkg/cannabis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseStrainQuery(t *testing.T) {
+	t.Run("Invalid", func(t *testing.T) {
+		tests := []struct {
+			name  string
+			query string
+		}{
+			{"UnknownField", "color:green"},
+			{"NotANumber", "thc>high"},
+			{"UnsupportedOperator", "manu>aurora"},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				_, err := ParseStrainQuery(tt.query)
+				assert.ErrorIs(t, err, ErrInvalidQuery)
+			})
+		}
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		q, err := ParseStrainQuery("   ")
+		require.NoError(t, err)
+		assert.True(t, q.Empty())
+		assert.True(t, q.Matches(testStrain()))
+	})
+}
+
+func TestStrainQuery_Matches(t *testing.T) {
+	strain := testStrain()
+	strain.Genetic = can.Indica
+	strain.Manufacturer = "Aurora Cannabis"
+	strain.Country = "Canada"
+	strain.Terpenes = []*can.StrainTerpene{{Type: can.Limonene}, {Type: can.BetaMyrcene}}
+	strain.Ratings = []*can.Rating{{Overall: 4, Taste: 5, Tags: []string{"smooth"}}, {Overall: 5}}
+
+	tests := []struct {
+		name     string
+		query    string
+		expected bool
+	}{
+		{"FreeText", "test", true},
+		{"FreeTextTerpene", "myrcene", true},
+		{"FreeTextMismatch", "sativa", false},
+		{"THCGreater", "thc>19", true},
+		{"THCGreaterMismatch", "thc>20", false},
+		{"THCGreaterEqual", "thc>=20", true},
+		{"CBDLess", "cbd<1", true},
+		{"AmountEqual", "amount:3,5", true},
+		{"Genetic", "genetic:indica", true},
+		{"GeneticPrefix", "genetic:ind", true},
+		{"GeneticMismatch", "genetic:sativa", false},
+		{"Terpene", "terp:limonene", true},
+		{"TerpeneMismatch", "terp:linalool", false},
+		{"Manufacturer", "manu:aurora", true},
+		{"ManufacturerQuoted", `manu:"aurora cannabis"`, true},
+		{"Country", "country:can", true},
+		{"CountryMismatch", "country:germany", false},
+		{"Product", "name:strain", true},
+		{"Combined", "thc>20 genetic:indica terp:limonene manu:aurora", false},
+		{"CombinedMatch", "thc>15 genetic:indica terp:limonene manu:aurora", true},
+		{"CaseInsensitive", "MANU:AURORA", true},
+		{"Rating", "rating>=4.5", true},
+		{"RatingMismatch", "rating>4.5", false},
+		{"Taste", "taste:5", true},
+		{"ValueUnrated", "value>0", false},
+		{"Tag", "tag:smooth", true},
+		{"TagMismatch", "tag:harsh", false},
+		{"FreeTextTag", "smooth", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, err := ParseStrainQuery(tt.query)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, q.Matches(strain))
+		})
+	}
+}
+
+func TestStrainQuery_MatchesCountryCode(t *testing.T) {
+	strain := testStrain()
+	strain.Country = "CA"
+
+	for _, query := range []string{"country:ca", "country:canada", "canada"} {
+		q, err := ParseStrainQuery(query)
+		require.NoError(t, err)
+		assert.True(t, q.Matches(strain), query)
+	}
+	q, err := ParseStrainQuery("country:germany")
+	require.NoError(t, err)
+	assert.False(t, q.Matches(strain))
+}
+
+func TestStrainQuery_MatchesTreatment(t *testing.T) {
+	gamma, untreated, plasma, unknown := testStrain(), testStrain(), testStrain(), testStrain()
+	gamma.Treatment = can.Treatment{Type: can.GammaIrradiated}
+	untreated.Treatment = can.Treatment{Type: can.Untreated}
+	plasma.Treatment = can.Treatment{Type: can.ColdPlasma}
+	unknown.Treatment = can.Treatment{Type: can.UnknownTreatment}
+
+	tests := []struct {
+		query    string
+		expected []bool // gamma, untreated, plasma, unknown
+	}{
+		{"treatment:irradiated", []bool{true, false, false, false}},
+		{"treatment:none", []bool{false, true, false, false}},
+		{"treatment:unknown", []bool{false, false, false, true}},
+		{"treat:gamma", []bool{true, false, false, false}},
+		{"treatment:cold", []bool{false, false, true, false}},
+		{"treatment:e", []bool{false, false, false, false}},
+		{"treatment:untreated", []bool{false, false, false, false}},
+		{"gamma", []bool{true, false, false, false}},
+	}
+
+	for _, tt := range tests {
+		q, err := ParseStrainQuery(tt.query)
+		require.NoError(t, err)
+		assert.Equal(t, tt.expected, []bool{q.Matches(gamma), q.Matches(untreated), q.Matches(plasma), q.Matches(unknown)}, tt.query)
+	}
+}
@@ -0,0 +1,64 @@
+package service
+
+import (
+	"log"
+
+	can "github.com/TheDonDope/wits-tui/pkg/cannabis"
+	"github.com/TheDonDope/wits-tui/pkg/storage"
+	"github.com/google/uuid"
+)
+
+// RecipeService provides operations on edibles recipes.
+type RecipeService interface {
+	AddRecipe(r *can.Recipe) error
+	GetRecipes() []*can.Recipe
+	RecipesOfStrains() map[uuid.UUID][]*can.Recipe
+}
+
+// RecipeServiceType provides operations on edibles recipes, accessing a store.
+type RecipeServiceType struct {
+	store storage.RecipeStore
+}
+
+// NewRecipeService creates a new service layer for edibles recipes.
+func NewRecipeService(s storage.RecipeStore) *RecipeServiceType {
+	log.Println("✅ 🤝  (pkg/service/recipe.go) NewRecipeService(s storage.RecipeStore)")
+	return &RecipeServiceType{store: s}
+}
+
+// AddRecipe adds a recipe to the store.
+func (svc *RecipeServiceType) AddRecipe(r *can.Recipe) error {
+	log.Printf("💬 🤝  (pkg/service/recipe.go) AddRecipe(r *can.Recipe: %v)\n", r.ID)
+	return svc.store.AddRecipe(r)
+}
+
+// GetRecipes retrieves all recipes from the store.
+func (svc *RecipeServiceType) GetRecipes() []*can.Recipe {
+	log.Println("💬 🤝  (pkg/service/recipe.go) GetRecipes()")
+	return svc.store.GetRecipes()
+}
+
+// RecipesOfStrains returns the recipes keyed by the IDs of the strains they
+// use. A recipe using several strains is listed for each of them.
+func (svc *RecipeServiceType) RecipesOfStrains() map[uuid.UUID][]*can.Recipe {
+	log.Println("💬 🤝  (pkg/service/recipe.go) RecipesOfStrains()")
+	recipes := map[uuid.UUID][]*can.Recipe{}
+	for _, r := range svc.store.GetRecipes() {
+		for _, i := range r.Ingredients {
+			if !containsRecipe(recipes[i.StrainID], r) {
+				recipes[i.StrainID] = append(recipes[i.StrainID], r)
+			}
+		}
+	}
+	return recipes
+}
+
+// containsRecipe reports whether the given recipes contain the given recipe.
+func containsRecipe(recipes []*can.Recipe, r *can.Recipe) bool {
+	for _, other := range recipes {
+		if other.ID == r.ID {
+			return true
+		}
+	}
+	return false
+}
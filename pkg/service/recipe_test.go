@@ -0,0 +1,29 @@
+package service
+
+import (
+	"testing"
+
+	can "github.com/TheDonDope/wits-tui/pkg/cannabis"
+	"github.com/TheDonDope/wits-tui/pkg/storage"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecipeService_RecipesOfStrains(t *testing.T) {
+	t.Setenv("STORAGE_MODE", storage.StoreInMemory)
+	svc := NewRecipeService(storage.NewRecipeStore())
+	a, b := testStrain(), testStrain()
+	a.ID, b.ID = uuid.New(), uuid.New()
+	brownies := &can.Recipe{ID: uuid.New(), Name: "Brownies", Ingredients: []*can.Ingredient{
+		can.NewIngredient(a, 1), can.NewIngredient(b, 1), can.NewIngredient(a, 0.5)}}
+	cookies := &can.Recipe{ID: uuid.New(), Name: "Cookies", Ingredients: []*can.Ingredient{can.NewIngredient(a, 2)}}
+	require.NoError(t, svc.AddRecipe(brownies))
+	require.NoError(t, svc.AddRecipe(cookies))
+
+	recipes := svc.RecipesOfStrains()
+
+	assert.Equal(t, []*can.Recipe{brownies, cookies}, recipes[a.ID])
+	assert.Equal(t, []*can.Recipe{brownies}, recipes[b.ID])
+	assert.Len(t, svc.GetRecipes(), 2)
+}
@@ -0,0 +1,135 @@
+package service
+
+import (
+	"maps"
+	"slices"
+	"sort"
+
+	can "github.com/TheDonDope/wits-tui/pkg/cannabis"
+)
+
+// ReferenceEntry is a cannabinoid or terpene of the reference catalog.
+type ReferenceEntry struct {
+	ID           string   // The stable identifier
+	Name         string   // The full name
+	ShortName    string   // The short name of a cannabinoid
+	Effects      []string // The subjective effects
+	Flavors      []string // The subjective flavors of a terpene
+	Notes        string   // Additional notes on a cannabinoid
+	BoilingPoint int      // The boiling point in degrees Celsius
+	Terpene      bool     // Whether the entry is a terpene, else a cannabinoid
+}
+
+// ReferenceFilter selects catalog entries by effect and flavor. Empty fields
+// match every entry.
+type ReferenceFilter struct {
+	Effect string // The effect an entry must have
+	Flavor string // The flavor an entry must have
+}
+
+// Active reports whether the filter excludes any entry.
+func (f ReferenceFilter) Active() bool {
+	return f.Effect != "" || f.Flavor != ""
+}
+
+// Matches reports whether the given entry has the effect and flavor of the
+// filter.
+func (f ReferenceFilter) Matches(e ReferenceEntry) bool {
+	return (f.Effect == "" || slices.Contains(e.Effects, f.Effect)) &&
+		(f.Flavor == "" || slices.Contains(e.Flavors, f.Flavor))
+}
+
+// ReferenceEntries returns the catalog entries matching the given filter. The
+// cannabinoids are followed by the terpenes, each in the order of their
+// types, or all of them ordered by their boiling point, lowest first.
+func ReferenceEntries(f ReferenceFilter, byBoilingPoint bool) []ReferenceEntry {
+	var entries []ReferenceEntry
+	for _, c := range slices.Sorted(maps.Keys(can.Cannabinoids)) {
+		cb := can.Cannabinoids[c]
+		entries = append(entries, ReferenceEntry{
+			ID:           cb.ID,
+			Name:         cb.Name,
+			ShortName:    cb.ShortName,
+			Effects:      cb.Effects,
+			Notes:        cb.Notes,
+			BoilingPoint: cb.BoilingPoint,
+		})
+	}
+	for _, t := range slices.Sorted(maps.Keys(can.Terpenes)) {
+		tp := can.Terpenes[t]
+		entries = append(entries, ReferenceEntry{
+			ID:           tp.ID,
+			Name:         tp.Name,
+			Effects:      tp.Effects,
+			Flavors:      tp.Flavors,
+			BoilingPoint: tp.BoilingPoint,
+			Terpene:      true,
+		})
+	}
+	matching := []ReferenceEntry{}
+	for _, e := range entries {
+		if f.Matches(e) {
+			matching = append(matching, e)
+		}
+	}
+	if byBoilingPoint {
+		sort.SliceStable(matching, func(i, j int) bool {
+			return matching[i].BoilingPoint < matching[j].BoilingPoint
+		})
+	}
+	return matching
+}
+
+// ReferenceEffects returns the distinct effects of all catalog entries,
+// sorted alphabetically.
+func ReferenceEffects() []string {
+	seen := map[string]bool{}
+	for _, e := range ReferenceEntries(ReferenceFilter{}, false) {
+		for _, effect := range e.Effects {
+			seen[effect] = true
+		}
+	}
+	return slices.Sorted(maps.Keys(seen))
+}
+
+// ReferenceFlavors returns the distinct flavors of all catalog entries,
+// sorted alphabetically.
+func ReferenceFlavors() []string {
+	seen := map[string]bool{}
+	for _, e := range ReferenceEntries(ReferenceFilter{}, false) {
+		for _, flavor := range e.Flavors {
+			seen[flavor] = true
+		}
+	}
+	return slices.Sorted(maps.Keys(seen))
+}
+
+// StrainsContaining returns the given strains containing the given entry,
+// either among their terpenes or in their cannabinoid profile.
+func StrainsContaining(strains []*can.Strain, e ReferenceEntry) []*can.Strain {
+	var containing []*can.Strain
+	for _, s := range strains {
+		if strainContains(s, e) {
+			containing = append(containing, s)
+		}
+	}
+	return containing
+}
+
+// strainContains reports whether the given strain contains the given entry.
+func strainContains(s *can.Strain, e ReferenceEntry) bool {
+	if e.Terpene {
+		for _, t := range s.Terpenes {
+			if t.ID() == e.ID {
+				return true
+			}
+		}
+		return false
+	}
+	for c := range s.Cannabinoids {
+		if c.ID() == e.ID {
+			return true
+		}
+	}
+	return false
+}
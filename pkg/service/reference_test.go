@@ -0,0 +1,56 @@
+package service
+
+import (
+	"testing"
+
+	can "github.com/TheDonDope/wits-tui/pkg/cannabis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReferenceEntries(t *testing.T) {
+	all := ReferenceEntries(ReferenceFilter{}, false)
+	require.Len(t, all, len(can.Cannabinoids)+len(can.Terpenes))
+	assert.Equal(t, "thca", all[0].ID)
+	assert.False(t, all[0].Terpene)
+	assert.Equal(t, "beta-caryophyllene", all[len(can.Cannabinoids)].ID)
+
+	sedative := ReferenceEntries(ReferenceFilter{Effect: "sedative"}, true)
+	var ids []string
+	for _, e := range sedative {
+		assert.Contains(t, e.Effects, "sedative")
+		ids = append(ids, e.ID)
+	}
+	// Cannabinoids and terpenes are ordered by their boiling points together
+	assert.Equal(t, []string{"terpinolene", "cbe", "linalool", "alpha-terpineol", "pulegone"}, ids)
+
+	citrus := ReferenceEntries(ReferenceFilter{Effect: "anti-depressant", Flavor: "citrus"}, false)
+	require.Len(t, citrus, 2)
+	assert.Equal(t, "limonene", citrus[0].ID)
+	assert.Equal(t, "linalool", citrus[1].ID)
+
+	assert.Empty(t, ReferenceEntries(ReferenceFilter{Flavor: "chocolate"}, false))
+}
+
+func TestReferenceEffectsAndFlavors(t *testing.T) {
+	effects := ReferenceEffects()
+	assert.Contains(t, effects, "sedative")
+	assert.IsIncreasing(t, effects)
+
+	flavors := ReferenceFlavors()
+	assert.Contains(t, flavors, "citrus")
+	assert.IsIncreasing(t, flavors)
+}
+
+func TestStrainsContaining(t *testing.T) {
+	kush := &can.Strain{Strain: "Pink Kush", Terpenes: []*can.StrainTerpene{{Type: can.BetaMyrcene}},
+		Cannabinoids: map[can.CannabinoidType]float64{can.CBG: 0.8}}
+	haze := &can.Strain{Strain: "Ghost Train Haze", Terpenes: []*can.StrainTerpene{{Type: can.Limonene}}}
+	strains := []*can.Strain{kush, haze}
+
+	myrcene := ReferenceEntry{ID: "beta-myrcene", Terpene: true}
+	assert.Equal(t, []*can.Strain{kush}, StrainsContaining(strains, myrcene))
+	cbg := ReferenceEntry{ID: "cbg"}
+	assert.Equal(t, []*can.Strain{kush}, StrainsContaining(strains, cbg))
+	assert.Empty(t, StrainsContaining(strains, ReferenceEntry{ID: "borneol", Terpene: true}))
+}
@@ -0,0 +1,184 @@
+package service
+
+import (
+	"log"
+	"time"
+
+	can "github.com/TheDonDope/wits-tui/pkg/cannabis"
+	"github.com/TheDonDope/wits-tui/pkg/storage"
+	"github.com/google/uuid"
+)
+
+// ReminderConditions are the conditions the refill, reorder and check-in
+// reminders are evaluated against.
+type ReminderConditions struct {
+	Overall       StockForecast         // The forecast of the overall stock
+	PerStrain     []StockForecast       // The forecasts per strain
+	Prescriptions []*can.Prescription   // All prescriptions
+	Warnings      []PrescriptionWarning // The warnings about the valid prescriptions
+	Break         *can.ToleranceBreak   // The current tolerance break, if any
+}
+
+// GatherReminderConditions gathers the reminder conditions at the given time
+// from the given strains, sessions, prescriptions and tolerance breaks.
+func GatherReminderConditions(strains []*can.Strain, sessions []*can.Session, prescriptions PrescriptionService, breaks BreakService, at time.Time, th StockThresholds) ReminderConditions {
+	c := ReminderConditions{
+		Prescriptions: prescriptions.GetPrescriptions(),
+		Warnings:      prescriptions.Warnings(strains, at),
+		Break:         breaks.CurrentBreak(at),
+	}
+	c.Overall, c.PerStrain = ForecastStock(strains, sessions, at, th)
+	return c
+}
+
+// DueReminder is a reminder which is due, with the reason it is due for.
+type DueReminder struct {
+	Reminder     *can.Reminder        // The due reminder
+	At           time.Time            // The scheduled time it is due since
+	Forecast     *StockForecast       // The forecast running out, for reorder reminders
+	Prescription *PrescriptionWarning // The prescription warning, for refill reminders
+	Break        *can.ToleranceBreak  // The tolerance break, for check-in reminders
+}
+
+// ReminderService provides operations on reminders.
+type ReminderService interface {
+	AddReminder(r *can.Reminder) error
+	GetReminders() []*can.Reminder
+	RemoveReminder(id uuid.UUID) error
+	Due(c ReminderConditions, at time.Time) []DueReminder
+	MarkNotified(due []DueReminder, at time.Time) error
+}
+
+// ReminderServiceType provides operations on reminders, accessing a store.
+type ReminderServiceType struct {
+	store storage.ReminderStore
+}
+
+// NewReminderService creates a new service layer for reminders.
+func NewReminderService(s storage.ReminderStore) *ReminderServiceType {
+	log.Println("✅ 🤝  (pkg/service/reminder.go) NewReminderService(s storage.ReminderStore)")
+	return &ReminderServiceType{store: s}
+}
+
+// AddReminder adds a reminder to the store.
+func (svc *ReminderServiceType) AddReminder(r *can.Reminder) error {
+	log.Printf("💬 🤝  (pkg/service/reminder.go) AddReminder(r *can.Reminder: %v)\n", r.ID)
+	return svc.store.AddReminder(r)
+}
+
+// GetReminders retrieves all reminders from the store.
+func (svc *ReminderServiceType) GetReminders() []*can.Reminder {
+	log.Println("💬 🤝  (pkg/service/reminder.go) GetReminders()")
+	return svc.store.GetReminders()
+}
+
+// RemoveReminder removes the reminder with the given ID from the store.
+func (svc *ReminderServiceType) RemoveReminder(id uuid.UUID) error {
+	log.Printf("💬 🤝  (pkg/service/reminder.go) RemoveReminder(id uuid.UUID: %v)\n", id)
+	return svc.store.RemoveReminder(id)
+}
+
+// Due returns the reminders of the store which are due at the given time,
+// evaluated against the given conditions.
+func (svc *ReminderServiceType) Due(c ReminderConditions, at time.Time) []DueReminder {
+	log.Println("💬 🤝  (pkg/service/reminder.go) Due()")
+	return DueReminders(svc.store.GetReminders(), c, at)
+}
+
+// MarkNotified records that the given due reminders were notified at the given
+// time, so that they are not due again before their next scheduled time.
+func (svc *ReminderServiceType) MarkNotified(due []DueReminder, at time.Time) error {
+	log.Printf("💬 🤝  (pkg/service/reminder.go) MarkNotified(len(due): %v)\n", len(due))
+	for _, d := range due {
+		d.Reminder.NotifiedAt = at
+		if err := svc.store.UpdateReminder(d.Reminder); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DueReminders returns the given reminders whose scheduled time has passed at
+// the given time since their last notification. Dose reminders are always due
+// then, while the other kinds are only due if their condition holds:
+//   - refill: a valid prescription expires within the lead days or its quota
+//     is nearly used up
+//   - reorder: the stock of the strain, or the overall stock if none is given,
+//     is running low or runs out within the lead days
+//   - check-in: the current tolerance break is active and not checked in today
+func DueReminders(reminders []*can.Reminder, c ReminderConditions, at time.Time) []DueReminder {
+	var due []DueReminder
+	for _, r := range reminders {
+		if !r.Pending(at) {
+			continue
+		}
+		d := DueReminder{Reminder: r}
+		d.At, _ = r.LastOccurrence(at)
+		switch r.Kind {
+		case can.DoseReminder:
+		case can.RefillReminder:
+			d.Prescription = refillWarning(r, c, at)
+			if d.Prescription == nil {
+				continue
+			}
+		case can.ReorderReminder:
+			d.Forecast = reorderForecast(r, c)
+			if d.Forecast == nil {
+				continue
+			}
+		case can.CheckInReminder:
+			if c.Break == nil || !c.Break.Active(at) || c.Break.CheckInOn(at) != nil {
+				continue
+			}
+			d.Break = c.Break
+		default:
+			continue
+		}
+		due = append(due, d)
+	}
+	return due
+}
+
+// refillWarning returns the warning about a prescription the given refill
+// reminder is due for, or nil if there is none. A prescription is due if its
+// quota is nearly used up, or from the lead days before its expiry on, which
+// default to ExpiryWarningDays.
+func refillWarning(r *can.Reminder, c ReminderConditions, at time.Time) *PrescriptionWarning {
+	for _, w := range c.Warnings {
+		if w.Kind == QuotaNearlyUsed {
+			return &w
+		}
+	}
+	lead := r.LeadDays
+	if lead <= 0 {
+		lead = ExpiryWarningDays
+	}
+	for _, p := range c.Prescriptions {
+		if days := daysUntil(at, p.ValidUntil); p.Valid(at) && days <= lead {
+			return &PrescriptionWarning{Prescription: p, Kind: ExpiresSoon, DaysLeft: days}
+		}
+	}
+	return nil
+}
+
+// reorderForecast returns the forecast the given reorder reminder is due for,
+// or nil if the stock lasts longer than its lead days and is not running low.
+func reorderForecast(r *can.Reminder, c ReminderConditions) *StockForecast {
+	f := c.Overall
+	if r.Strain != "" {
+		found := false
+		for _, pf := range c.PerStrain {
+			if pf.Product == r.Strain {
+				f, found = pf, true
+				break
+			}
+		}
+		if !found {
+			return nil
+		}
+	}
+	if f.Low || (f.Predicted() && f.DaysLeft <= float64(r.LeadDays)) {
+		return &f
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+package service
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	can "github.com/TheDonDope/wits-tui/pkg/cannabis"
+	"github.com/TheDonDope/wits-tui/pkg/storage"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testReminder returns a reminder of the given kind scheduled at 08:00 every
+// day, created on 2024-03-01.
+func testReminder(kind can.ReminderKind) *can.Reminder {
+	return &can.Reminder{
+		ID:        uuid.New(),
+		Kind:      kind,
+		Times:     []string{"08:00"},
+		CreatedAt: time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestDueReminders(t *testing.T) {
+	at := time.Date(2024, time.March, 13, 12, 0, 0, 0, time.UTC)
+
+	t.Run("Dose", func(t *testing.T) {
+		r := testReminder(can.DoseReminder)
+		due := DueReminders([]*can.Reminder{r}, ReminderConditions{}, at)
+
+		require.Len(t, due, 1)
+		assert.Equal(t, time.Date(2024, time.March, 13, 8, 0, 0, 0, time.UTC), due[0].At)
+
+		r.NotifiedAt = at
+		assert.Empty(t, DueReminders([]*can.Reminder{r}, ReminderConditions{}, at.Add(time.Hour)))
+	})
+
+	t.Run("Refill", func(t *testing.T) {
+		r := testReminder(can.RefillReminder)
+		p := &can.Prescription{Doctor: "Dr. Test", IssuedAt: at.AddDate(0, -2, 0), ValidUntil: at.AddDate(0, 0, 20)}
+
+		assert.Empty(t, DueReminders([]*can.Reminder{r}, ReminderConditions{Prescriptions: []*can.Prescription{p}}, at),
+			"expires after the default lead days")
+
+		r.LeadDays = 21
+		due := DueReminders([]*can.Reminder{r}, ReminderConditions{Prescriptions: []*can.Prescription{p}}, at)
+		require.Len(t, due, 1)
+		assert.Equal(t, 20, due[0].Prescription.DaysLeft)
+
+		r.LeadDays = 0
+		quota := PrescriptionWarning{Prescription: p, Kind: QuotaNearlyUsed, Remaining: 2}
+		due = DueReminders([]*can.Reminder{r}, ReminderConditions{Warnings: []PrescriptionWarning{quota}}, at)
+		require.Len(t, due, 1)
+		assert.Equal(t, QuotaNearlyUsed, due[0].Prescription.Kind)
+	})
+
+	t.Run("Reorder", func(t *testing.T) {
+		r := testReminder(can.ReorderReminder)
+		r.LeadDays = 10
+		c := ReminderConditions{
+			Overall:   StockForecast{Amount: 20, DailyUsage: 1, DaysLeft: 20},
+			PerStrain: []StockForecast{{Product: "Pink Kush", Amount: 5, DailyUsage: 0.5, DaysLeft: 10}},
+		}
+
+		assert.Empty(t, DueReminders([]*can.Reminder{r}, c, at), "the overall stock lasts")
+
+		r.Strain = "Pink Kush"
+		due := DueReminders([]*can.Reminder{r}, c, at)
+		require.Len(t, due, 1)
+		assert.Equal(t, "Pink Kush", due[0].Forecast.Product)
+
+		r.Strain = "Wedding Cake"
+		assert.Empty(t, DueReminders([]*can.Reminder{r}, c, at), "not consumed recently")
+
+		r.Strain = ""
+		c.Overall = StockForecast{Amount: 1, DaysLeft: math.Inf(1), Low: true}
+		assert.Len(t, DueReminders([]*can.Reminder{r}, c, at), 1)
+	})
+
+	t.Run("CheckIn", func(t *testing.T) {
+		r := testReminder(can.CheckInReminder)
+		b := &can.ToleranceBreak{StartsAt: at.AddDate(0, 0, -3), Days: 7}
+
+		assert.Empty(t, DueReminders([]*can.Reminder{r}, ReminderConditions{}, at), "no break")
+		due := DueReminders([]*can.Reminder{r}, ReminderConditions{Break: b}, at)
+		require.Len(t, due, 1)
+		assert.Equal(t, b, due[0].Break)
+
+		b.CheckIn(&can.CheckIn{Day: at, Abstained: true})
+		assert.Empty(t, DueReminders([]*can.Reminder{r}, ReminderConditions{Break: b}, at), "checked in")
+	})
+}
+
+func TestReminderService(t *testing.T) {
+	t.Setenv("STORAGE_MODE", storage.StoreInMemory)
+	svc := NewReminderService(storage.NewReminderStore())
+	r := testReminder(can.DoseReminder)
+	require.NoError(t, svc.AddReminder(r))
+	at := time.Date(2024, time.March, 13, 12, 0, 0, 0, time.UTC)
+
+	due := svc.Due(ReminderConditions{}, at)
+	require.Len(t, due, 1)
+	require.NoError(t, svc.MarkNotified(due, at))
+
+	assert.Empty(t, svc.Due(ReminderConditions{}, at))
+	assert.Len(t, svc.Due(ReminderConditions{}, at.AddDate(0, 0, 1)), 1)
+
+	require.NoError(t, svc.RemoveReminder(r.ID))
+	assert.Empty(t, svc.GetReminders())
+}
+
+func TestGatherReminderConditions(t *testing.T) {
+	t.Setenv("STORAGE_MODE", storage.StoreInMemory)
+	at := time.Date(2024, time.March, 13, 12, 0, 0, 0, time.UTC)
+	breaks := NewBreakService(storage.NewBreakStore())
+	b := &can.ToleranceBreak{ID: uuid.New(), StartsAt: at, Days: 7}
+	require.NoError(t, breaks.PlanBreak(b))
+	strains := []*can.Strain{{Strain: "Pink Kush", Amount: 2}}
+	sessions := []*can.Session{{Strain: "Pink Kush", Grams: 3, StartedAt: at.AddDate(0, 0, -1)}}
+
+	c := GatherReminderConditions(strains, sessions, NewPrescriptionService(storage.NewPrescriptionStore()), breaks, at,
+		StockThresholds{LowGrams: 5, LowDays: 7})
+
+	assert.True(t, c.Overall.Low)
+	assert.Len(t, c.PerStrain, 1)
+	assert.Equal(t, b, c.Break)
+	assert.Empty(t, c.Prescriptions)
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"strings"
+
+	can "github.com/TheDonDope/wits-tui/pkg/cannabis"
+	"github.com/TheDonDope/wits-tui/pkg/i18n"
+	"github.com/TheDonDope/wits-tui/pkg/notify"
+)
+
+// ReminderKindText returns the translated name of the given reminder kind.
+func ReminderKindText(kind can.ReminderKind) string {
+	switch kind {
+	case can.DoseReminder:
+		return i18n.T("Dose")
+	case can.RefillReminder:
+		return i18n.T("Refill prescription")
+	case can.ReorderReminder:
+		return i18n.T("Reorder")
+	case can.CheckInReminder:
+		return i18n.T("T-break check-in")
+	}
+	return string(kind)
+}
+
+// PrescriptionWarningText returns the translated text of the given
+// prescription warning.
+func PrescriptionWarningText(w PrescriptionWarning) string {
+	if w.Kind == QuotaNearlyUsed {
+		return i18n.T("Only %s g are left of the quota prescribed by %s.",
+			i18n.FormatFloat(w.Remaining, 1), w.Prescription.Doctor)
+	}
+	return i18n.T("The prescription of %s expires in %d days.", w.Prescription.Doctor, w.DaysLeft)
+}
+
+// ReminderNotification returns the translated notification of the given due
+// reminder, telling the reason it is due for.
+func ReminderNotification(d DueReminder) notify.Notification {
+	r := d.Reminder
+	var body string
+	switch {
+	case r.Kind == can.DoseReminder:
+		body = doseText(r)
+	case d.Prescription != nil:
+		body = PrescriptionWarningText(*d.Prescription)
+	case d.Forecast != nil && d.Forecast.Predicted() && d.Forecast.Product != "":
+		body = i18n.T("%s runs out in %s days, %s g left.", d.Forecast.Product,
+			i18n.FormatFloat(d.Forecast.DaysLeft, 0), i18n.FormatFloat(d.Forecast.Amount, 1))
+	case d.Forecast != nil && d.Forecast.Predicted():
+		body = i18n.T("Your stock runs out in %s days, %s g left.",
+			i18n.FormatFloat(d.Forecast.DaysLeft, 0), i18n.FormatFloat(d.Forecast.Amount, 1))
+	case d.Forecast != nil:
+		body = i18n.T("Your stock is running low: %s g left.", i18n.FormatFloat(d.Forecast.Amount, 1))
+	case d.Break != nil:
+		body = i18n.T("Check in on day %d of your T-break.", d.Break.Day(d.At))
+	}
+	return notify.Notification{Title: ReminderKindText(r.Kind), Body: withNotes(body, r)}
+}
+
+// ReminderDescription returns the translated description of the given
+// reminder ahead of time, e.g. for a calendar. The description of the
+// conditional kinds tells when to act on them.
+func ReminderDescription(r *can.Reminder) string {
+	var description string
+	switch r.Kind {
+	case can.DoseReminder:
+		description = doseText(r)
+	case can.RefillReminder:
+		lead := r.LeadDays
+		if lead <= 0 {
+			lead = ExpiryWarningDays
+		}
+		description = i18n.T("Renew your prescription if it expires within %d days or its quota is nearly used up.", lead)
+	case can.ReorderReminder:
+		if r.Strain != "" {
+			description = i18n.T("Reorder %s if it runs low or runs out within %d days.", r.Strain, r.LeadDays)
+		} else {
+			description = i18n.T("Reorder your stock if it runs low or runs out within %d days.", r.LeadDays)
+		}
+	case can.CheckInReminder:
+		description = i18n.T("Check in if you are on a T-break.")
+	}
+	return withNotes(description, r)
+}
+
+// doseText returns the translated instruction of the given dose reminder.
+func doseText(r *can.Reminder) string {
+	switch {
+	case r.Strain != "" && r.Grams > 0:
+		return i18n.T("Take %s g of %s.", i18n.FormatFloat(r.Grams, 2), r.Strain)
+	case r.Strain != "":
+		return i18n.T("Take your dose of %s.", r.Strain)
+	}
+	return i18n.T("Take your dose.")
+}
+
+// withNotes returns the given text followed by the notes of the given reminder.
+func withNotes(text string, r *can.Reminder) string {
+	if r.Notes == "" {
+		return text
+	}
+	return strings.TrimSpace(text + " " + r.Notes)
+}
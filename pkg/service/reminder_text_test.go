@@ -0,0 +1,77 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	can "github.com/TheDonDope/wits-tui/pkg/cannabis"
+	"github.com/TheDonDope/wits-tui/pkg/i18n"
+	"github.com/TheDonDope/wits-tui/pkg/notify"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReminderNotification(t *testing.T) {
+	at := time.Date(2024, time.March, 12, 8, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		due  DueReminder
+		want notify.Notification
+	}{
+		{
+			name: "Dose",
+			due:  DueReminder{Reminder: &can.Reminder{Kind: can.DoseReminder, Strain: "Gorilla Glue", Grams: 0.15}},
+			want: notify.Notification{Title: "Dose", Body: "Take 0.15 g of Gorilla Glue."},
+		},
+		{
+			name: "DoseWithNotes",
+			due:  DueReminder{Reminder: &can.Reminder{Kind: can.DoseReminder, Notes: "After breakfast."}},
+			want: notify.Notification{Title: "Dose", Body: "Take your dose. After breakfast."},
+		},
+		{
+			name: "Refill",
+			due: DueReminder{Reminder: &can.Reminder{Kind: can.RefillReminder},
+				Prescription: &PrescriptionWarning{Prescription: &can.Prescription{Doctor: "Dr. Gruber"}, Kind: ExpiresSoon, DaysLeft: 7}},
+			want: notify.Notification{Title: "Refill prescription", Body: "The prescription of Dr. Gruber expires in 7 days."},
+		},
+		{
+			name: "Reorder",
+			due: DueReminder{Reminder: &can.Reminder{Kind: can.ReorderReminder, Strain: "Gorilla Glue"},
+				Forecast: &StockForecast{Product: "Gorilla Glue", Amount: 2.5, DaysLeft: 5, DailyUsage: 0.5}},
+			want: notify.Notification{Title: "Reorder", Body: "Gorilla Glue runs out in 5 days, 2.5 g left."},
+		},
+		{
+			name: "ReorderStock",
+			due: DueReminder{Reminder: &can.Reminder{Kind: can.ReorderReminder},
+				Forecast: &StockForecast{Amount: 2.5, DaysLeft: 5, DailyUsage: 0.5}},
+			want: notify.Notification{Title: "Reorder", Body: "Your stock runs out in 5 days, 2.5 g left."},
+		},
+		{
+			name: "CheckIn",
+			due:  DueReminder{Reminder: &can.Reminder{Kind: can.CheckInReminder}, At: at, Break: testBreak()},
+			want: notify.Notification{Title: "T-break check-in", Body: "Check in on day 3 of your T-break."},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ReminderNotification(tt.due))
+		})
+	}
+}
+
+func TestReminderDescription(t *testing.T) {
+	assert.Equal(t, "Take your dose of Gorilla Glue.", ReminderDescription(&can.Reminder{Kind: can.DoseReminder, Strain: "Gorilla Glue"}))
+	assert.Equal(t, "Reorder Gorilla Glue if it runs low or runs out within 5 days.",
+		ReminderDescription(&can.Reminder{Kind: can.ReorderReminder, Strain: "Gorilla Glue", LeadDays: 5}))
+	assert.Equal(t, "Reorder your stock if it runs low or runs out within 3 days.", ReminderDescription(&can.Reminder{Kind: can.ReorderReminder, LeadDays: 3}))
+	assert.Equal(t, "Check in if you are on a T-break. Drink water.", ReminderDescription(&can.Reminder{Kind: can.CheckInReminder, Notes: "Drink water."}))
+}
+
+func TestReminderText_Translated(t *testing.T) {
+	require.NoError(t, i18n.SetLocale(i18n.German))
+	t.Cleanup(func() { _ = i18n.SetLocale(i18n.English) })
+	r := &can.Reminder{Kind: can.DoseReminder}
+
+	assert.Equal(t, notify.Notification{Title: "Dosis", Body: "Nimm deine Dosis."}, ReminderNotification(DueReminder{Reminder: r}))
+	assert.Equal(t, "Nimm deine Dosis.", ReminderDescription(r))
+}
@@ -0,0 +1,230 @@
+package service
+
+import (
+	"sort"
+	"time"
+
+	can "github.com/TheDonDope/wits-tui/pkg/cannabis"
+)
+
+// Report summarizes the consumption within a time range, e.g. for a visit to
+// the prescribing doctor.
+type Report struct {
+	From          time.Time           // The start of the range
+	To            time.Time           // The end of the range, exclusive
+	Sessions      int                 // The number of sessions
+	Grams         float64             // The consumed amount in grams
+	THC           float64             // The consumed THC in mg
+	CBD           float64             // The consumed CBD in mg
+	Strains       []StrainConsumption // The consumption per strain, most consumed first
+	Daily         []*DailyDose        // The consumption per day with sessions
+	Symptoms      []SymptomTrend      // The course of the rated symptoms, by symptom
+	Prescriptions []PrescriptionUsage // The prescriptions valid within the range
+	SideEffects   []EffectCount       // The perceived side effects, most frequent first
+}
+
+// StrainConsumption is the consumption of a strain within a report.
+type StrainConsumption struct {
+	Strain   string  // The product name of the strain
+	Sessions int     // The number of sessions
+	Grams    float64 // The consumed amount in grams
+	THC      float64 // The consumed THC in mg
+	CBD      float64 // The consumed CBD in mg
+}
+
+// SymptomTrend is the course of the severity of a symptom within a report.
+type SymptomTrend struct {
+	Symptom  string          // The name of the symptom
+	Sessions int             // The number of sessions the symptom was rated in
+	Before   float64         // The average severity before the sessions
+	After    float64         // The average severity after the sessions
+	Weeks    []WeeklySymptom // The average severities per week with ratings
+}
+
+// WeeklySymptom is the average severity of a symptom within a week.
+type WeeklySymptom struct {
+	Week     time.Time // The start of the week (Monday)
+	Sessions int       // The number of sessions the symptom was rated in
+	Before   float64   // The average severity before the sessions
+	After    float64   // The average severity after the sessions
+}
+
+// Relief returns the average decrease of the severity of the symptom by a
+// session.
+func (t SymptomTrend) Relief() float64 {
+	return t.Before - t.After
+}
+
+// Change returns the change of the average severity before the sessions from
+// the first to the last week, negative if the symptom improved.
+func (t SymptomTrend) Change() float64 {
+	if len(t.Weeks) == 0 {
+		return 0
+	}
+	return t.Weeks[len(t.Weeks)-1].Before - t.Weeks[0].Before
+}
+
+// PrescriptionUsage is the usage of a prescription within a report.
+type PrescriptionUsage struct {
+	Prescription *can.Prescription // The prescription
+	Batches      int               // The number of batches dispensed within the range
+	Dispensed    float64           // The grams dispensed within the range
+	Remaining    float64           // The grams left of the quota at the end of the range, if still valid
+	Valid        bool              // Whether the prescription is valid at the end of the range
+}
+
+// EffectCount is the number of sessions an effect was perceived in.
+type EffectCount struct {
+	Effect   string // The name of the effect
+	Sessions int    // The number of sessions
+}
+
+// DayCount returns the number of calendar days of the report.
+func (r *Report) DayCount() int {
+	return max(daysUntil(r.From, r.To), 1)
+}
+
+// AverageTHC returns the consumed THC in mg per calendar day of the report.
+func (r *Report) AverageTHC() float64 {
+	return r.THC / float64(r.DayCount())
+}
+
+// AverageCBD returns the consumed CBD in mg per calendar day of the report.
+func (r *Report) AverageCBD() float64 {
+	return r.CBD / float64(r.DayCount())
+}
+
+// BuildReport summarizes the given sessions started in the time range
+// [from, to), the prescriptions valid within it and the batches of the given
+// strains dispensed on them.
+func BuildReport(sessions []*can.Session, strains []*can.Strain, prescriptions []*can.Prescription, from, to time.Time) *Report {
+	r := &Report{From: from, To: to}
+	var inRange []*can.Session
+	byStrain := map[string]*StrainConsumption{}
+	sideEffects := map[string]int{}
+	for _, s := range sessions {
+		if s.StartedAt.Before(from) || !s.StartedAt.Before(to) {
+			continue
+		}
+		inRange = append(inRange, s)
+		r.Sessions++
+		r.Grams += s.Grams
+		r.THC += s.THC
+		r.CBD += s.CBD
+		sc, ok := byStrain[s.Strain]
+		if !ok {
+			sc = &StrainConsumption{Strain: s.Strain}
+			byStrain[s.Strain] = sc
+		}
+		sc.Sessions++
+		sc.Grams += s.Grams
+		sc.THC += s.THC
+		sc.CBD += s.CBD
+		for _, e := range s.SideEffects {
+			sideEffects[e]++
+		}
+	}
+	for _, sc := range byStrain {
+		r.Strains = append(r.Strains, *sc)
+	}
+	sort.Slice(r.Strains, func(i, j int) bool {
+		if r.Strains[i].Grams != r.Strains[j].Grams {
+			return r.Strains[i].Grams > r.Strains[j].Grams
+		}
+		return r.Strains[i].Strain < r.Strains[j].Strain
+	})
+	r.Daily = TrackDosage(inRange, can.NewDosageCalculator(nil), from, to)
+	r.Symptoms = symptomTrends(inRange)
+	for e, n := range sideEffects {
+		r.SideEffects = append(r.SideEffects, EffectCount{Effect: e, Sessions: n})
+	}
+	sort.Slice(r.SideEffects, func(i, j int) bool {
+		if r.SideEffects[i].Sessions != r.SideEffects[j].Sessions {
+			return r.SideEffects[i].Sessions > r.SideEffects[j].Sessions
+		}
+		return r.SideEffects[i].Effect < r.SideEffects[j].Effect
+	})
+	r.Prescriptions = prescriptionUsage(prescriptions, strains, from, to)
+	return r
+}
+
+// symptomTrends averages the severities of the symptoms rated in the given
+// sessions, overall and per week. The trends are sorted by symptom.
+func symptomTrends(sessions []*can.Session) []SymptomTrend {
+	trends := map[string]*SymptomTrend{}
+	weeks := map[string]map[time.Time]*WeeklySymptom{}
+	for _, s := range sessions {
+		week := startOfWeek(s.StartedAt)
+		for _, score := range s.Symptoms {
+			t, ok := trends[score.Symptom]
+			if !ok {
+				t = &SymptomTrend{Symptom: score.Symptom}
+				trends[score.Symptom] = t
+				weeks[score.Symptom] = map[time.Time]*WeeklySymptom{}
+			}
+			t.Sessions++
+			t.Before += float64(score.Before)
+			t.After += float64(score.After)
+			w, ok := weeks[score.Symptom][week]
+			if !ok {
+				w = &WeeklySymptom{Week: week}
+				weeks[score.Symptom][week] = w
+			}
+			w.Sessions++
+			w.Before += float64(score.Before)
+			w.After += float64(score.After)
+		}
+	}
+	var sorted []SymptomTrend
+	for symptom, t := range trends {
+		t.Before /= float64(t.Sessions)
+		t.After /= float64(t.Sessions)
+		for _, w := range weeks[symptom] {
+			w.Before /= float64(w.Sessions)
+			w.After /= float64(w.Sessions)
+			t.Weeks = append(t.Weeks, *w)
+		}
+		sort.Slice(t.Weeks, func(i, j int) bool {
+			return t.Weeks[i].Week.Before(t.Weeks[j].Week)
+		})
+		sorted = append(sorted, *t)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Symptom < sorted[j].Symptom
+	})
+	return sorted
+}
+
+// prescriptionUsage returns the usage of the given prescriptions valid within
+// the time range [from, to) by the batches of the given strains.
+func prescriptionUsage(prescriptions []*can.Prescription, strains []*can.Strain, from, to time.Time) []PrescriptionUsage {
+	end := to.Add(-time.Nanosecond)
+	var usage []PrescriptionUsage
+	for _, p := range prescriptions {
+		if !p.IssuedAt.Before(to) || !p.ValidUntil.AddDate(0, 0, 1).After(from) {
+			continue
+		}
+		u := PrescriptionUsage{Prescription: p, Valid: p.Valid(end)}
+		for _, s := range strains {
+			for _, b := range s.Batches {
+				if b.PrescriptionID == p.ID && !b.PurchasedAt.Before(from) && b.PurchasedAt.Before(to) {
+					u.Batches++
+					u.Dispensed += b.Grams
+				}
+			}
+		}
+		if u.Valid {
+			u.Remaining = p.Remaining(strains, end)
+		}
+		usage = append(usage, u)
+	}
+	sort.Slice(usage, func(i, j int) bool {
+		return usage[i].Prescription.IssuedAt.Before(usage[j].Prescription.IssuedAt)
+	})
+	return usage
+}
+
+// startOfWeek returns the start of the week (Monday) of the given time.
+func startOfWeek(t time.Time) time.Time {
+	return startOfDay(t).AddDate(0, 0, -(int(t.Weekday())+6)%7)
+}
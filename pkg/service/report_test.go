@@ -0,0 +1,67 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	can "github.com/TheDonDope/wits-tui/pkg/cannabis"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildReport(t *testing.T) {
+	from := time.Date(2024, time.March, 4, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 14)
+	day := func(d, h int) time.Time { return from.AddDate(0, 0, d).Add(time.Duration(h) * time.Hour) }
+	p := &can.Prescription{ID: uuid.New(), Doctor: "Dr. Test", IssuedAt: from.AddDate(0, -1, 0), ValidUntil: from.AddDate(0, 2, 0), Grams: 30}
+	expired := &can.Prescription{ID: uuid.New(), Doctor: "Dr. Old", IssuedAt: from.AddDate(0, -6, 0), ValidUntil: from.AddDate(0, -3, 0)}
+	strains := []*can.Strain{{Strain: "Pink Kush", Batches: []*can.Batch{
+		{Grams: 10, PurchasedAt: day(1, 10), PrescriptionID: p.ID},
+		{Grams: 5, PurchasedAt: from.AddDate(0, 0, -10), PrescriptionID: p.ID},
+	}}}
+	sessions := []*can.Session{
+		{Strain: "Pink Kush", Grams: 0.2, THC: 40, CBD: 1, StartedAt: day(0, 20),
+			Symptoms: []*can.SymptomScore{{Symptom: "pain", Before: 8, After: 4}}, SideEffects: []string{"dry mouth"}},
+		{Strain: "Pink Kush", Grams: 0.1, THC: 20, CBD: 0.5, StartedAt: day(0, 22), SideEffects: []string{"dry mouth", "dizziness"}},
+		{Strain: "Bedrocan", Grams: 0.5, THC: 100, StartedAt: day(8, 21),
+			Symptoms: []*can.SymptomScore{{Symptom: "pain", Before: 6, After: 2}, {Symptom: "sleep", Before: 7, After: 3}}},
+		{Strain: "Bedrocan", Grams: 1, THC: 200, StartedAt: to},
+	}
+
+	r := BuildReport(sessions, strains, []*can.Prescription{p, expired}, from, to)
+
+	assert.Equal(t, 3, r.Sessions)
+	assert.InDelta(t, 0.8, r.Grams, 1e-9)
+	assert.InDelta(t, 160, r.THC, 1e-9)
+	assert.Equal(t, 14, r.DayCount())
+	assert.InDelta(t, 160.0/14, r.AverageTHC(), 1e-9)
+
+	require.Len(t, r.Strains, 2)
+	assert.Equal(t, "Bedrocan", r.Strains[0].Strain)
+	assert.Equal(t, StrainConsumption{Strain: "Pink Kush", Sessions: 2, Grams: 0.30000000000000004, THC: 60, CBD: 1.5}, r.Strains[1])
+
+	require.Len(t, r.Daily, 2)
+	assert.Equal(t, from, r.Daily[0].Day)
+	assert.Equal(t, 2, r.Daily[0].Sessions)
+
+	require.Len(t, r.Symptoms, 2)
+	pain := r.Symptoms[0]
+	assert.Equal(t, "pain", pain.Symptom)
+	assert.Equal(t, 2, pain.Sessions)
+	assert.InDelta(t, 4, pain.Relief(), 1e-9)
+	require.Len(t, pain.Weeks, 2)
+	assert.Equal(t, from.AddDate(0, 0, 7), pain.Weeks[1].Week)
+	assert.InDelta(t, -2, pain.Change(), 1e-9)
+	assert.Equal(t, "sleep", r.Symptoms[1].Symptom)
+
+	assert.Equal(t, []EffectCount{{Effect: "dry mouth", Sessions: 2}, {Effect: "dizziness", Sessions: 1}}, r.SideEffects)
+
+	require.Len(t, r.Prescriptions, 1)
+	u := r.Prescriptions[0]
+	assert.Same(t, p, u.Prescription)
+	assert.Equal(t, 1, u.Batches)
+	assert.InDelta(t, 10, u.Dispensed, 1e-9)
+	assert.True(t, u.Valid)
+	assert.InDelta(t, 20, u.Remaining, 1e-9, "the earlier batch is of the previous quota period")
+}
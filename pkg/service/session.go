@@ -0,0 +1,55 @@
+package service
+
+import (
+	"log"
+	"time"
+
+	can "github.com/TheDonDope/wits-tui/pkg/cannabis"
+	"github.com/TheDonDope/wits-tui/pkg/storage"
+)
+
+// SessionService provides operations on consumption sessions.
+type SessionService interface {
+	LogSession(s *can.Session) (*can.Strain, error)
+	GetSessions() []*can.Session
+}
+
+// SessionServiceType provides operations on consumption sessions, accessing a
+// session and a strain store.
+type SessionServiceType struct {
+	sessions storage.SessionStore
+	strains  storage.StrainStore
+}
+
+// NewSessionService creates a new service layer for consumption sessions.
+func NewSessionService(sessions storage.SessionStore, strains storage.StrainStore) *SessionServiceType {
+	log.Println("✅ 🤝  (pkg/service/session.go) NewSessionService(sessions storage.SessionStore, strains storage.StrainStore)")
+	return &SessionServiceType{sessions: sessions, strains: strains}
+}
+
+// LogSession adds the session to the store and removes the consumed grams
+// from the stock of its strain. The consumed milligrams of THC and CBD are
+// computed from the potency of the strain. It returns the updated strain.
+func (svc *SessionServiceType) LogSession(s *can.Session) (*can.Strain, error) {
+	log.Printf("💬 🤝  (pkg/service/session.go) LogSession(s *can.Session: %v)\n", s.ID)
+	strain, err := svc.strains.FindStrainByProduct(s.Strain)
+	if err != nil {
+		return nil, err
+	}
+	s.THC, s.CBD = can.Milligrams(s.Grams, strain.Potency())
+	strain.Consume(s.Grams)
+	strain.UpdatedAt = time.Now()
+	if err := svc.strains.UpdateStrain(strain); err != nil {
+		return nil, err
+	}
+	if err := svc.sessions.AddSession(s); err != nil {
+		return nil, err
+	}
+	return strain, nil
+}
+
+// GetSessions retrieves all sessions from the store.
+func (svc *SessionServiceType) GetSessions() []*can.Session {
+	log.Println("💬 🤝  (pkg/service/session.go) GetSessions()")
+	return svc.sessions.GetSessions()
+}
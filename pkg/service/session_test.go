@@ -0,0 +1,57 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	can "github.com/TheDonDope/wits-tui/pkg/cannabis"
+	"github.com/TheDonDope/wits-tui/pkg/storage"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSessionService(t *testing.T) {
+	t.Run("LogSession", func(t *testing.T) {
+		t.Setenv("STORAGE_MODE", storage.StoreInMemory)
+		strains := storage.NewStrainStore()
+		require.NoError(t, strains.AddStrain(testStrain()))
+		svc := NewSessionService(storage.NewSessionStore(), strains)
+		session := &can.Session{ID: uuid.New(), Strain: testStrain().Strain, Grams: 0.5, StartedAt: time.Now()}
+
+		strain, err := svc.LogSession(session)
+
+		require.NoError(t, err)
+		assert.InDelta(t, 3.0, strain.Amount, 1e-9)
+		assert.InDelta(t, 100, session.THC, 1e-9)
+		assert.InDelta(t, 2.5, session.CBD, 1e-9)
+		assert.Equal(t, []*can.Session{session}, svc.GetSessions())
+	})
+
+	t.Run("AcidProfile", func(t *testing.T) {
+		t.Setenv("STORAGE_MODE", storage.StoreInMemory)
+		strains := storage.NewStrainStore()
+		strain := testStrain()
+		strain.THC, strain.CBD = 0, 0
+		strain.Cannabinoids = map[can.CannabinoidType]float64{can.THCA: 20, can.Delta9THC: 1, can.CBDA: 2}
+		require.NoError(t, strains.AddStrain(strain))
+		svc := NewSessionService(storage.NewSessionStore(), strains)
+		session := &can.Session{ID: uuid.New(), Strain: strain.Strain, Grams: 0.5, StartedAt: time.Now()}
+
+		_, err := svc.LogSession(session)
+
+		require.NoError(t, err)
+		assert.InDelta(t, 5*(1+20*can.DecarboxylationFactor), session.THC, 1e-9)
+		assert.InDelta(t, 5*2*can.DecarboxylationFactor, session.CBD, 1e-9)
+	})
+
+	t.Run("UnknownStrain", func(t *testing.T) {
+		t.Setenv("STORAGE_MODE", storage.StoreInMemory)
+		svc := NewSessionService(storage.NewSessionStore(), storage.NewStrainStore())
+
+		_, err := svc.LogSession(&can.Session{ID: uuid.New(), Strain: "Unknown", Grams: 0.5})
+
+		assert.ErrorIs(t, err, storage.ErrStrainNotFound)
+		assert.Empty(t, svc.GetSessions())
+	})
+}
@@ -0,0 +1,221 @@
+package service
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	can "github.com/TheDonDope/wits-tui/pkg/cannabis"
+	"github.com/TheDonDope/wits-tui/pkg/i18n"
+)
+
+// The weights of the parts of the similarity of two strains, summing up to 1.
+const (
+	terpeneWeight = 0.5
+	geneticWeight = 0.2
+	ratioWeight   = 0.3
+)
+
+// StrainSimilarity is the similarity of a strain to another one, together
+// with the reasons for it.
+type StrainSimilarity struct {
+	Strain         *can.Strain       // The similar strain
+	Score          float64           // The similarity between 0 (none) and 1 (same profile)
+	SharedTerpenes []can.TerpeneType // The terpenes both strains contain
+	SameGenetic    bool              // Whether both strains have the same genetic
+	RatioDelta     float64           // The difference of the THC shares of the cannabinoid content, between 0 and 1
+	RatioKnown     bool              // Whether both strains contain THC or CBD to compare the ratio
+}
+
+// SimilarStrains returns all given strains except the given one, ranked by
+// their similarity to it, most similar first. The similarity is composed of
+// the overlap of the terpenes, the genetic and the THC/CBD ratio.
+func SimilarStrains(strain *can.Strain, strains []*can.Strain) []StrainSimilarity {
+	similar := []StrainSimilarity{}
+	for _, other := range strains {
+		if other.ID == strain.ID {
+			continue
+		}
+		similar = append(similar, similarity(strain, other))
+	}
+	sort.SliceStable(similar, func(i, j int) bool {
+		return similar[i].Score > similar[j].Score
+	})
+	return similar
+}
+
+// similarity returns the similarity of the other strain to the given one.
+func similarity(strain, other *can.Strain) StrainSimilarity {
+	sim := StrainSimilarity{Strain: other}
+
+	union := map[can.TerpeneType]bool{}
+	for _, t := range strain.Terpenes {
+		if t.Terpene() != nil {
+			union[t.Type] = true
+		}
+	}
+	for _, t := range other.Terpenes {
+		if t.Terpene() == nil {
+			continue
+		}
+		if union[t.Type] && !slices.Contains(sim.SharedTerpenes, t.Type) {
+			sim.SharedTerpenes = append(sim.SharedTerpenes, t.Type)
+		}
+		union[t.Type] = true
+	}
+	slices.Sort(sim.SharedTerpenes)
+	if len(union) > 0 {
+		sim.Score += terpeneWeight * float64(len(sim.SharedTerpenes)) / float64(len(union))
+	}
+
+	switch {
+	case strain.Genetic == other.Genetic:
+		sim.SameGenetic = true
+		sim.Score += geneticWeight
+	case strain.Genetic == can.Hybrid || other.Genetic == can.Hybrid:
+		// A hybrid shares half of its phenotype with sativas and indicas
+		sim.Score += geneticWeight / 2
+	}
+
+	a, aok := thcShare(strain)
+	b, bok := thcShare(other)
+	if aok && bok {
+		sim.RatioKnown = true
+		sim.RatioDelta = a - b
+		if sim.RatioDelta < 0 {
+			sim.RatioDelta = -sim.RatioDelta
+		}
+		sim.Score += ratioWeight * (1 - sim.RatioDelta)
+	}
+	return sim
+}
+
+// thcShare returns the share of THC in the THC and CBD content of the given
+// strain, or false if it contains neither.
+func thcShare(s *can.Strain) (float64, bool) {
+	total := s.THC + s.CBD
+	if total <= 0 {
+		return 0, false
+	}
+	return s.THC / total, true
+}
+
+// StrainMatch is a strain matching desired effects and flavors.
+type StrainMatch struct {
+	Strain  *can.Strain // The matching strain
+	Score   float64     // The share of the desired effects and flavors the strain provides
+	Effects []string    // The desired effects the strain provides
+	Flavors []string    // The desired flavors the strain provides
+}
+
+// RecommendStrains returns the given strains in stock which provide any of the
+// desired effects or flavors through their terpenes and cannabinoids, best
+// match first. Strains matching equally are ordered by their terpene content
+// providing the matches, highest first.
+func RecommendStrains(strains []*can.Strain, effects, flavors []string) []StrainMatch {
+	matches := []StrainMatch{}
+	desired := len(effects) + len(flavors)
+	if desired == 0 {
+		return matches
+	}
+	content := map[*can.Strain]float64{}
+	for _, s := range strains {
+		if s.Amount <= 0 {
+			continue
+		}
+		m := StrainMatch{Strain: s}
+		provided := map[string]bool{}
+		tasted := map[string]bool{}
+		for _, t := range s.Terpenes {
+			tp := t.Terpene()
+			if tp == nil {
+				continue
+			}
+			matching := false
+			for _, e := range tp.Effects {
+				if slices.Contains(effects, e) {
+					provided[e], matching = true, true
+				}
+			}
+			for _, f := range tp.Flavors {
+				if slices.Contains(flavors, f) {
+					tasted[f], matching = true, true
+				}
+			}
+			if matching {
+				content[s] += t.Percent
+			}
+		}
+		for c := range s.Cannabinoids {
+			for _, e := range can.Cannabinoids[c].Effects {
+				if slices.Contains(effects, e) {
+					provided[e] = true
+				}
+			}
+		}
+		for _, e := range effects {
+			if provided[e] {
+				m.Effects = append(m.Effects, e)
+			}
+		}
+		for _, f := range flavors {
+			if tasted[f] {
+				m.Flavors = append(m.Flavors, f)
+			}
+		}
+		if len(m.Effects)+len(m.Flavors) == 0 {
+			continue
+		}
+		m.Score = float64(len(m.Effects)+len(m.Flavors)) / float64(desired)
+		matches = append(matches, m)
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].Score != matches[j].Score {
+			return matches[i].Score > matches[j].Score
+		}
+		return content[matches[i].Strain] > content[matches[j].Strain]
+	})
+	return matches
+}
+
+// SimilarityLine returns the translated line describing the given similar
+// strain, naming its similarity in percent and the reasons for it.
+func SimilarityLine(sim StrainSimilarity) string {
+	line := fmt.Sprintf("%s (%s%%)", sim.Strain.Strain, i18n.FormatFloat(sim.Score*100, 0))
+	if reasons := SimilarityReasons(sim); reasons != "" {
+		line += ": " + reasons
+	}
+	return line
+}
+
+// SimilarityReasons returns the translated explanation of the given similarity
+// by the shared terpenes, the genetic and the THC/CBD ratio.
+func SimilarityReasons(sim StrainSimilarity) string {
+	var reasons []string
+	if len(sim.SharedTerpenes) > 0 {
+		names := make([]string, len(sim.SharedTerpenes))
+		for i, t := range sim.SharedTerpenes {
+			names[i] = i18n.Term(can.Terpenes[t].Name)
+		}
+		reasons = append(reasons, i18n.T("shares %s", strings.Join(names, ", ")))
+	}
+	if sim.SameGenetic {
+		reasons = append(reasons, i18n.T("also %s", i18n.Term(can.Genetics[sim.Strain.Genetic])))
+	}
+	if sim.RatioKnown {
+		if delta := sim.RatioDelta * 100; delta < 5 {
+			reasons = append(reasons, i18n.T("same THC/CBD ratio"))
+		} else {
+			reasons = append(reasons, i18n.T("THC share differs by %s%%", i18n.FormatFloat(delta, 0)))
+		}
+	}
+	return strings.Join(reasons, ", ")
+}
+
+// MatchLine returns the translated line describing the given recommended
+// strain, naming its match in percent and the effects and flavors it provides.
+func MatchLine(m StrainMatch) string {
+	return fmt.Sprintf("%s (%s%%): %s", m.Strain.Strain, i18n.FormatFloat(m.Score*100, 0),
+		strings.Join(i18n.Terms(slices.Concat(m.Effects, m.Flavors)), ", "))
+}
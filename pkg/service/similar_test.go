@@ -0,0 +1,120 @@
+package service
+
+import (
+	"testing"
+
+	can "github.com/TheDonDope/wits-tui/pkg/cannabis"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+// profiledStrain returns a test strain with the given name, genetic, THC/CBD
+// content and terpenes.
+func profiledStrain(name string, g can.GeneticType, thc, cbd float64, terpenes ...*can.StrainTerpene) *can.Strain {
+	s := testStrain()
+	s.ID = uuid.New()
+	s.Strain, s.Genetic, s.THC, s.CBD = name, g, thc, cbd
+	s.Cannabinoids = nil
+	s.Terpenes = terpenes
+	return s
+}
+
+func TestSimilarStrains(t *testing.T) {
+	target := profiledStrain("Target", can.Indica, 20, 0, &can.StrainTerpene{Type: can.BetaMyrcene}, &can.StrainTerpene{Type: can.Linalool})
+	twin := profiledStrain("Twin", can.Indica, 18, 0, &can.StrainTerpene{Type: can.Linalool}, &can.StrainTerpene{Type: can.BetaMyrcene})
+	hybrid := profiledStrain("Hybrid", can.Hybrid, 10, 10, &can.StrainTerpene{Type: can.BetaMyrcene}, &can.StrainTerpene{Type: can.Limonene})
+	other := profiledStrain("Other", can.Sativa, 0, 0, &can.StrainTerpene{Type: can.Limonene})
+
+	similar := SimilarStrains(target, []*can.Strain{other, target, hybrid, twin})
+
+	require.Len(t, similar, 3, "the strain itself is not listed")
+	assert.Equal(t, []string{"Twin", "Hybrid", "Other"}, []string{similar[0].Strain.Strain, similar[1].Strain.Strain, similar[2].Strain.Strain})
+
+	assert.InDelta(t, 1.0, similar[0].Score, 1e-9)
+	assert.Equal(t, []can.TerpeneType{can.BetaMyrcene, can.Linalool}, similar[0].SharedTerpenes)
+	assert.True(t, similar[0].SameGenetic)
+	assert.True(t, similar[0].RatioKnown)
+	assert.Zero(t, similar[0].RatioDelta)
+
+	// A third of the terpenes, half a genetic and half the ratio
+	assert.InDelta(t, 0.5/3+0.1+0.15, similar[1].Score, 1e-9)
+	assert.Equal(t, []can.TerpeneType{can.BetaMyrcene}, similar[1].SharedTerpenes)
+	assert.False(t, similar[1].SameGenetic)
+	assert.InDelta(t, 0.5, similar[1].RatioDelta, 1e-9)
+
+	assert.Zero(t, similar[2].Score)
+	assert.False(t, similar[2].RatioKnown, "the ratio of a strain without THC and CBD is unknown")
+}
+
+func TestSimilarStrains_MissingTerpene(t *testing.T) {
+	var missing can.StrainTerpene
+	require.NoError(t, yaml.Unmarshal([]byte("id: unobtainium\n"), &missing))
+	target := profiledStrain("Target", can.Indica, 20, 0, &missing, &can.StrainTerpene{Type: can.BetaMyrcene})
+	other := profiledStrain("Other", can.Indica, 20, 0, &missing, &can.StrainTerpene{Type: can.BetaMyrcene})
+
+	similar := SimilarStrains(target, []*can.Strain{other})
+	matches := RecommendStrains([]*can.Strain{other}, []string{"analgesic"}, nil)
+
+	require.Len(t, similar, 1)
+	assert.Equal(t, []can.TerpeneType{can.BetaMyrcene}, similar[0].SharedTerpenes, "terpenes missing from the catalog are not compared")
+	require.Len(t, matches, 1)
+}
+
+func TestSimilarityReasons(t *testing.T) {
+	sim := StrainSimilarity{
+		Strain:         &can.Strain{Strain: "Twin", Genetic: can.Indica},
+		Score:          0.9,
+		SharedTerpenes: []can.TerpeneType{can.BetaMyrcene, can.Linalool},
+		SameGenetic:    true,
+		RatioKnown:     true,
+		RatioDelta:     0.01,
+	}
+	assert.Equal(t, "shares β-Myrcene, Linalool, also Indica, same THC/CBD ratio", SimilarityReasons(sim))
+
+	sim.SharedTerpenes, sim.SameGenetic, sim.RatioDelta = nil, false, 0.25
+	assert.Equal(t, "THC share differs by 25%", SimilarityReasons(sim))
+	assert.Equal(t, "Twin (90%): THC share differs by 25%", SimilarityLine(sim))
+}
+
+func TestMatchLine(t *testing.T) {
+	m := StrainMatch{Strain: &can.Strain{Strain: "Lavender"}, Score: 0.5, Effects: []string{"sedative"}, Flavors: []string{"citrus"}}
+
+	assert.Equal(t, "Lavender (50%): sedative, citrus", MatchLine(m))
+}
+
+func TestRecommendStrains(t *testing.T) {
+	citrus := profiledStrain("Citrus", can.Sativa, 20, 0, &can.StrainTerpene{Type: can.Limonene, Percent: 0.4})
+	lavender := profiledStrain("Lavender", can.Indica, 20, 0, &can.StrainTerpene{Type: can.Linalool, Percent: 0.9})
+	earthy := profiledStrain("Earthy", can.Indica, 20, 0, &can.StrainTerpene{Type: can.BetaMyrcene})
+	earthy.Cannabinoids = map[can.CannabinoidType]float64{can.CBD: 1}
+	empty := profiledStrain("Empty", can.Sativa, 20, 0, &can.StrainTerpene{Type: can.Linalool})
+	empty.Amount = 0
+	strains := []*can.Strain{citrus, lavender, earthy, empty}
+
+	t.Run("Flavors", func(t *testing.T) {
+		matches := RecommendStrains(strains, nil, []string{"citrus"})
+
+		require.Len(t, matches, 2, "strains out of stock are not recommended")
+		assert.Equal(t, "Lavender", matches[0].Strain.Strain, "the higher terpene content wins a tie")
+		assert.Equal(t, "Citrus", matches[1].Strain.Strain)
+		assert.Equal(t, []string{"citrus"}, matches[0].Flavors)
+		assert.InDelta(t, 1.0, matches[0].Score, 1e-9)
+	})
+
+	t.Run("Effects", func(t *testing.T) {
+		matches := RecommendStrains(strains, []string{"sedative", "anti-anxiety"}, []string{"earth"})
+
+		require.Len(t, matches, 2)
+		assert.Equal(t, "Earthy", matches[0].Strain.Strain)
+		assert.Equal(t, []string{"anti-anxiety"}, matches[0].Effects, "effects of the cannabinoids count")
+		assert.Equal(t, []string{"earth"}, matches[0].Flavors)
+		assert.InDelta(t, 2.0/3, matches[0].Score, 1e-9)
+		assert.Equal(t, "Lavender", matches[1].Strain.Strain)
+	})
+
+	t.Run("Nothing desired", func(t *testing.T) {
+		assert.Empty(t, RecommendStrains(strains, nil, nil))
+	})
+}
@@ -0,0 +1,101 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	can "github.com/TheDonDope/wits-tui/pkg/cannabis"
+)
+
+// StrainSortOrder is the order in which strains are listed.
+type StrainSortOrder string
+
+const (
+	// SortByName sorts strains by their product name, ascending.
+	SortByName StrainSortOrder = "name"
+	// SortByTHC sorts strains by their THC content, highest first.
+	SortByTHC StrainSortOrder = "thc"
+	// SortByCBD sorts strains by their CBD content, highest first.
+	SortByCBD StrainSortOrder = "cbd"
+	// SortByAmount sorts strains by their amount, highest first.
+	SortByAmount StrainSortOrder = "amount"
+	// SortByCreated sorts strains by their creation timestamp, newest first.
+	SortByCreated StrainSortOrder = "created"
+	// SortByUpdated sorts strains by their last update timestamp, newest first.
+	SortByUpdated StrainSortOrder = "updated"
+	// SortByRating sorts strains by their average overall rating, best first.
+	SortByRating StrainSortOrder = "rating"
+)
+
+// StrainSortOrders contains all sort orders in the order they are cycled
+// through.
+var StrainSortOrders = []StrainSortOrder{SortByName, SortByTHC, SortByCBD, SortByAmount, SortByCreated, SortByUpdated, SortByRating}
+
+// ParseStrainSortOrder returns the sort order with the given name. An empty
+// name results in the default SortByName.
+func ParseStrainSortOrder(name string) (StrainSortOrder, error) {
+	if name == "" {
+		return SortByName, nil
+	}
+	for _, o := range StrainSortOrders {
+		if string(o) == name {
+			return o, nil
+		}
+	}
+	return SortByName, fmt.Errorf("unknown sort order %q", name)
+}
+
+// Next returns the sort order following this one, wrapping around to the
+// first.
+func (o StrainSortOrder) Next() StrainSortOrder {
+	for i, so := range StrainSortOrders {
+		if so == o {
+			return StrainSortOrders[(i+1)%len(StrainSortOrders)]
+		}
+	}
+	return SortByName
+}
+
+// SortStrains sorts the given strains in place by the given order. Favorites
+// are pinned to the top, and strains considered equal are ordered by their
+// product name.
+func SortStrains(strains []*can.Strain, order StrainSortOrder) {
+	byName := func(a, b *can.Strain) bool {
+		return strings.ToLower(a.Strain) < strings.ToLower(b.Strain)
+	}
+	sort.SliceStable(strains, func(i, j int) bool {
+		a, b := strains[i], strains[j]
+		if a.Favorite != b.Favorite {
+			return a.Favorite
+		}
+		switch order {
+		case SortByTHC:
+			if a.THC != b.THC {
+				return a.THC > b.THC
+			}
+		case SortByCBD:
+			if a.CBD != b.CBD {
+				return a.CBD > b.CBD
+			}
+		case SortByAmount:
+			if a.Amount != b.Amount {
+				return a.Amount > b.Amount
+			}
+		case SortByCreated:
+			if !a.CreatedAt.Equal(b.CreatedAt) {
+				return a.CreatedAt.After(b.CreatedAt)
+			}
+		case SortByUpdated:
+			if !a.UpdatedAt.Equal(b.UpdatedAt) {
+				return a.UpdatedAt.After(b.UpdatedAt)
+			}
+		case SortByRating:
+			ra, rb := a.AverageRatings().Overall, b.AverageRatings().Overall
+			if ra != rb {
+				return ra > rb
+			}
+		}
+		return byName(a, b)
+	})
+}
@@ -0,0 +1,83 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	can "github.com/TheDonDope/wits-tui/pkg/cannabis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sortTestStrains returns strains with distinct values for every sort order.
+func sortTestStrains() []*can.Strain {
+	a, b, c := testStrain(), testStrain(), testStrain()
+	a.Strain, a.THC, a.CBD, a.Amount = "Alpha", 18, 1, 10
+	b.Strain, b.THC, b.CBD, b.Amount = "bravo", 25, 0.1, 5
+	c.Strain, c.THC, c.CBD, c.Amount = "Charlie", 22, 8, 15
+	a.CreatedAt = a.CreatedAt.Add(2 * time.Hour)
+	b.CreatedAt = b.CreatedAt.Add(time.Hour)
+	b.UpdatedAt = b.UpdatedAt.Add(time.Hour)
+	c.UpdatedAt = c.UpdatedAt.Add(2 * time.Hour)
+	a.Ratings = []*can.Rating{{Overall: 3}, {Overall: 5}}
+	b.Ratings = []*can.Rating{{Overall: 3}}
+	return []*can.Strain{c, a, b}
+}
+
+func TestSortStrains(t *testing.T) {
+	tests := []struct {
+		order    StrainSortOrder
+		expected []string
+	}{
+		{SortByName, []string{"Alpha", "bravo", "Charlie"}},
+		{SortByTHC, []string{"bravo", "Charlie", "Alpha"}},
+		{SortByCBD, []string{"Charlie", "Alpha", "bravo"}},
+		{SortByAmount, []string{"Charlie", "Alpha", "bravo"}},
+		{SortByCreated, []string{"Alpha", "bravo", "Charlie"}},
+		{SortByUpdated, []string{"Charlie", "bravo", "Alpha"}},
+		{SortByRating, []string{"Alpha", "bravo", "Charlie"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.order), func(t *testing.T) {
+			strains := sortTestStrains()
+			SortStrains(strains, tt.order)
+			var names []string
+			for _, s := range strains {
+				names = append(names, s.Strain)
+			}
+			assert.Equal(t, tt.expected, names)
+		})
+	}
+}
+
+func TestSortStrains_Favorites(t *testing.T) {
+	strains := sortTestStrains()
+	strains[0].Favorite = true // Charlie
+
+	SortStrains(strains, SortByName)
+
+	assert.Equal(t, "Charlie", strains[0].Strain, "favorites are pinned to the top")
+	assert.Equal(t, "Alpha", strains[1].Strain)
+}
+
+func TestStrainSortOrder(t *testing.T) {
+	t.Run("Parse", func(t *testing.T) {
+		o, err := ParseStrainSortOrder("thc")
+		require.NoError(t, err)
+		assert.Equal(t, SortByTHC, o)
+
+		o, err = ParseStrainSortOrder("")
+		require.NoError(t, err)
+		assert.Equal(t, SortByName, o)
+
+		_, err = ParseStrainSortOrder("color")
+		assert.Error(t, err)
+	})
+
+	t.Run("Next", func(t *testing.T) {
+		assert.Equal(t, SortByTHC, SortByName.Next())
+		assert.Equal(t, SortByRating, SortByUpdated.Next())
+		assert.Equal(t, SortByName, SortByRating.Next())
+	})
+}
@@ -0,0 +1,97 @@
+package service
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	can "github.com/TheDonDope/wits-tui/pkg/cannabis"
+	"github.com/TheDonDope/wits-tui/pkg/i18n"
+)
+
+// MonthlySpend is the summary of the money spent on purchases within a month.
+type MonthlySpend struct {
+	Month          time.Time          // The first day of the month
+	Total          float64            // The total price paid
+	Grams          float64            // The total grams purchased
+	ByManufacturer map[string]float64 // The price paid, keyed by manufacturer
+	ByPharmacy     map[string]float64 // The price paid, keyed by pharmacy
+}
+
+// SummarizeSpend returns the monthly spending on all priced batches of the
+// given strains which were purchased within [from, to), sorted by month. Zero
+// bounds are not applied. Purchases without a manufacturer or pharmacy are
+// grouped under an empty key.
+func SummarizeSpend(strains []*can.Strain, from, to time.Time) []*MonthlySpend {
+	months := map[time.Time]*MonthlySpend{}
+	for _, s := range strains {
+		for _, b := range s.Batches {
+			if b.Price <= 0 || b.PurchasedAt.IsZero() {
+				continue
+			}
+			if (!from.IsZero() && b.PurchasedAt.Before(from)) || (!to.IsZero() && !b.PurchasedAt.Before(to)) {
+				continue
+			}
+			month := startOfMonth(b.PurchasedAt)
+			ms, ok := months[month]
+			if !ok {
+				ms = &MonthlySpend{
+					Month:          month,
+					ByManufacturer: map[string]float64{},
+					ByPharmacy:     map[string]float64{},
+				}
+				months[month] = ms
+			}
+			ms.Total += b.Price
+			ms.Grams += b.Grams
+			ms.ByManufacturer[s.Manufacturer] += b.Price
+			ms.ByPharmacy[b.Pharmacy] += b.Price
+		}
+	}
+
+	summary := make([]*MonthlySpend, 0, len(months))
+	for _, ms := range months {
+		summary = append(summary, ms)
+	}
+	sort.Slice(summary, func(i, j int) bool {
+		return summary[i].Month.Before(summary[j].Month)
+	})
+	return summary
+}
+
+// GroupsBySpend returns the keys of the given spend groups, ordered by the
+// price paid from highest to lowest and then by name.
+func GroupsBySpend(groups map[string]float64) []string {
+	keys := make([]string, 0, len(groups))
+	for k := range groups {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if groups[keys[i]] != groups[keys[j]] {
+			return groups[keys[i]] > groups[keys[j]]
+		}
+		return keys[i] < keys[j]
+	})
+	return keys
+}
+
+// SpendGroupsLine returns the given spend groups as a single line with the
+// given label, ordered by the price paid, e.g. `Pharmacies: Linden-Apotheke
+// 120,00 €, unknown 30,00 €`. Purchases without a group are translated as
+// unknown.
+func SpendGroupsLine(label string, groups map[string]float64, currency string) string {
+	var parts []string
+	for _, g := range GroupsBySpend(groups) {
+		name := g
+		if name == "" {
+			name = i18n.T("unknown")
+		}
+		parts = append(parts, name+" "+i18n.FormatMoney(groups[g], currency))
+	}
+	return label + ": " + strings.Join(parts, ", ")
+}
+
+// startOfMonth returns the first day of the month of the given time.
+func startOfMonth(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+}
@@ -0,0 +1,66 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	can "github.com/TheDonDope/wits-tui/pkg/cannabis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// spendTestStrains returns strains with priced batches in January and
+// February 2024.
+func spendTestStrains() []*can.Strain {
+	day := func(month time.Month, d int) time.Time { return time.Date(2024, month, d, 10, 0, 0, 0, time.UTC) }
+	a, b := testStrain(), testStrain()
+	a.Manufacturer, b.Manufacturer = "Aurora", "Tilray"
+	a.Batches = []*can.Batch{
+		{PurchasedAt: day(time.January, 5), Pharmacy: "Apo A", Price: 100, Grams: 10},
+		{PurchasedAt: day(time.February, 2), Pharmacy: "Apo B", Price: 60, Grams: 5},
+		{PurchasedAt: day(time.February, 3), Grams: 3}, // Without price
+	}
+	b.Batches = []*can.Batch{
+		{PurchasedAt: day(time.January, 20), Pharmacy: "Apo A", Price: 50, Grams: 5},
+	}
+	return []*can.Strain{a, b}
+}
+
+func TestSummarizeSpend(t *testing.T) {
+	t.Run("AllMonths", func(t *testing.T) {
+		summary := SummarizeSpend(spendTestStrains(), time.Time{}, time.Time{})
+
+		require.Len(t, summary, 2)
+		jan, feb := summary[0], summary[1]
+		assert.Equal(t, time.January, jan.Month.Month())
+		assert.Equal(t, 150.0, jan.Total)
+		assert.Equal(t, 15.0, jan.Grams)
+		assert.Equal(t, map[string]float64{"Aurora": 100, "Tilray": 50}, jan.ByManufacturer)
+		assert.Equal(t, map[string]float64{"Apo A": 150}, jan.ByPharmacy)
+		assert.Equal(t, 60.0, feb.Total)
+		assert.Equal(t, 5.0, feb.Grams)
+	})
+
+	t.Run("Range", func(t *testing.T) {
+		from := time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC)
+		summary := SummarizeSpend(spendTestStrains(), from, time.Time{})
+
+		require.Len(t, summary, 1)
+		assert.Equal(t, time.February, summary[0].Month.Month())
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		assert.Empty(t, SummarizeSpend(nil, time.Time{}, time.Time{}))
+	})
+}
+
+func TestGroupsBySpend(t *testing.T) {
+	groups := map[string]float64{"b": 10, "a": 10, "c": 30}
+	assert.Equal(t, []string{"c", "a", "b"}, GroupsBySpend(groups))
+}
+
+func TestSpendGroupsLine(t *testing.T) {
+	groups := map[string]float64{"Linden-Apotheke": 120, "": 30}
+
+	assert.Equal(t, "Pharmacies: Linden-Apotheke 120.00 EUR, unknown 30.00 EUR", SpendGroupsLine("Pharmacies", groups, "EUR"))
+}
@@ -0,0 +1,86 @@
+package service
+
+import (
+	"math"
+	"time"
+
+	can "github.com/TheDonDope/wits-tui/pkg/cannabis"
+)
+
+// DefaultUsageWindow is the number of past days the average daily usage is
+// computed over, if none is given.
+const DefaultUsageWindow = 30
+
+// StockThresholds defines when the stock is running low.
+type StockThresholds struct {
+	LowGrams    float64 // The total amount in grams below which the stock is low
+	LowDays     int     // The days left until the predicted run-out below which the stock is low
+	UsageWindow int     // The number of past days the average daily usage is computed over
+}
+
+// StockForecast is the predicted run-out of either a single strain or the
+// overall stock.
+type StockForecast struct {
+	Product    string    // The product name of the strain, empty for the overall stock
+	Amount     float64   // The amount in grams in stock
+	DailyUsage float64   // The average grams consumed per day
+	DaysLeft   float64   // The days until the stock runs out, +Inf without usage
+	RunOut     time.Time // The predicted run-out date, zero without usage
+	Low        bool      // Whether the stock is below the thresholds
+}
+
+// Predicted reports whether a run-out date could be predicted, which requires
+// consumption within the usage window.
+func (f StockForecast) Predicted() bool {
+	return f.DailyUsage > 0
+}
+
+// ForecastStock predicts the run-out of the given strains from the average
+// daily usage of the given sessions within the usage window before the given
+// time. It returns the forecast of the overall stock and of every strain
+// consumed within the usage window, in the order of the given strains. The
+// overall stock is low if it is below the gram threshold or runs out within
+// the day threshold, a single strain is low if it runs out within the day
+// threshold.
+func ForecastStock(strains []*can.Strain, sessions []*can.Session, at time.Time, th StockThresholds) (StockForecast, []StockForecast) {
+	window := th.UsageWindow
+	if window <= 0 {
+		window = DefaultUsageWindow
+	}
+	from := at.AddDate(0, 0, -window)
+	used := map[string]float64{}
+	var usedTotal float64
+	for _, s := range sessions {
+		if s.StartedAt.Before(from) || s.StartedAt.After(at) {
+			continue
+		}
+		used[s.Strain] += s.Grams
+		usedTotal += s.Grams
+	}
+
+	var perStrain []StockForecast
+	var amount float64
+	for _, s := range strains {
+		amount += s.Amount
+		if used[s.Strain] == 0 {
+			continue
+		}
+		f := forecast(s.Strain, s.Amount, used[s.Strain]/float64(window), at)
+		f.Low = f.DaysLeft < float64(th.LowDays)
+		perStrain = append(perStrain, f)
+	}
+	overall := forecast("", amount, usedTotal/float64(window), at)
+	overall.Low = amount < th.LowGrams || overall.DaysLeft < float64(th.LowDays)
+	return overall, perStrain
+}
+
+// forecast returns the forecast for the given amount consumed at the given
+// daily usage from the given time on.
+func forecast(product string, amount, dailyUsage float64, at time.Time) StockForecast {
+	f := StockForecast{Product: product, Amount: amount, DailyUsage: dailyUsage, DaysLeft: math.Inf(1)}
+	if dailyUsage > 0 {
+		f.DaysLeft = amount / dailyUsage
+		f.RunOut = at.Add(time.Duration(f.DaysLeft * float64(24*time.Hour)))
+	}
+	return f
+}
@@ -0,0 +1,61 @@
+package service
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	can "github.com/TheDonDope/wits-tui/pkg/cannabis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestForecastStock(t *testing.T) {
+	at := time.Date(2024, time.March, 31, 12, 0, 0, 0, time.UTC)
+	a, b := testStrain(), testStrain()
+	a.Strain, a.Amount = "Alpha", 3
+	b.Strain, b.Amount = "Bravo", 12
+	strains := []*can.Strain{a, b}
+	sessions := []*can.Session{
+		{Strain: "Alpha", Grams: 6, StartedAt: at.AddDate(0, 0, -10)},
+		{Strain: "Alpha", Grams: 3, StartedAt: at.AddDate(0, 0, -2)},
+		{Strain: "Bravo", Grams: 50, StartedAt: at.AddDate(0, 0, -40)}, // Outside the window
+	}
+	th := StockThresholds{LowGrams: 5, LowDays: 7, UsageWindow: 30}
+
+	t.Run("Forecast", func(t *testing.T) {
+		overall, perStrain := ForecastStock(strains, sessions, at, th)
+
+		assert.Equal(t, 15.0, overall.Amount)
+		assert.InDelta(t, 0.3, overall.DailyUsage, 1e-9)
+		assert.InDelta(t, 50, overall.DaysLeft, 1e-9)
+		assert.Equal(t, at.AddDate(0, 0, 50), overall.RunOut)
+		assert.False(t, overall.Low)
+
+		require.Len(t, perStrain, 1)
+		assert.Equal(t, "Alpha", perStrain[0].Product)
+		assert.InDelta(t, 10, perStrain[0].DaysLeft, 1e-9)
+		assert.False(t, perStrain[0].Low)
+	})
+
+	t.Run("Low", func(t *testing.T) {
+		th := th
+		th.LowDays = 14
+		th.LowGrams = 20
+
+		overall, perStrain := ForecastStock(strains, sessions, at, th)
+
+		assert.True(t, overall.Low)
+		assert.True(t, perStrain[0].Low)
+	})
+
+	t.Run("WithoutUsage", func(t *testing.T) {
+		overall, perStrain := ForecastStock(strains, nil, at, StockThresholds{})
+
+		assert.False(t, overall.Predicted())
+		assert.True(t, math.IsInf(overall.DaysLeft, 1))
+		assert.True(t, overall.RunOut.IsZero())
+		assert.False(t, overall.Low)
+		assert.Empty(t, perStrain)
+	})
+}
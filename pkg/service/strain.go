@@ -2,6 +2,8 @@ package service
 
 import (
 	"log"
+	"strings"
+	"time"
 
 	can "github.com/TheDonDope/wits-tui/pkg/cannabis"
 	"github.com/TheDonDope/wits-tui/pkg/storage"
@@ -10,8 +12,14 @@ import (
 // StrainService provides operations on strains.
 type StrainService interface {
 	AddStrain(s *can.Strain) error
+	UpdateStrain(s *can.Strain) error
+	AddBatch(product string, b *can.Batch) (*can.Strain, error)
+	AddRating(product string, r *can.Rating) (*can.Strain, error)
+	ToggleFavorite(product string) (*can.Strain, error)
 	GetStrains() []*can.Strain
+	ListStrains(order StrainSortOrder) []*can.Strain
 	FindStrainByProduct(p string) (*can.Strain, error)
+	FindStrainByName(name string) (*can.Strain, error)
 }
 
 // StrainServiceType provides operations on strains, accessing a store.
@@ -31,14 +39,90 @@ func (svc *StrainServiceType) AddStrain(s *can.Strain) error {
 	return svc.store.AddStrain(s)
 }
 
+// UpdateStrain replaces the strain with the same product name in the store.
+func (svc *StrainServiceType) UpdateStrain(s *can.Strain) error {
+	log.Printf("💬 🤝  (pkg/service/strain.go) UpdateStrain(s *can.Strain: %v)\n", s.ID)
+	return svc.store.UpdateStrain(s)
+}
+
+// AddBatch adds a purchased batch to the strain with the given product name
+// and returns the updated strain.
+func (svc *StrainServiceType) AddBatch(product string, b *can.Batch) (*can.Strain, error) {
+	log.Printf("💬 🤝  (pkg/service/strain.go) AddBatch(product string: %v, b *can.Batch: %v)\n", product, b.ID)
+	s, err := svc.store.FindStrainByProduct(product)
+	if err != nil {
+		return nil, err
+	}
+	s.AddBatch(b)
+	s.UpdatedAt = time.Now()
+	if err := svc.store.UpdateStrain(s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// AddRating adds a rating with tasting notes to the strain with the given
+// product name and returns the updated strain.
+func (svc *StrainServiceType) AddRating(product string, r *can.Rating) (*can.Strain, error) {
+	log.Printf("💬 🤝  (pkg/service/strain.go) AddRating(product string: %v, r *can.Rating: %v)\n", product, r.ID)
+	s, err := svc.store.FindStrainByProduct(product)
+	if err != nil {
+		return nil, err
+	}
+	s.AddRating(r)
+	s.UpdatedAt = time.Now()
+	if err := svc.store.UpdateStrain(s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// ToggleFavorite pins the strain with the given product name to the top of the
+// list, or unpins it if it is a favorite, and returns the updated strain.
+func (svc *StrainServiceType) ToggleFavorite(product string) (*can.Strain, error) {
+	log.Printf("💬 🤝  (pkg/service/strain.go) ToggleFavorite(product string: %v)\n", product)
+	s, err := svc.store.FindStrainByProduct(product)
+	if err != nil {
+		return nil, err
+	}
+	s.Favorite = !s.Favorite
+	if err := svc.store.UpdateStrain(s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
 // GetStrains retrieves all strains from the store.
 func (svc *StrainServiceType) GetStrains() []*can.Strain {
 	log.Println("💬 🤝  (pkg/service/strain.go) GetStrains()")
 	return svc.store.GetStrains()
 }
 
+// ListStrains retrieves all strains from the store, sorted by the given order.
+func (svc *StrainServiceType) ListStrains(order StrainSortOrder) []*can.Strain {
+	log.Printf("💬 🤝  (pkg/service/strain.go) ListStrains(order StrainSortOrder: %v)\n", order)
+	strains := svc.store.GetStrains()
+	SortStrains(strains, order)
+	return strains
+}
+
 // FindStrainByProduct looks up a strain by its prodcut name.
 func (svc *StrainServiceType) FindStrainByProduct(p string) (*can.Strain, error) {
 	log.Printf("💬 🤝  (pkg/service/strain.go) FindStrainByProduct(p string: %v)\n", p)
 	return svc.store.FindStrainByProduct(p)
 }
+
+// FindStrainByName looks up a strain by its product name, ignoring case, like
+// the product names allowed by a prescription.
+func (svc *StrainServiceType) FindStrainByName(name string) (*can.Strain, error) {
+	log.Printf("💬 🤝  (pkg/service/strain.go) FindStrainByName(name string: %v)\n", name)
+	if s, err := svc.store.FindStrainByProduct(name); err == nil {
+		return s, nil
+	}
+	for _, s := range svc.store.GetStrains() {
+		if strings.EqualFold(s.Strain, name) {
+			return s, nil
+		}
+	}
+	return nil, storage.ErrStrainNotFound
+}
@@ -33,11 +33,13 @@ func testStrain() *can.Strain {
 		Manufacturer: "Test Manufacturer",
 		Country:      "Test Country",
 		Genetic:      can.Sativa,
-		Radiated:     false,
+		Treatment:    can.Treatment{Type: can.Untreated},
 		THC:          20.0,
 		CBD:          0.5,
-		Terpenes:     []*can.Terpene{},
+		Cannabinoids: map[can.CannabinoidType]float64{can.THCA: 21.5},
+		Terpenes:     []*can.StrainTerpene{},
 		Amount:       3.5,
+		Batches:      []*can.Batch{},
 		CreatedAt:    testTime,
 		UpdatedAt:    testTime,
 	}
@@ -48,6 +50,9 @@ type mockStrainStore struct {
 	addStrainCalls []*can.Strain
 	addStrainErr   error
 
+	updateStrainCalls []*can.Strain
+	updateStrainErr   error
+
 	getStrainsCalls  int
 	getStrainsResult []*can.Strain
 
@@ -61,6 +66,11 @@ func (m *mockStrainStore) AddStrain(s *can.Strain) error {
 	return m.addStrainErr
 }
 
+func (m *mockStrainStore) UpdateStrain(s *can.Strain) error {
+	m.updateStrainCalls = append(m.updateStrainCalls, s)
+	return m.updateStrainErr
+}
+
 func (m *mockStrainStore) GetStrains() []*can.Strain {
 	m.getStrainsCalls++
 	return m.getStrainsResult
@@ -99,6 +109,89 @@ func TestStrainService(t *testing.T) {
 		})
 	})
 
+	t.Run("AddBatch", func(t *testing.T) {
+		t.Run("Success", func(t *testing.T) {
+			strain := testStrain()
+			store := &mockStrainStore{
+				findStrainByProductResult: strain,
+			}
+			svc := NewStrainService(store)
+			batch := &can.Batch{ID: uuid.New(), Grams: 10, Remaining: 10, THC: 25}
+
+			result, err := svc.AddBatch(strain.Strain, batch)
+
+			require.NoError(t, err)
+			assert.Same(t, strain, result)
+			assert.Len(t, result.Batches, 2)
+			assert.InDelta(t, 13.5, result.Amount, 1e-9)
+			assert.Len(t, store.updateStrainCalls, 1)
+		})
+
+		t.Run("NotFound", func(t *testing.T) {
+			store := &mockStrainStore{
+				findStrainByProductErr: storage.ErrStrainNotFound,
+			}
+			svc := NewStrainService(store)
+
+			_, err := svc.AddBatch("Non-existent Strain", &can.Batch{})
+
+			assert.ErrorIs(t, err, storage.ErrStrainNotFound)
+			assert.Empty(t, store.updateStrainCalls)
+		})
+	})
+
+	t.Run("AddRating", func(t *testing.T) {
+		strain := testStrain()
+		store := &mockStrainStore{
+			findStrainByProductResult: strain,
+		}
+		svc := NewStrainService(store)
+
+		result, err := svc.AddRating(strain.Strain, &can.Rating{ID: uuid.New(), Overall: 4})
+
+		require.NoError(t, err)
+		assert.Len(t, result.Ratings, 1)
+		assert.InDelta(t, 4, result.AverageRatings().Overall, 1e-9)
+		assert.Len(t, store.updateStrainCalls, 1)
+	})
+
+	t.Run("ToggleFavorite", func(t *testing.T) {
+		strain := testStrain()
+		store := &mockStrainStore{
+			findStrainByProductResult: strain,
+		}
+		svc := NewStrainService(store)
+
+		result, err := svc.ToggleFavorite(strain.Strain)
+		require.NoError(t, err)
+		assert.True(t, result.Favorite)
+
+		result, err = svc.ToggleFavorite(strain.Strain)
+		require.NoError(t, err)
+		assert.False(t, result.Favorite)
+		assert.Len(t, store.updateStrainCalls, 2)
+
+		store.findStrainByProductErr = storage.ErrStrainNotFound
+		_, err = svc.ToggleFavorite("Non-existent Strain")
+		assert.ErrorIs(t, err, storage.ErrStrainNotFound)
+	})
+
+	t.Run("FindStrainByName", func(t *testing.T) {
+		strain := testStrain()
+		store := &mockStrainStore{
+			findStrainByProductErr: storage.ErrStrainNotFound,
+			getStrainsResult:       []*can.Strain{strain},
+		}
+		svc := NewStrainService(store)
+
+		result, err := svc.FindStrainByName("test STRAIN")
+		require.NoError(t, err)
+		assert.Same(t, strain, result)
+
+		_, err = svc.FindStrainByName("Other Strain")
+		assert.ErrorIs(t, err, storage.ErrStrainNotFound)
+	})
+
 	t.Run("GetStrains", func(t *testing.T) {
 		t.Run("Empty", func(t *testing.T) {
 			store := &mockStrainStore{}
@@ -124,6 +217,19 @@ func TestStrainService(t *testing.T) {
 		})
 	})
 
+	t.Run("ListStrains", func(t *testing.T) {
+		store := &mockStrainStore{
+			getStrainsResult: sortTestStrains(),
+		}
+		svc := NewStrainService(store)
+
+		result := svc.ListStrains(SortByTHC)
+
+		require.Len(t, result, 3)
+		assert.Equal(t, "bravo", result[0].Strain)
+		assert.Equal(t, 1, store.getStrainsCalls)
+	})
+
 	t.Run("FindStrainByProduct", func(t *testing.T) {
 		t.Run("Found", func(t *testing.T) {
 			expected := testStrain()
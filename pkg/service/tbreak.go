@@ -0,0 +1,153 @@
+package service
+
+import (
+	"errors"
+	"log"
+	"time"
+
+	can "github.com/TheDonDope/wits-tui/pkg/cannabis"
+	"github.com/TheDonDope/wits-tui/pkg/storage"
+	"github.com/google/uuid"
+)
+
+// ErrBreakOverlaps is returned when a planned tolerance break overlaps with
+// another one, including their tapering periods.
+var ErrBreakOverlaps = errors.New("Tolerance break overlaps with another planned break")
+
+// BreakPhase is the enum for the phases of a tolerance break.
+type BreakPhase int
+
+const (
+	// BreakPlanned is the phase before the tapering period
+	BreakPlanned BreakPhase = iota
+	// BreakTapering is the phase of the tapering period
+	BreakTapering
+	// BreakActive is the phase of the abstinence
+	BreakActive
+	// BreakFinished is the phase after the last day of the break
+	BreakFinished
+)
+
+// BreakProgress is the progress of a tolerance break at a point in time.
+type BreakProgress struct {
+	Break         *can.ToleranceBreak // The break
+	Phase         BreakPhase          // The phase at the time
+	Day           int                 // The current day of the break, 0 outside of it
+	DaysLeft      int                 // The days until the end of the break
+	Progress      float64             // The share of the break passed, from 0 to 1
+	Streak        int                 // The current number of abstinent days in a row
+	LongestStreak int                 // The longest number of abstinent days in a row
+	Slips         int                 // The number of days with a consumption
+	CheckedIn     bool                // Whether there is a check-in for the day
+}
+
+// BreakService provides operations on tolerance breaks.
+type BreakService interface {
+	PlanBreak(b *can.ToleranceBreak) error
+	CheckIn(id uuid.UUID, c *can.CheckIn) (*can.ToleranceBreak, error)
+	GetBreaks() []*can.ToleranceBreak
+	CurrentBreak(at time.Time) *can.ToleranceBreak
+}
+
+// BreakServiceType provides operations on tolerance breaks, accessing a store.
+type BreakServiceType struct {
+	store storage.BreakStore
+}
+
+// NewBreakService creates a new service layer for tolerance breaks.
+func NewBreakService(s storage.BreakStore) *BreakServiceType {
+	log.Println("✅ 🤝  (pkg/service/tbreak.go) NewBreakService(s storage.BreakStore)")
+	return &BreakServiceType{store: s}
+}
+
+// PlanBreak adds a tolerance break to the store, unless it overlaps with
+// another break.
+func (svc *BreakServiceType) PlanBreak(b *can.ToleranceBreak) error {
+	log.Printf("💬 🤝  (pkg/service/tbreak.go) PlanBreak(b *can.ToleranceBreak: %v)\n", b.ID)
+	for _, other := range svc.store.GetBreaks() {
+		if b.TaperStartsAt().Before(other.EndsAt()) && other.TaperStartsAt().Before(b.EndsAt()) {
+			return ErrBreakOverlaps
+		}
+	}
+	return svc.store.AddBreak(b)
+}
+
+// CheckIn records the given check-in for the tolerance break with the given
+// ID. It returns the updated break.
+func (svc *BreakServiceType) CheckIn(id uuid.UUID, c *can.CheckIn) (*can.ToleranceBreak, error) {
+	log.Printf("💬 🤝  (pkg/service/tbreak.go) CheckIn(id uuid.UUID: %v)\n", id)
+	b, err := svc.store.FindBreak(id)
+	if err != nil {
+		return nil, err
+	}
+	b.CheckIn(c)
+	if err := svc.store.UpdateBreak(b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// GetBreaks retrieves all tolerance breaks from the store, latest first.
+func (svc *BreakServiceType) GetBreaks() []*can.ToleranceBreak {
+	log.Println("💬 🤝  (pkg/service/tbreak.go) GetBreaks()")
+	return svc.store.GetBreaks()
+}
+
+// CurrentBreak returns the tolerance break which is tapering or active at the
+// given time, or else the next planned one. It returns nil if there is none.
+func (svc *BreakServiceType) CurrentBreak(at time.Time) *can.ToleranceBreak {
+	log.Println("💬 🤝  (pkg/service/tbreak.go) CurrentBreak()")
+	var next *can.ToleranceBreak
+	for _, b := range svc.store.GetBreaks() {
+		switch {
+		case b.Tapering(at) || b.Active(at):
+			return b
+		case b.TaperStartsAt().After(at):
+			// Breaks are ordered latest first, so the last one found is the next
+			next = b
+		}
+	}
+	return next
+}
+
+// TrackBreak returns the progress of the given tolerance break at the given
+// time. A day of the break is abstinent if no session was logged on it and
+// its check-in does not report a consumption. The current day only counts
+// once it is checked in or a session was logged on it.
+func TrackBreak(b *can.ToleranceBreak, sessions []*can.Session, at time.Time) BreakProgress {
+	p := BreakProgress{Break: b, DaysLeft: b.Days}
+	today := startOfDay(at)
+	switch {
+	case b.Tapering(at):
+		p.Phase = BreakTapering
+	case b.Active(at):
+		p.Phase = BreakActive
+		p.Day = b.Day(at)
+		p.DaysLeft = b.Days - p.Day + 1
+	case !at.Before(b.EndsAt()):
+		p.Phase = BreakFinished
+		p.DaysLeft = 0
+	}
+	consumed := map[time.Time]bool{}
+	for _, s := range sessions {
+		consumed[startOfDay(s.StartedAt)] = true
+	}
+	for day := startOfDay(b.StartsAt); day.Before(b.EndsAt()) && !day.After(today); day = day.AddDate(0, 0, 1) {
+		c := b.CheckInOn(day)
+		if day.Equal(today) && c == nil && !consumed[day] {
+			break
+		}
+		if consumed[day] || (c != nil && !c.Abstained) {
+			p.Slips++
+			p.Streak = 0
+			continue
+		}
+		p.Streak++
+		p.LongestStreak = max(p.LongestStreak, p.Streak)
+	}
+	if b.Days > 0 {
+		p.Progress = float64(b.Days-p.DaysLeft) / float64(b.Days)
+	}
+	p.CheckedIn = b.CheckInOn(at) != nil
+	return p
+}
@@ -0,0 +1,102 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	can "github.com/TheDonDope/wits-tui/pkg/cannabis"
+	"github.com/TheDonDope/wits-tui/pkg/storage"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testBreak returns a 7 day break starting on 2024-03-10 with 2 tapering
+// days.
+func testBreak() *can.ToleranceBreak {
+	return &can.ToleranceBreak{
+		ID:          uuid.New(),
+		StartsAt:    time.Date(2024, time.March, 10, 0, 0, 0, 0, time.UTC),
+		Days:        7,
+		TaperDays:   2,
+		BaselineTHC: 30,
+	}
+}
+
+func TestBreakService(t *testing.T) {
+	t.Setenv("STORAGE_MODE", storage.StoreInMemory)
+	svc := NewBreakService(storage.NewBreakStore())
+	b := testBreak()
+	require.NoError(t, svc.PlanBreak(b))
+
+	t.Run("PlanBreak", func(t *testing.T) {
+		overlapping := testBreak()
+		overlapping.StartsAt = b.EndsAt().AddDate(0, 0, 1) // Its tapering overlaps
+		assert.ErrorIs(t, svc.PlanBreak(overlapping), ErrBreakOverlaps)
+
+		later := testBreak()
+		later.StartsAt = b.EndsAt().AddDate(0, 1, 0)
+		require.NoError(t, svc.PlanBreak(later))
+		assert.Len(t, svc.GetBreaks(), 2)
+	})
+
+	t.Run("CurrentBreak", func(t *testing.T) {
+		assert.Equal(t, b.ID, svc.CurrentBreak(time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)).ID, "the next planned break")
+		assert.Equal(t, b.ID, svc.CurrentBreak(time.Date(2024, time.March, 8, 12, 0, 0, 0, time.UTC)).ID, "the tapering break")
+		assert.Equal(t, b.ID, svc.CurrentBreak(time.Date(2024, time.March, 16, 12, 0, 0, 0, time.UTC)).ID, "the active break")
+		assert.NotEqual(t, b.ID, svc.CurrentBreak(b.EndsAt()).ID)
+		assert.Nil(t, svc.CurrentBreak(time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)))
+	})
+
+	t.Run("CheckIn", func(t *testing.T) {
+		updated, err := svc.CheckIn(b.ID, &can.CheckIn{Day: b.StartsAt, Abstained: true, Craving: 5})
+		require.NoError(t, err)
+		assert.Len(t, updated.CheckIns, 1)
+
+		_, err = svc.CheckIn(uuid.New(), &can.CheckIn{Day: b.StartsAt})
+		assert.ErrorIs(t, err, storage.ErrBreakNotFound)
+	})
+}
+
+func TestTrackBreak(t *testing.T) {
+	b := testBreak()
+	day := func(d int) time.Time { return time.Date(2024, time.March, d, 0, 0, 0, 0, time.UTC) }
+	b.CheckIn(&can.CheckIn{Day: day(10), Abstained: true})
+	b.CheckIn(&can.CheckIn{Day: day(12), Abstained: false})
+	sessions := []*can.Session{
+		{StartedAt: day(9).Add(20 * time.Hour)}, // During the tapering
+		{StartedAt: day(14).Add(20 * time.Hour)},
+	}
+
+	t.Run("Planned", func(t *testing.T) {
+		p := TrackBreak(b, sessions, day(1))
+		assert.Equal(t, BreakPlanned, p.Phase)
+		assert.Equal(t, 7, p.DaysLeft)
+		assert.Zero(t, p.Progress)
+	})
+
+	t.Run("Tapering", func(t *testing.T) {
+		assert.Equal(t, BreakTapering, TrackBreak(b, sessions, day(9)).Phase)
+	})
+
+	t.Run("Active", func(t *testing.T) {
+		p := TrackBreak(b, sessions, day(15).Add(12*time.Hour))
+		assert.Equal(t, BreakActive, p.Phase)
+		assert.Equal(t, 6, p.Day)
+		assert.Equal(t, 2, p.DaysLeft)
+		assert.InDelta(t, 5.0/7, p.Progress, 1e-9)
+		assert.Equal(t, 2, p.Slips)
+		assert.Equal(t, 0, p.Streak, "today is not checked in yet")
+		assert.Equal(t, 2, p.LongestStreak)
+		assert.False(t, p.CheckedIn)
+	})
+
+	t.Run("Finished", func(t *testing.T) {
+		p := TrackBreak(b, sessions, day(20))
+		assert.Equal(t, BreakFinished, p.Phase)
+		assert.Zero(t, p.DaysLeft)
+		assert.InDelta(t, 1, p.Progress, 1e-9)
+		assert.Equal(t, 2, p.Streak)
+		assert.Equal(t, 2, p.LongestStreak)
+	})
+}
@@ -0,0 +1,92 @@
+package service
+
+import (
+	"math"
+	"time"
+
+	can "github.com/TheDonDope/wits-tui/pkg/cannabis"
+)
+
+const (
+	// ToleranceHalfLifeDays is the number of days after which a day of
+	// consumption only weighs half as much in the tolerance estimate.
+	ToleranceHalfLifeDays = 7
+	// LowToleranceTHC is the absorbed THC in mg per day below which the
+	// tolerance is low.
+	LowToleranceTHC = 5
+	// HighToleranceTHC is the absorbed THC in mg per day from which on the
+	// tolerance is high.
+	HighToleranceTHC = 20
+)
+
+// ToleranceLevel is the enum for the levels of an estimated tolerance.
+type ToleranceLevel int
+
+const (
+	// NoTolerance is the level without any consumption logged
+	NoTolerance ToleranceLevel = iota
+	// LowTolerance is the level of an occasional consumption
+	LowTolerance
+	// ModerateTolerance is the level of a regular consumption
+	ModerateTolerance
+	// HighTolerance is the level of a heavy daily consumption
+	HighTolerance
+)
+
+// ToleranceLevels maps the tolerance levels to their names.
+var ToleranceLevels = map[ToleranceLevel]string{
+	NoTolerance:       "none",
+	LowTolerance:      "low",
+	ModerateTolerance: "moderate",
+	HighTolerance:     "high",
+}
+
+// String returns the name of the tolerance level.
+func (l ToleranceLevel) String() string {
+	return ToleranceLevels[l]
+}
+
+// Tolerance is the estimated tolerance from the consumption of the past days.
+type Tolerance struct {
+	DailyTHC float64        // The weighted average of the absorbed THC in mg per day
+	Level    ToleranceLevel // The level of the tolerance
+	Days     int            // The number of days since the first session
+}
+
+// EstimateTolerance estimates the tolerance at the given time from the
+// absorbed THC of the given sessions started before it. The absorbed THC is
+// averaged per day, weighing recent days more: the weight of a day halves
+// every ToleranceHalfLifeDays, so that days without a session lower the
+// estimate step by step.
+func EstimateTolerance(sessions []*can.Session, c *can.DosageCalculator, at time.Time) Tolerance {
+	days := TrackDosage(sessions, c, time.Time{}, at)
+	if len(days) == 0 {
+		return Tolerance{}
+	}
+	decay := math.Pow(0.5, 1.0/ToleranceHalfLifeDays)
+	today := startOfDay(at)
+	tracked := map[time.Time]float64{}
+	for _, d := range days {
+		tracked[d.Day] = d.AbsorbedTHC
+	}
+	t := Tolerance{}
+	for day := days[0].Day; !day.After(today); day = day.AddDate(0, 0, 1) {
+		t.DailyTHC = t.DailyTHC*decay + tracked[day]*(1-decay)
+		t.Days++
+	}
+	t.Level = toleranceLevel(t.DailyTHC)
+	return t
+}
+
+// toleranceLevel returns the level of the given absorbed THC in mg per day.
+func toleranceLevel(dailyTHC float64) ToleranceLevel {
+	switch {
+	case dailyTHC <= 0:
+		return NoTolerance
+	case dailyTHC < LowToleranceTHC:
+		return LowTolerance
+	case dailyTHC < HighToleranceTHC:
+		return ModerateTolerance
+	}
+	return HighTolerance
+}
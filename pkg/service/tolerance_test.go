@@ -0,0 +1,37 @@
+package service
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	can "github.com/TheDonDope/wits-tui/pkg/cannabis"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEstimateTolerance(t *testing.T) {
+	day := time.Date(2024, time.March, 10, 0, 0, 0, 0, time.UTC)
+	c := can.NewDosageCalculator(map[can.ConsumptionMethod]float64{can.Vaporizing: 0.5})
+	decay := math.Pow(0.5, 1.0/ToleranceHalfLifeDays)
+	sessions := []*can.Session{
+		{THC: 80, Method: can.Vaporizing, StartedAt: day.Add(20 * time.Hour)},
+		{THC: 40, Method: can.Vaporizing, StartedAt: day.AddDate(0, 0, 1).Add(8 * time.Hour)},
+		{THC: 400, Method: can.Vaporizing, StartedAt: day.AddDate(0, 0, 5)}, // After the estimate
+	}
+
+	tolerance := EstimateTolerance(sessions, c, day.AddDate(0, 0, 2).Add(12*time.Hour))
+
+	want := ((40*(1-decay))*decay + 20*(1-decay)) * decay
+	assert.Equal(t, 3, tolerance.Days)
+	assert.InDelta(t, want, tolerance.DailyTHC, 1e-9)
+	assert.Equal(t, LowTolerance, tolerance.Level)
+	assert.Equal(t, Tolerance{}, EstimateTolerance(nil, c, day))
+}
+
+func TestToleranceLevel(t *testing.T) {
+	assert.Equal(t, NoTolerance, toleranceLevel(0))
+	assert.Equal(t, LowTolerance, toleranceLevel(4.9))
+	assert.Equal(t, ModerateTolerance, toleranceLevel(LowToleranceTHC))
+	assert.Equal(t, HighTolerance, toleranceLevel(HighToleranceTHC))
+	assert.Equal(t, "moderate", ModerateTolerance.String())
+}
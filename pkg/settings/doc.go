@@ -0,0 +1,2 @@
+// Package settings provides reading and writing of the user settings.
+package settings // import "github.com/TheDonDope/wits-tui/pkg/settings"
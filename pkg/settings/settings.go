@@ -0,0 +1,167 @@
+package settings
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	settingsFile = "settings.yml"
+
+	// DefaultCurrency is the currency used if none is configured.
+	DefaultCurrency = "EUR"
+	// DefaultLowGrams is the total amount in grams below which the stock is
+	// low, if none is configured.
+	DefaultLowGrams = 5.0
+	// DefaultLowDays is the number of days until the predicted run-out below
+	// which the stock is low, if none is configured.
+	DefaultLowDays = 7
+	// DefaultUsageWindowDays is the number of past days the average daily
+	// usage is computed over, if none is configured.
+	DefaultUsageWindowDays = 30
+)
+
+// Settings is the type for the user settings, persisted to a YML file within
+// the .wits folder.
+type Settings struct {
+	// Appearance contains the settings for the look of the tui.
+	Appearance Appearance `yaml:"appearance,omitempty"`
+	// Locale is the language of the tui (e.g. `de`). If empty, the language
+	// is detected from the environment.
+	Locale string `yaml:"locale,omitempty"`
+	// Currency is the ISO 4217 code of the currency prices are paid in (e.g.
+	// `EUR`).
+	Currency string `yaml:"currency,omitempty"`
+	// Strains contains the settings for the Strains appliance.
+	Strains Strains `yaml:"strains,omitempty"`
+	// Stock contains the thresholds below which the stock is running low.
+	Stock Stock `yaml:"stock,omitempty"`
+	// Dosage contains the settings for the dosage calculation.
+	Dosage Dosage `yaml:"dosage,omitempty"`
+	// Journal contains the symptoms and effects recorded with a session.
+	Journal Journal `yaml:"journal,omitempty"`
+	// Reminders contains the settings for notifying due reminders.
+	Reminders Reminders `yaml:"reminders,omitempty"`
+	// Keybindings overrides the default keys of an action, keyed by the action
+	// name (e.g. `quit: [ctrl+q]`).
+	Keybindings map[string][]string `yaml:"keybindings,omitempty"`
+}
+
+// Appearance contains the settings for the look of the tui.
+type Appearance struct {
+	// Theme is the name of the color theme, either a built-in or a custom one
+	// from the themes folder in the WITS_DIR.
+	Theme string `yaml:"theme,omitempty"`
+}
+
+// Strains contains the settings for the Strains appliance.
+type Strains struct {
+	// SortOrder is the order in which strains are listed (one of: `name`,
+	// `thc`, `cbd`, `amount`, `created`, `updated`, `rating`).
+	SortOrder string `yaml:"sort_order,omitempty"`
+	// Columns are the columns of the strains table, in the order they are
+	// shown (any of: `product`, `cultivar`, `manufacturer`, `country`,
+	// `genetic`, `radiated`, `treatment`, `thc`, `cbd`, `amount`, `terpenes`,
+	// `cost_per_gram`, `cost_per_mg_thc`, `rating`, `updated`).
+	Columns []string `yaml:"columns,omitempty"`
+}
+
+// Stock contains the thresholds below which the stock is running low.
+type Stock struct {
+	// LowGrams is the total amount in grams below which the stock is low.
+	LowGrams float64 `yaml:"low_grams,omitempty"`
+	// LowDays is the number of days until the predicted run-out below which
+	// the stock is low.
+	LowDays int `yaml:"low_days,omitempty"`
+	// UsageWindowDays is the number of past days the average daily usage is
+	// computed over.
+	UsageWindowDays int `yaml:"usage_window_days,omitempty"`
+}
+
+// Dosage contains the settings for the dosage calculation.
+type Dosage struct {
+	// Bioavailability overrides the share of the consumed cannabinoids
+	// reaching the bloodstream, keyed by the consumption method (one of:
+	// `vaporizing`, `smoking`, `oral`), as a factor between 0 and 1.
+	Bioavailability map[string]float64 `yaml:"bioavailability,omitempty"`
+}
+
+// Journal contains the symptoms and effects recorded with a session.
+type Journal struct {
+	// Symptoms are the symptoms rated before and after a session, by their
+	// severity from 0 (none) to 10 (worst).
+	Symptoms []string `yaml:"symptoms,omitempty"`
+	// Effects are the perceived effects which can be recorded.
+	Effects []string `yaml:"effects,omitempty"`
+	// SideEffects are the perceived side effects which can be recorded.
+	SideEffects []string `yaml:"side_effects,omitempty"`
+}
+
+// Reminders contains the settings for notifying due reminders.
+type Reminders struct {
+	// Silent disables the terminal bell rung for a due reminder.
+	Silent bool `yaml:"silent,omitempty"`
+	// Command is the command hook run for a due reminder, with its title and
+	// message appended to the arguments (e.g. `[notify-send, -a, wits]`).
+	Command []string `yaml:"command,omitempty"`
+}
+
+// Default returns the default settings.
+func Default() *Settings {
+	return &Settings{
+		Currency: DefaultCurrency,
+		Stock: Stock{
+			LowGrams:        DefaultLowGrams,
+			LowDays:         DefaultLowDays,
+			UsageWindowDays: DefaultUsageWindowDays,
+		},
+		Journal: Journal{
+			Symptoms:    []string{"pain", "sleep", "anxiety", "appetite", "nausea"},
+			Effects:     []string{"relaxed", "calm", "happy", "euphoric", "focused", "creative", "sleepy"},
+			SideEffects: []string{"dry mouth", "dry eyes", "dizziness", "headache", "paranoia", "racing heart"},
+		},
+		Keybindings: map[string][]string{},
+	}
+}
+
+// Load reads the settings from the settings file in the WITS_DIR. If the file
+// does not exist, the default settings are returned.
+func Load() (*Settings, error) {
+	log.Println("💬 🔧  (pkg/settings/settings.go) Load()")
+	s := Default()
+	data, err := os.ReadFile(path())
+	if err != nil {
+		if os.IsNotExist(err) {
+			log.Println("ℹ️  🔧  (pkg/settings/settings.go) 🗒️  Settings file not existing. Returning default settings.")
+			return s, nil
+		}
+		log.Printf("🚨 🔧  (pkg/settings/settings.go) 🗒️  Failed to read settings with error: %v \n", err)
+		return nil, err
+	}
+	if err := yaml.Unmarshal(data, s); err != nil {
+		log.Printf("🚨 🔧  (pkg/settings/settings.go) 🗒️  Failed to unmarshal settings with error: %v \n", err)
+		return nil, err
+	}
+	log.Println("✅ 🔧  (pkg/settings/settings.go) Load()")
+	return s, nil
+}
+
+// Save writes the settings to the settings file in the WITS_DIR.
+func (s *Settings) Save() error {
+	log.Println("💬 🔧  (pkg/settings/settings.go) Save()")
+	data, err := yaml.Marshal(s)
+	if err != nil {
+		log.Printf("🚨 🔧  (pkg/settings/settings.go) 🗒️  Failed to marshal settings with error: %v \n", err)
+		return err
+	}
+	log.Println("✅ 🔧  (pkg/settings/settings.go) Save()")
+	return os.WriteFile(path(), data, 0644)
+}
+
+// path returns the path to the settings file.
+func path() string {
+	return fmt.Sprintf("%s/%s", os.Getenv("WITS_DIR"), settingsFile)
+}
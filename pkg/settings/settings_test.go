@@ -0,0 +1,51 @@
+package settings
+
+import (
+	"io"
+	"log"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestMain handles global test setup
+func TestMain(m *testing.M) {
+	// Disable log output during tests
+	log.SetOutput(io.Discard)
+	os.Exit(m.Run())
+}
+
+func TestSettings(t *testing.T) {
+	t.Run("LoadMissingFile", func(t *testing.T) {
+		t.Setenv("WITS_DIR", t.TempDir())
+
+		s, err := Load()
+
+		require.NoError(t, err)
+		assert.Equal(t, Default(), s)
+	})
+
+	t.Run("LoadInvalidFile", func(t *testing.T) {
+		tempDir := t.TempDir()
+		t.Setenv("WITS_DIR", tempDir)
+		require.NoError(t, os.WriteFile(tempDir+"/"+settingsFile, []byte("keybindings: ["), 0644))
+
+		_, err := Load()
+
+		assert.Error(t, err)
+	})
+
+	t.Run("Persistence", func(t *testing.T) {
+		t.Setenv("WITS_DIR", t.TempDir())
+		s := Default()
+		s.Keybindings["quit"] = []string{"ctrl+q"}
+
+		require.NoError(t, s.Save())
+		loaded, err := Load()
+
+		require.NoError(t, err)
+		assert.Equal(t, s, loaded)
+	})
+}
@@ -0,0 +1,222 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	can "github.com/TheDonDope/wits-tui/pkg/cannabis"
+	"gopkg.in/yaml.v3"
+)
+
+const cultivarsFile = "cultivars.yml"
+
+var (
+	// ErrCultivarNotFound is returned when a cultivar is not found in the store.
+	ErrCultivarNotFound = errors.New("Cultivar with that name not found")
+	// ErrCultivarAlreadyExists is returned when a cultivar with the same name already exists in the store.
+	ErrCultivarAlreadyExists = errors.New("Cultivar with that name already exists")
+)
+
+// CultivarStore is an interface for storing cultivars. Cultivars are
+// identified by their name, ignoring case.
+type CultivarStore interface {
+	AddCultivar(c *can.Cultivar) error
+	UpdateCultivar(c *can.Cultivar) error
+	GetCultivars() []*can.Cultivar
+	FindCultivar(name string) (*can.Cultivar, error)
+}
+
+// cultivarKey returns the key of the cultivar with the given name.
+func cultivarKey(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// CultivarStoreInMemory is the in memory implementation of the CultivarStore
+// interface.
+type CultivarStoreInMemory struct {
+	mu        sync.Mutex
+	cultivars map[string]*can.Cultivar
+}
+
+// AddCultivar adds a cultivar to the store, using its name as the key.
+func (csim *CultivarStoreInMemory) AddCultivar(c *can.Cultivar) error {
+	log.Printf("💬 💾  (pkg/storage/cultivar_store.go) AddCultivar(c *can.Cultivar: %v) \n", c.ID)
+	csim.mu.Lock()
+	defer csim.mu.Unlock()
+
+	if _, exists := csim.cultivars[cultivarKey(c.Name)]; exists {
+		log.Printf("🚨 💾  (pkg/storage/cultivar_store.go) 🗒️  Failed to add already existing cultivar: %v \n", c.ID)
+		return ErrCultivarAlreadyExists
+	}
+	csim.cultivars[cultivarKey(c.Name)] = c
+	log.Printf("✅ 💾  (pkg/storage/cultivar_store.go) AddCultivar() -> len(csim.cultivars): %v \n", len(csim.cultivars))
+	return nil
+}
+
+// UpdateCultivar replaces the cultivar with the same name in the store.
+func (csim *CultivarStoreInMemory) UpdateCultivar(c *can.Cultivar) error {
+	log.Printf("💬 💾  (pkg/storage/cultivar_store.go) UpdateCultivar(c *can.Cultivar: %v) \n", c.ID)
+	csim.mu.Lock()
+	defer csim.mu.Unlock()
+
+	if _, exists := csim.cultivars[cultivarKey(c.Name)]; !exists {
+		log.Printf("🚨 💾  (pkg/storage/cultivar_store.go) 🗒️  Failed to update non existing cultivar: %v \n", c.ID)
+		return ErrCultivarNotFound
+	}
+	csim.cultivars[cultivarKey(c.Name)] = c
+	log.Println("✅ 💾  (pkg/storage/cultivar_store.go) UpdateCultivar()")
+	return nil
+}
+
+// GetCultivars returns all cultivars in the store as a slice.
+func (csim *CultivarStoreInMemory) GetCultivars() []*can.Cultivar {
+	log.Println("💬 💾  (pkg/storage/cultivar_store.go) GetCultivars()")
+	csim.mu.Lock()
+	defer csim.mu.Unlock()
+
+	var cultivars []*can.Cultivar
+	for _, c := range csim.cultivars {
+		cultivars = append(cultivars, c)
+	}
+	sortCultivarsByName(cultivars)
+	log.Printf("✅ 💾  (pkg/storage/cultivar_store.go) GetCultivars() -> len(cultivars): %v \n", len(cultivars))
+	return cultivars
+}
+
+// FindCultivar finds a cultivar in the store by its name, ignoring case.
+func (csim *CultivarStoreInMemory) FindCultivar(name string) (*can.Cultivar, error) {
+	log.Printf("💬 💾  (pkg/storage/cultivar_store.go) FindCultivar(name string: %v) \n", name)
+	csim.mu.Lock()
+	defer csim.mu.Unlock()
+
+	c, exists := csim.cultivars[cultivarKey(name)]
+	if !exists {
+		log.Printf("🚨 💾  (pkg/storage/cultivar_store.go) 🗒️  Cultivar with name %v does not exist. \n", name)
+		return nil, ErrCultivarNotFound
+	}
+	log.Printf("✅ 💾  (pkg/storage/cultivar_store.go) FindCultivar() -> cultivar: %v (%v) \n", c.Name, c.ID)
+	return c, nil
+}
+
+// CultivarStoreYMLFile is the yaml file storage implementation of the
+// CultivarStore interface.
+type CultivarStoreYMLFile struct {
+	mu        sync.Mutex
+	cultivars map[string]*can.Cultivar
+}
+
+// AddCultivar adds a cultivar to the store, using its name as the key.
+func (csyf *CultivarStoreYMLFile) AddCultivar(c *can.Cultivar) error {
+	log.Printf("💬 💾  (pkg/storage/cultivar_store.go) AddCultivar(c *can.Cultivar: %v) \n", c.ID)
+	csyf.mu.Lock()
+	defer csyf.mu.Unlock()
+
+	if _, exists := csyf.cultivars[cultivarKey(c.Name)]; exists {
+		log.Printf("🚨 💾  (pkg/storage/cultivar_store.go) 🗒️  Failed to add already existing cultivar: %v \n", c.ID)
+		return ErrCultivarAlreadyExists
+	}
+	csyf.cultivars[cultivarKey(c.Name)] = c
+	log.Println("✅ 💾  (pkg/storage/cultivar_store.go) AddCultivar()")
+	return csyf.persist()
+}
+
+// UpdateCultivar replaces the cultivar with the same name in the store.
+func (csyf *CultivarStoreYMLFile) UpdateCultivar(c *can.Cultivar) error {
+	log.Printf("💬 💾  (pkg/storage/cultivar_store.go) UpdateCultivar(c *can.Cultivar: %v) \n", c.ID)
+	csyf.mu.Lock()
+	defer csyf.mu.Unlock()
+
+	if _, exists := csyf.cultivars[cultivarKey(c.Name)]; !exists {
+		log.Printf("🚨 💾  (pkg/storage/cultivar_store.go) 🗒️  Failed to update non existing cultivar: %v \n", c.ID)
+		return ErrCultivarNotFound
+	}
+	csyf.cultivars[cultivarKey(c.Name)] = c
+	log.Println("✅ 💾  (pkg/storage/cultivar_store.go) UpdateCultivar()")
+	return csyf.persist()
+}
+
+// persist writes all cultivars to the cultivars file. The caller must hold the
+// lock.
+func (csyf *CultivarStoreYMLFile) persist() error {
+	data, err := yaml.Marshal(csyf.cultivars)
+	if err != nil {
+		log.Printf("🚨 💾  (pkg/storage/cultivar_store.go) 🗒️  Failed to marshal cultivar with error: %v \n", err)
+		return err
+	}
+	return os.WriteFile(fmt.Sprintf("%s/%s", os.Getenv("WITS_DIR"), cultivarsFile), data, 0644)
+}
+
+// GetCultivars returns all cultivars in the store as a slice.
+func (csyf *CultivarStoreYMLFile) GetCultivars() []*can.Cultivar {
+	log.Println("💬 💾  (pkg/storage/cultivar_store.go) GetCultivars()")
+	csyf.mu.Lock()
+	defer csyf.mu.Unlock()
+
+	var cultivars []*can.Cultivar
+	for _, c := range csyf.cultivars {
+		cultivars = append(cultivars, c)
+	}
+	sortCultivarsByName(cultivars)
+	log.Printf("✅ 💾  (pkg/storage/cultivar_store.go) GetCultivars() -> len(cultivars): %v \n", len(cultivars))
+	return cultivars
+}
+
+// FindCultivar finds a cultivar in the store by its name, ignoring case.
+func (csyf *CultivarStoreYMLFile) FindCultivar(name string) (*can.Cultivar, error) {
+	log.Printf("💬 💾  (pkg/storage/cultivar_store.go) FindCultivar(name string: %v) \n", name)
+	csyf.mu.Lock()
+	defer csyf.mu.Unlock()
+
+	c, exists := csyf.cultivars[cultivarKey(name)]
+	if !exists {
+		log.Printf("🚨 💾  (pkg/storage/cultivar_store.go) 🗒️  Cultivar with name %v does not exist. \n", name)
+		return nil, ErrCultivarNotFound
+	}
+	log.Printf("✅ 💾  (pkg/storage/cultivar_store.go) FindCultivar() -> cultivar: %v (%v) \n", c.Name, c.ID)
+	return c, nil
+}
+
+// sortCultivarsByName sorts the given cultivars by their name, so that stores
+// always return cultivars in a stable order.
+func sortCultivarsByName(cultivars []*can.Cultivar) {
+	sort.Slice(cultivars, func(i, j int) bool {
+		return cultivarKey(cultivars[i].Name) < cultivarKey(cultivars[j].Name)
+	})
+}
+
+// NewCultivarStore returns a new CultivarStore implementation depending on the
+// configured storage mode in the environment variable.
+func NewCultivarStore() CultivarStore {
+	storageMode := os.Getenv("STORAGE_MODE")
+	log.Printf("💬 💾  (pkg/storage/cultivar_store.go) NewCultivarStore() -> storageMode: %v \n", storageMode)
+	switch storageMode {
+	case StoreInMemory:
+		return &CultivarStoreInMemory{
+			cultivars: make(map[string]*can.Cultivar),
+		}
+	case StoreYMLFile:
+		csyf := &CultivarStoreYMLFile{
+			cultivars: make(map[string]*can.Cultivar),
+		}
+		data, err := os.ReadFile(fmt.Sprintf("%s/%s", os.Getenv("WITS_DIR"), cultivarsFile))
+		if err != nil {
+			if os.IsNotExist(err) {
+				log.Println("ℹ️  💾  (pkg/storage/cultivar_store.go) 🗒️  Cultivar file not existing. Returning new empty store.")
+				return csyf
+			}
+		}
+		err = yaml.Unmarshal(data, csyf.cultivars)
+		if err != nil {
+			log.Printf("🚨 💾  (pkg/storage/cultivar_store.go) 🗒️  Failed unmarshal cultivar data with error: %v. Returning new empty store. \n", err)
+			return csyf
+		}
+		log.Printf("✅ 💾  (pkg/storage/cultivar_store.go) NewCultivarStore() -> len(cultivars): %v \n", len(csyf.cultivars))
+		return csyf
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	can "github.com/TheDonDope/wits-tui/pkg/cannabis"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testCultivar generates a consistent test cultivar with fixed values
+func testCultivar() *can.Cultivar {
+	testUUID := uuid.MustParse("7ea7b810-9dad-11d1-80b4-00c04fd430c8")
+	testTime := time.Date(2023, time.October, 5, 0, 0, 0, 0, time.UTC)
+
+	return &can.Cultivar{
+		ID:        testUUID,
+		Name:      "Pink Kush",
+		Indica:    80,
+		Sativa:    20,
+		Parents:   []string{"OG Kush"},
+		CreatedAt: testTime,
+		UpdatedAt: testTime,
+	}
+}
+
+// TestCultivarStores runs all tests for both cultivar store implementations
+func TestCultivarStores(t *testing.T) {
+	stores := map[string]func(t *testing.T) CultivarStore{
+		"InMemory": func(t *testing.T) CultivarStore {
+			t.Setenv("STORAGE_MODE", StoreInMemory)
+			return NewCultivarStore()
+		},
+		"YMLFile": func(t *testing.T) CultivarStore {
+			t.Setenv("STORAGE_MODE", StoreYMLFile)
+			t.Setenv("WITS_DIR", t.TempDir())
+			return NewCultivarStore()
+		},
+	}
+
+	for name, newStore := range stores {
+		t.Run(name, func(t *testing.T) {
+			t.Run("AddCultivar", func(t *testing.T) {
+				store := newStore(t)
+				c := testCultivar()
+
+				require.NoError(t, store.AddCultivar(c))
+				duplicate := testCultivar()
+				duplicate.Name = "pink kush"
+				assert.ErrorIs(t, store.AddCultivar(duplicate), ErrCultivarAlreadyExists)
+				assert.Len(t, store.GetCultivars(), 1)
+			})
+
+			t.Run("GetCultivars", func(t *testing.T) {
+				store := newStore(t)
+				assert.Empty(t, store.GetCultivars())
+
+				og := testCultivar()
+				og.ID = uuid.New()
+				og.Name = "OG Kush"
+				require.NoError(t, store.AddCultivar(testCultivar()))
+				require.NoError(t, store.AddCultivar(og))
+
+				cultivars := store.GetCultivars()
+				require.Len(t, cultivars, 2)
+				assert.Equal(t, "OG Kush", cultivars[0].Name)
+			})
+
+			t.Run("FindAndUpdateCultivar", func(t *testing.T) {
+				store := newStore(t)
+				c := testCultivar()
+
+				_, err := store.FindCultivar(c.Name)
+				assert.ErrorIs(t, err, ErrCultivarNotFound)
+				assert.ErrorIs(t, store.UpdateCultivar(c), ErrCultivarNotFound)
+
+				require.NoError(t, store.AddCultivar(c))
+				updated := testCultivar()
+				updated.Breeder = "Test Seeds"
+				require.NoError(t, store.UpdateCultivar(updated))
+
+				found, err := store.FindCultivar("PINK KUSH")
+				require.NoError(t, err)
+				assert.Equal(t, "Test Seeds", found.Breeder)
+			})
+		})
+	}
+
+	t.Run("Persistence", func(t *testing.T) {
+		t.Setenv("STORAGE_MODE", StoreYMLFile)
+		t.Setenv("WITS_DIR", t.TempDir())
+		c := testCultivar()
+		require.NoError(t, NewCultivarStore().AddCultivar(c))
+
+		persisted, err := NewCultivarStore().FindCultivar(c.Name)
+
+		require.NoError(t, err)
+		assert.Equal(t, c, persisted)
+	})
+}
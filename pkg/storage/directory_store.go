@@ -0,0 +1,175 @@
+package storage
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+	"gopkg.in/yaml.v3"
+)
+
+// directory describes the entries of a directory of names and aliases, such
+// as the manufacturers or the pharmacies.
+type directory[E any] struct {
+	kind        string               // The kind of the entries, used in log lines
+	file        string               // The file within the WITS_DIR, empty if the entries are only kept in memory
+	id          func(E) uuid.UUID    // The ID of an entry
+	name        func(E) string       // The name of an entry
+	matches     func(E, string) bool // Whether an entry is known by a name or alias
+	errNotFound error                // The error returned for unknown entries
+	errExists   error                // The error returned when adding an entry twice
+}
+
+// directoryStore is the implementation shared by the stores of the
+// directories, keeping the entries by their ID in memory and, if the directory
+// has a file, persisting them to it.
+type directoryStore[E any] struct {
+	directory[E]
+	mu      sync.Mutex
+	entries map[uuid.UUID]E
+}
+
+// add adds an entry to the store, using its ID as the key.
+func (ds *directoryStore[E]) add(e E) error {
+	log.Printf("💬 💾  (pkg/storage/directory_store.go) add(%v: %v) \n", ds.kind, ds.id(e))
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+
+	if _, exists := ds.entries[ds.id(e)]; exists {
+		log.Printf("🚨 💾  (pkg/storage/directory_store.go) 🗒️  Failed to add already existing %v: %v \n", ds.kind, ds.id(e))
+		return ds.errExists
+	}
+	ds.entries[ds.id(e)] = e
+	log.Printf("✅ 💾  (pkg/storage/directory_store.go) add() -> len(%v): %v \n", ds.kind, len(ds.entries))
+	return ds.persist()
+}
+
+// update replaces the entry with the same ID in the store.
+func (ds *directoryStore[E]) update(e E) error {
+	log.Printf("💬 💾  (pkg/storage/directory_store.go) update(%v: %v) \n", ds.kind, ds.id(e))
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+
+	if _, exists := ds.entries[ds.id(e)]; !exists {
+		log.Printf("🚨 💾  (pkg/storage/directory_store.go) 🗒️  Failed to update non existing %v: %v \n", ds.kind, ds.id(e))
+		return ds.errNotFound
+	}
+	ds.entries[ds.id(e)] = e
+	log.Println("✅ 💾  (pkg/storage/directory_store.go) update()")
+	return ds.persist()
+}
+
+// remove removes the entry with the given ID from the store.
+func (ds *directoryStore[E]) remove(id uuid.UUID) error {
+	log.Printf("💬 💾  (pkg/storage/directory_store.go) remove(%v: %v) \n", ds.kind, id)
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+
+	if _, exists := ds.entries[id]; !exists {
+		log.Printf("🚨 💾  (pkg/storage/directory_store.go) 🗒️  Failed to remove non existing %v: %v \n", ds.kind, id)
+		return ds.errNotFound
+	}
+	delete(ds.entries, id)
+	log.Println("✅ 💾  (pkg/storage/directory_store.go) remove()")
+	return ds.persist()
+}
+
+// all returns all entries in the store.
+func (ds *directoryStore[E]) all() []E {
+	log.Printf("💬 💾  (pkg/storage/directory_store.go) all(%v) \n", ds.kind)
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+
+	entries := ds.sorted()
+	log.Printf("✅ 💾  (pkg/storage/directory_store.go) all() -> len(%v): %v \n", ds.kind, len(entries))
+	return entries
+}
+
+// find finds an entry in the store by its name or one of its aliases, ignoring
+// case. Names take precedence over aliases.
+func (ds *directoryStore[E]) find(name string) (E, error) {
+	log.Printf("💬 💾  (pkg/storage/directory_store.go) find(%v: %v) \n", ds.kind, name)
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+
+	var alias *E
+	for _, e := range ds.sorted() {
+		if strings.EqualFold(ds.name(e), strings.TrimSpace(name)) {
+			log.Printf("✅ 💾  (pkg/storage/directory_store.go) find() -> %v: %v (%v) \n", ds.kind, ds.name(e), ds.id(e))
+			return e, nil
+		}
+		if alias == nil && ds.matches(e, name) {
+			alias = &e
+		}
+	}
+	if alias == nil {
+		log.Printf("🚨 💾  (pkg/storage/directory_store.go) 🗒️  %v with name %v does not exist. \n", ds.kind, name)
+		var none E
+		return none, ds.errNotFound
+	}
+	log.Printf("✅ 💾  (pkg/storage/directory_store.go) find() -> %v: %v (%v) \n", ds.kind, ds.name(*alias), ds.id(*alias))
+	return *alias, nil
+}
+
+// sorted returns the entries sorted by their name, so that stores always
+// return them in a stable order. The caller must hold the lock.
+func (ds *directoryStore[E]) sorted() []E {
+	var entries []E
+	for _, e := range ds.entries {
+		entries = append(entries, e)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return strings.ToLower(ds.name(entries[i])) < strings.ToLower(ds.name(entries[j]))
+	})
+	return entries
+}
+
+// persist writes all entries to the file of the directory, if it has one. The
+// caller must hold the lock.
+func (ds *directoryStore[E]) persist() error {
+	if ds.file == "" {
+		return nil
+	}
+	data, err := yaml.Marshal(ds.entries)
+	if err != nil {
+		log.Printf("🚨 💾  (pkg/storage/directory_store.go) 🗒️  Failed to marshal %v with error: %v \n", ds.kind, err)
+		return err
+	}
+	return os.WriteFile(fmt.Sprintf("%s/%s", os.Getenv("WITS_DIR"), ds.file), data, 0644)
+}
+
+// newDirectoryStore returns a new store of the given directory depending on
+// the configured storage mode in the environment variable, or nil if no
+// storage mode is configured.
+func newDirectoryStore[E any](d directory[E]) *directoryStore[E] {
+	storageMode := os.Getenv("STORAGE_MODE")
+	log.Printf("💬 💾  (pkg/storage/directory_store.go) newDirectoryStore(%v) -> storageMode: %v \n", d.kind, storageMode)
+	ds := &directoryStore[E]{entries: make(map[uuid.UUID]E)}
+	switch storageMode {
+	case StoreInMemory:
+		d.file = ""
+		ds.directory = d
+		return ds
+	case StoreYMLFile:
+		ds.directory = d
+		data, err := os.ReadFile(fmt.Sprintf("%s/%s", os.Getenv("WITS_DIR"), d.file))
+		if err != nil {
+			if os.IsNotExist(err) {
+				log.Printf("ℹ️  💾  (pkg/storage/directory_store.go) 🗒️  %v file not existing. Returning new empty store. \n", d.kind)
+				return ds
+			}
+		}
+		err = yaml.Unmarshal(data, ds.entries)
+		if err != nil {
+			log.Printf("🚨 💾  (pkg/storage/directory_store.go) 🗒️  Failed unmarshal %v data with error: %v. Returning new empty store. \n", d.kind, err)
+			return ds
+		}
+		log.Printf("✅ 💾  (pkg/storage/directory_store.go) newDirectoryStore() -> len(%v): %v \n", d.kind, len(ds.entries))
+		return ds
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package storage
+
+import (
+	"testing"
+
+	can "github.com/TheDonDope/wits-tui/pkg/cannabis"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestDirectoryStores runs all tests for the directory store in both storage
+// modes, using the directory of the manufacturers.
+func TestDirectoryStores(t *testing.T) {
+	stores := map[string]func(t *testing.T) *directoryStore[*can.Manufacturer]{
+		"InMemory": func(t *testing.T) *directoryStore[*can.Manufacturer] {
+			t.Setenv("STORAGE_MODE", StoreInMemory)
+			return newDirectoryStore(manufacturers)
+		},
+		"YMLFile": func(t *testing.T) *directoryStore[*can.Manufacturer] {
+			t.Setenv("STORAGE_MODE", StoreYMLFile)
+			t.Setenv("WITS_DIR", t.TempDir())
+			return newDirectoryStore(manufacturers)
+		},
+	}
+
+	for name, newStore := range stores {
+		t.Run(name, func(t *testing.T) {
+			t.Run("Add", func(t *testing.T) {
+				store := newStore(t)
+
+				require.NoError(t, store.add(testManufacturer()))
+				assert.ErrorIs(t, store.add(testManufacturer()), ErrManufacturerAlreadyExists)
+				assert.Len(t, store.all(), 1)
+			})
+
+			t.Run("Find", func(t *testing.T) {
+				store := newStore(t)
+				require.NoError(t, store.add(testManufacturer()))
+				// A name takes precedence over the alias of another entry
+				other := &can.Manufacturer{ID: uuid.New(), Name: "Aurora Cannabis"}
+				require.NoError(t, store.add(other))
+
+				found, err := store.find(" aurora ")
+				require.NoError(t, err)
+				assert.Equal(t, "Aurora", found.Name)
+				found, err = store.find("AURORA CANNABIS")
+				require.NoError(t, err)
+				assert.Equal(t, other.ID, found.ID)
+				_, err = store.find("Bedrocan")
+				assert.ErrorIs(t, err, ErrManufacturerNotFound)
+			})
+
+			t.Run("UpdateAndRemove", func(t *testing.T) {
+				store := newStore(t)
+				m := testManufacturer()
+				assert.ErrorIs(t, store.update(m), ErrManufacturerNotFound)
+				assert.ErrorIs(t, store.remove(m.ID), ErrManufacturerNotFound)
+
+				require.NoError(t, store.add(m))
+				updated := testManufacturer()
+				updated.Website = "https://example.com"
+				require.NoError(t, store.update(updated))
+				found, err := store.find("Aurora Cannabis")
+				require.NoError(t, err)
+				assert.Equal(t, "https://example.com", found.Website)
+
+				require.NoError(t, store.remove(m.ID))
+				assert.Empty(t, store.all())
+			})
+		})
+	}
+
+	t.Run("NoStorageMode", func(t *testing.T) {
+		t.Setenv("STORAGE_MODE", "")
+		assert.Nil(t, newDirectoryStore(manufacturers))
+	})
+}
@@ -0,0 +1,80 @@
+package storage
+
+import (
+	"errors"
+
+	can "github.com/TheDonDope/wits-tui/pkg/cannabis"
+	"github.com/google/uuid"
+)
+
+const manufacturersFile = "manufacturers.yml"
+
+var (
+	// ErrManufacturerNotFound is returned when a manufacturer is not found in the store.
+	ErrManufacturerNotFound = errors.New("Manufacturer with that ID or name not found")
+	// ErrManufacturerAlreadyExists is returned when a manufacturer with the same ID already exists in the store.
+	ErrManufacturerAlreadyExists = errors.New("Manufacturer with that ID already exists")
+)
+
+// ManufacturerStore is an interface for storing manufacturers.
+type ManufacturerStore interface {
+	AddManufacturer(m *can.Manufacturer) error
+	UpdateManufacturer(m *can.Manufacturer) error
+	RemoveManufacturer(id uuid.UUID) error
+	GetManufacturers() []*can.Manufacturer
+	FindManufacturer(name string) (*can.Manufacturer, error)
+}
+
+// manufacturers is the directory of the manufacturers.
+var manufacturers = directory[*can.Manufacturer]{
+	kind:        "manufacturer",
+	file:        manufacturersFile,
+	id:          func(m *can.Manufacturer) uuid.UUID { return m.ID },
+	name:        func(m *can.Manufacturer) string { return m.Name },
+	matches:     (*can.Manufacturer).Matches,
+	errNotFound: ErrManufacturerNotFound,
+	errExists:   ErrManufacturerAlreadyExists,
+}
+
+// ManufacturerDirectoryStore is the implementation of the ManufacturerStore
+// interface, in memory or in a yaml file depending on the storage mode.
+type ManufacturerDirectoryStore struct {
+	store *directoryStore[*can.Manufacturer]
+}
+
+// AddManufacturer adds a manufacturer to the store, using its ID as the key.
+func (mds *ManufacturerDirectoryStore) AddManufacturer(m *can.Manufacturer) error {
+	return mds.store.add(m)
+}
+
+// UpdateManufacturer replaces the manufacturer with the same ID in the store.
+func (mds *ManufacturerDirectoryStore) UpdateManufacturer(m *can.Manufacturer) error {
+	return mds.store.update(m)
+}
+
+// RemoveManufacturer removes the manufacturer with the given ID from the store.
+func (mds *ManufacturerDirectoryStore) RemoveManufacturer(id uuid.UUID) error {
+	return mds.store.remove(id)
+}
+
+// GetManufacturers returns all manufacturers in the store, sorted by their
+// name.
+func (mds *ManufacturerDirectoryStore) GetManufacturers() []*can.Manufacturer {
+	return mds.store.all()
+}
+
+// FindManufacturer finds a manufacturer in the store by its name or one of its
+// aliases, ignoring case.
+func (mds *ManufacturerDirectoryStore) FindManufacturer(name string) (*can.Manufacturer, error) {
+	return mds.store.find(name)
+}
+
+// NewManufacturerStore returns a new ManufacturerStore implementation depending
+// on the configured storage mode in the environment variable.
+func NewManufacturerStore() ManufacturerStore {
+	store := newDirectoryStore(manufacturers)
+	if store == nil {
+		return nil
+	}
+	return &ManufacturerDirectoryStore{store: store}
+}
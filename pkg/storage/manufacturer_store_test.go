@@ -0,0 +1,44 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	can "github.com/TheDonDope/wits-tui/pkg/cannabis"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testManufacturer generates a consistent test manufacturer with fixed values
+func testManufacturer() *can.Manufacturer {
+	testUUID := uuid.MustParse("8fa7b810-9dad-11d1-80b4-00c04fd430c8")
+	testTime := time.Date(2023, time.October, 5, 0, 0, 0, 0, time.UTC)
+
+	return &can.Manufacturer{
+		ID:        testUUID,
+		Name:      "Aurora",
+		Aliases:   []string{"Aurora Cannabis"},
+		Country:   "CA",
+		CreatedAt: testTime,
+		UpdatedAt: testTime,
+	}
+}
+
+// TestManufacturerStore tests the manufacturer store, whose behavior is shared with the
+// other directories and tested in TestDirectoryStores.
+func TestManufacturerStore(t *testing.T) {
+	t.Setenv("STORAGE_MODE", StoreYMLFile)
+	t.Setenv("WITS_DIR", t.TempDir())
+	m := testManufacturer()
+	require.NoError(t, NewManufacturerStore().AddManufacturer(m))
+	store := NewManufacturerStore()
+
+	persisted, err := store.FindManufacturer("AURORA CANNABIS")
+	require.NoError(t, err)
+	assert.Equal(t, m, persisted)
+	assert.Equal(t, []*can.Manufacturer{m}, store.GetManufacturers())
+	assert.ErrorIs(t, store.AddManufacturer(m), ErrManufacturerAlreadyExists)
+	_, err = store.FindManufacturer("Bedrocan")
+	assert.ErrorIs(t, err, ErrManufacturerNotFound)
+}
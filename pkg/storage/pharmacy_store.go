@@ -0,0 +1,79 @@
+package storage
+
+import (
+	"errors"
+
+	can "github.com/TheDonDope/wits-tui/pkg/cannabis"
+	"github.com/google/uuid"
+)
+
+const pharmaciesFile = "pharmacies.yml"
+
+var (
+	// ErrPharmacyNotFound is returned when a pharmacy is not found in the store.
+	ErrPharmacyNotFound = errors.New("Pharmacy with that ID or name not found")
+	// ErrPharmacyAlreadyExists is returned when a pharmacy with the same ID already exists in the store.
+	ErrPharmacyAlreadyExists = errors.New("Pharmacy with that ID already exists")
+)
+
+// PharmacyStore is an interface for storing pharmacies.
+type PharmacyStore interface {
+	AddPharmacy(p *can.Pharmacy) error
+	UpdatePharmacy(p *can.Pharmacy) error
+	RemovePharmacy(id uuid.UUID) error
+	GetPharmacies() []*can.Pharmacy
+	FindPharmacy(name string) (*can.Pharmacy, error)
+}
+
+// pharmacies is the directory of the pharmacies.
+var pharmacies = directory[*can.Pharmacy]{
+	kind:        "pharmacy",
+	file:        pharmaciesFile,
+	id:          func(p *can.Pharmacy) uuid.UUID { return p.ID },
+	name:        func(p *can.Pharmacy) string { return p.Name },
+	matches:     (*can.Pharmacy).Matches,
+	errNotFound: ErrPharmacyNotFound,
+	errExists:   ErrPharmacyAlreadyExists,
+}
+
+// PharmacyDirectoryStore is the implementation of the PharmacyStore interface,
+// in memory or in a yaml file depending on the storage mode.
+type PharmacyDirectoryStore struct {
+	store *directoryStore[*can.Pharmacy]
+}
+
+// AddPharmacy adds a pharmacy to the store, using its ID as the key.
+func (pds *PharmacyDirectoryStore) AddPharmacy(p *can.Pharmacy) error {
+	return pds.store.add(p)
+}
+
+// UpdatePharmacy replaces the pharmacy with the same ID in the store.
+func (pds *PharmacyDirectoryStore) UpdatePharmacy(p *can.Pharmacy) error {
+	return pds.store.update(p)
+}
+
+// RemovePharmacy removes the pharmacy with the given ID from the store.
+func (pds *PharmacyDirectoryStore) RemovePharmacy(id uuid.UUID) error {
+	return pds.store.remove(id)
+}
+
+// GetPharmacies returns all pharmacies in the store, sorted by their name.
+func (pds *PharmacyDirectoryStore) GetPharmacies() []*can.Pharmacy {
+	return pds.store.all()
+}
+
+// FindPharmacy finds a pharmacy in the store by its name or one of its
+// aliases, ignoring case.
+func (pds *PharmacyDirectoryStore) FindPharmacy(name string) (*can.Pharmacy, error) {
+	return pds.store.find(name)
+}
+
+// NewPharmacyStore returns a new PharmacyStore implementation depending on the
+// configured storage mode in the environment variable.
+func NewPharmacyStore() PharmacyStore {
+	store := newDirectoryStore(pharmacies)
+	if store == nil {
+		return nil
+	}
+	return &PharmacyDirectoryStore{store: store}
+}
@@ -0,0 +1,44 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	can "github.com/TheDonDope/wits-tui/pkg/cannabis"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testPharmacy generates a consistent test pharmacy with fixed values
+func testPharmacy() *can.Pharmacy {
+	testUUID := uuid.MustParse("9aa7b810-9dad-11d1-80b4-00c04fd430c8")
+	testTime := time.Date(2023, time.October, 5, 0, 0, 0, 0, time.UTC)
+
+	return &can.Pharmacy{
+		ID:        testUUID,
+		Name:      "Linden-Apotheke",
+		Aliases:   []string{"Linden-Apotheke Berlin"},
+		Country:   "DE",
+		CreatedAt: testTime,
+		UpdatedAt: testTime,
+	}
+}
+
+// TestPharmacyStore tests the pharmacy store, whose behavior is shared with the
+// other directories and tested in TestDirectoryStores.
+func TestPharmacyStore(t *testing.T) {
+	t.Setenv("STORAGE_MODE", StoreYMLFile)
+	t.Setenv("WITS_DIR", t.TempDir())
+	p := testPharmacy()
+	require.NoError(t, NewPharmacyStore().AddPharmacy(p))
+	store := NewPharmacyStore()
+
+	persisted, err := store.FindPharmacy("LINDEN-APOTHEKE BERLIN")
+	require.NoError(t, err)
+	assert.Equal(t, p, persisted)
+	assert.Equal(t, []*can.Pharmacy{p}, store.GetPharmacies())
+	assert.ErrorIs(t, store.AddPharmacy(p), ErrPharmacyAlreadyExists)
+	_, err = store.FindPharmacy("Bären-Apotheke")
+	assert.ErrorIs(t, err, ErrPharmacyNotFound)
+}
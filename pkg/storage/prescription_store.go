@@ -0,0 +1,219 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"sync"
+
+	can "github.com/TheDonDope/wits-tui/pkg/cannabis"
+	"github.com/google/uuid"
+	"gopkg.in/yaml.v3"
+)
+
+const prescriptionsFile = "prescriptions.yml"
+
+var (
+	// ErrPrescriptionNotFound is returned when a prescription is not found in the store.
+	ErrPrescriptionNotFound = errors.New("Prescription with that ID not found")
+	// ErrPrescriptionAlreadyExists is returned when a prescription with the same ID already exists in the store.
+	ErrPrescriptionAlreadyExists = errors.New("Prescription with that ID already exists")
+)
+
+// PrescriptionStore is an interface for storing prescriptions.
+type PrescriptionStore interface {
+	AddPrescription(p *can.Prescription) error
+	UpdatePrescription(p *can.Prescription) error
+	GetPrescriptions() []*can.Prescription
+	FindPrescription(id uuid.UUID) (*can.Prescription, error)
+}
+
+// PrescriptionStoreInMemory is the in memory implementation of the
+// PrescriptionStore interface.
+type PrescriptionStoreInMemory struct {
+	mu            sync.Mutex
+	prescriptions map[uuid.UUID]*can.Prescription
+}
+
+// AddPrescription adds a prescription to the store, using its ID as the key.
+func (psim *PrescriptionStoreInMemory) AddPrescription(p *can.Prescription) error {
+	log.Printf("💬 💾  (pkg/storage/prescription_store.go) AddPrescription(p *can.Prescription: %v) \n", p.ID)
+	psim.mu.Lock()
+	defer psim.mu.Unlock()
+
+	if _, exists := psim.prescriptions[p.ID]; exists {
+		log.Printf("🚨 💾  (pkg/storage/prescription_store.go) 🗒️  Failed to add already existing prescription: %v \n", p.ID)
+		return ErrPrescriptionAlreadyExists
+	}
+	psim.prescriptions[p.ID] = p
+	log.Printf("✅ 💾  (pkg/storage/prescription_store.go) AddPrescription() -> len(psim.prescriptions): %v \n", len(psim.prescriptions))
+	return nil
+}
+
+// UpdatePrescription replaces the prescription with the same ID in the store.
+func (psim *PrescriptionStoreInMemory) UpdatePrescription(p *can.Prescription) error {
+	log.Printf("💬 💾  (pkg/storage/prescription_store.go) UpdatePrescription(p *can.Prescription: %v) \n", p.ID)
+	psim.mu.Lock()
+	defer psim.mu.Unlock()
+
+	if _, exists := psim.prescriptions[p.ID]; !exists {
+		log.Printf("🚨 💾  (pkg/storage/prescription_store.go) 🗒️  Failed to update non existing prescription: %v \n", p.ID)
+		return ErrPrescriptionNotFound
+	}
+	psim.prescriptions[p.ID] = p
+	log.Println("✅ 💾  (pkg/storage/prescription_store.go) UpdatePrescription()")
+	return nil
+}
+
+// GetPrescriptions returns all prescriptions in the store as a slice.
+func (psim *PrescriptionStoreInMemory) GetPrescriptions() []*can.Prescription {
+	log.Println("💬 💾  (pkg/storage/prescription_store.go) GetPrescriptions()")
+	psim.mu.Lock()
+	defer psim.mu.Unlock()
+
+	var prescriptions []*can.Prescription
+	for _, p := range psim.prescriptions {
+		prescriptions = append(prescriptions, p)
+	}
+	sortByIssueDate(prescriptions)
+	log.Printf("✅ 💾  (pkg/storage/prescription_store.go) GetPrescriptions() -> len(prescriptions): %v \n", len(prescriptions))
+	return prescriptions
+}
+
+// FindPrescription finds a prescription in the store by its ID.
+func (psim *PrescriptionStoreInMemory) FindPrescription(id uuid.UUID) (*can.Prescription, error) {
+	log.Printf("💬 💾  (pkg/storage/prescription_store.go) FindPrescription(id uuid.UUID: %v) \n", id)
+	psim.mu.Lock()
+	defer psim.mu.Unlock()
+
+	p, exists := psim.prescriptions[id]
+	if !exists {
+		log.Printf("🚨 💾  (pkg/storage/prescription_store.go) 🗒️  Prescription with ID %v does not exist. \n", id)
+		return nil, ErrPrescriptionNotFound
+	}
+	log.Printf("✅ 💾  (pkg/storage/prescription_store.go) FindPrescription() -> prescription: %v \n", p.ID)
+	return p, nil
+}
+
+// PrescriptionStoreYMLFile is the yaml file storage implementation of the
+// PrescriptionStore interface.
+type PrescriptionStoreYMLFile struct {
+	mu            sync.Mutex
+	prescriptions map[uuid.UUID]*can.Prescription
+}
+
+// AddPrescription adds a prescription to the store, using its ID as the key.
+func (psyf *PrescriptionStoreYMLFile) AddPrescription(p *can.Prescription) error {
+	log.Printf("💬 💾  (pkg/storage/prescription_store.go) AddPrescription(p *can.Prescription: %v) \n", p.ID)
+	psyf.mu.Lock()
+	defer psyf.mu.Unlock()
+
+	if _, exists := psyf.prescriptions[p.ID]; exists {
+		log.Printf("🚨 💾  (pkg/storage/prescription_store.go) 🗒️  Failed to add already existing prescription: %v \n", p.ID)
+		return ErrPrescriptionAlreadyExists
+	}
+	psyf.prescriptions[p.ID] = p
+	log.Println("✅ 💾  (pkg/storage/prescription_store.go) AddPrescription()")
+	return psyf.persist()
+}
+
+// UpdatePrescription replaces the prescription with the same ID in the store.
+func (psyf *PrescriptionStoreYMLFile) UpdatePrescription(p *can.Prescription) error {
+	log.Printf("💬 💾  (pkg/storage/prescription_store.go) UpdatePrescription(p *can.Prescription: %v) \n", p.ID)
+	psyf.mu.Lock()
+	defer psyf.mu.Unlock()
+
+	if _, exists := psyf.prescriptions[p.ID]; !exists {
+		log.Printf("🚨 💾  (pkg/storage/prescription_store.go) 🗒️  Failed to update non existing prescription: %v \n", p.ID)
+		return ErrPrescriptionNotFound
+	}
+	psyf.prescriptions[p.ID] = p
+	log.Println("✅ 💾  (pkg/storage/prescription_store.go) UpdatePrescription()")
+	return psyf.persist()
+}
+
+// persist writes all prescriptions to the prescriptions file. The caller must
+// hold the lock.
+func (psyf *PrescriptionStoreYMLFile) persist() error {
+	data, err := yaml.Marshal(psyf.prescriptions)
+	if err != nil {
+		log.Printf("🚨 💾  (pkg/storage/prescription_store.go) 🗒️  Failed to marshal prescription with error: %v \n", err)
+		return err
+	}
+	return os.WriteFile(fmt.Sprintf("%s/%s", os.Getenv("WITS_DIR"), prescriptionsFile), data, 0644)
+}
+
+// GetPrescriptions returns all prescriptions in the store as a slice.
+func (psyf *PrescriptionStoreYMLFile) GetPrescriptions() []*can.Prescription {
+	log.Println("💬 💾  (pkg/storage/prescription_store.go) GetPrescriptions()")
+	psyf.mu.Lock()
+	defer psyf.mu.Unlock()
+
+	var prescriptions []*can.Prescription
+	for _, p := range psyf.prescriptions {
+		prescriptions = append(prescriptions, p)
+	}
+	sortByIssueDate(prescriptions)
+	log.Printf("✅ 💾  (pkg/storage/prescription_store.go) GetPrescriptions() -> len(prescriptions): %v \n", len(prescriptions))
+	return prescriptions
+}
+
+// FindPrescription finds a prescription in the store by its ID.
+func (psyf *PrescriptionStoreYMLFile) FindPrescription(id uuid.UUID) (*can.Prescription, error) {
+	log.Printf("💬 💾  (pkg/storage/prescription_store.go) FindPrescription(id uuid.UUID: %v) \n", id)
+	psyf.mu.Lock()
+	defer psyf.mu.Unlock()
+
+	p, exists := psyf.prescriptions[id]
+	if !exists {
+		log.Printf("🚨 💾  (pkg/storage/prescription_store.go) 🗒️  Prescription with ID %v does not exist. \n", id)
+		return nil, ErrPrescriptionNotFound
+	}
+	log.Printf("✅ 💾  (pkg/storage/prescription_store.go) FindPrescription() -> prescription: %v \n", p.ID)
+	return p, nil
+}
+
+// sortByIssueDate sorts the given prescriptions by their issue date, latest
+// first, so that stores always return prescriptions in a stable order.
+func sortByIssueDate(prescriptions []*can.Prescription) {
+	sort.Slice(prescriptions, func(i, j int) bool {
+		if !prescriptions[i].IssuedAt.Equal(prescriptions[j].IssuedAt) {
+			return prescriptions[i].IssuedAt.After(prescriptions[j].IssuedAt)
+		}
+		return prescriptions[i].ID.String() < prescriptions[j].ID.String()
+	})
+}
+
+// NewPrescriptionStore returns a new PrescriptionStore implementation depending
+// on the configured storage mode in the environment variable.
+func NewPrescriptionStore() PrescriptionStore {
+	storageMode := os.Getenv("STORAGE_MODE")
+	log.Printf("💬 💾  (pkg/storage/prescription_store.go) NewPrescriptionStore() -> storageMode: %v \n", storageMode)
+	switch storageMode {
+	case StoreInMemory:
+		return &PrescriptionStoreInMemory{
+			prescriptions: make(map[uuid.UUID]*can.Prescription),
+		}
+	case StoreYMLFile:
+		psyf := &PrescriptionStoreYMLFile{
+			prescriptions: make(map[uuid.UUID]*can.Prescription),
+		}
+		data, err := os.ReadFile(fmt.Sprintf("%s/%s", os.Getenv("WITS_DIR"), prescriptionsFile))
+		if err != nil {
+			if os.IsNotExist(err) {
+				log.Println("ℹ️  💾  (pkg/storage/prescription_store.go) 🗒️  Prescription file not existing. Returning new empty store.")
+				return psyf
+			}
+		}
+		err = yaml.Unmarshal(data, psyf.prescriptions)
+		if err != nil {
+			log.Printf("🚨 💾  (pkg/storage/prescription_store.go) 🗒️  Failed unmarshal prescription data with error: %v. Returning new empty store. \n", err)
+			return psyf
+		}
+		log.Printf("✅ 💾  (pkg/storage/prescription_store.go) NewPrescriptionStore() -> len(prescriptions): %v \n", len(psyf.prescriptions))
+		return psyf
+	}
+	return nil
+}
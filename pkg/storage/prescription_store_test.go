@@ -0,0 +1,104 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	can "github.com/TheDonDope/wits-tui/pkg/cannabis"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testPrescription generates a consistent test prescription with fixed values
+func testPrescription() *can.Prescription {
+	testUUID := uuid.MustParse("7ca7b810-9dad-11d1-80b4-00c04fd430c8")
+	testTime := time.Date(2023, time.October, 5, 0, 0, 0, 0, time.UTC)
+
+	return &can.Prescription{
+		ID:         testUUID,
+		Doctor:     "Dr. Test",
+		IssuedAt:   testTime,
+		ValidUntil: testTime.AddDate(0, 3, 0),
+		Grams:      30,
+		PeriodDays: 30,
+		Strains:    []string{"Test Strain"},
+		CreatedAt:  testTime,
+		UpdatedAt:  testTime,
+	}
+}
+
+// TestPrescriptionStores runs all tests for both prescription store
+// implementations
+func TestPrescriptionStores(t *testing.T) {
+	stores := map[string]func(t *testing.T) PrescriptionStore{
+		"InMemory": func(t *testing.T) PrescriptionStore {
+			t.Setenv("STORAGE_MODE", StoreInMemory)
+			return NewPrescriptionStore()
+		},
+		"YMLFile": func(t *testing.T) PrescriptionStore {
+			t.Setenv("STORAGE_MODE", StoreYMLFile)
+			t.Setenv("WITS_DIR", t.TempDir())
+			return NewPrescriptionStore()
+		},
+	}
+
+	for name, newStore := range stores {
+		t.Run(name, func(t *testing.T) {
+			t.Run("AddPrescription", func(t *testing.T) {
+				store := newStore(t)
+				p := testPrescription()
+
+				require.NoError(t, store.AddPrescription(p))
+				assert.ErrorIs(t, store.AddPrescription(p), ErrPrescriptionAlreadyExists)
+				assert.Len(t, store.GetPrescriptions(), 1)
+			})
+
+			t.Run("GetPrescriptions", func(t *testing.T) {
+				store := newStore(t)
+				assert.Empty(t, store.GetPrescriptions())
+
+				older := testPrescription()
+				newer := testPrescription()
+				newer.ID = uuid.New()
+				newer.IssuedAt = older.IssuedAt.AddDate(0, 1, 0)
+				require.NoError(t, store.AddPrescription(older))
+				require.NoError(t, store.AddPrescription(newer))
+
+				prescriptions := store.GetPrescriptions()
+				require.Len(t, prescriptions, 2)
+				assert.Equal(t, newer.ID, prescriptions[0].ID)
+			})
+
+			t.Run("FindAndUpdatePrescription", func(t *testing.T) {
+				store := newStore(t)
+				p := testPrescription()
+
+				_, err := store.FindPrescription(p.ID)
+				assert.ErrorIs(t, err, ErrPrescriptionNotFound)
+				assert.ErrorIs(t, store.UpdatePrescription(p), ErrPrescriptionNotFound)
+
+				require.NoError(t, store.AddPrescription(p))
+				updated := testPrescription()
+				updated.Grams = 50
+				require.NoError(t, store.UpdatePrescription(updated))
+
+				found, err := store.FindPrescription(p.ID)
+				require.NoError(t, err)
+				assert.Equal(t, 50.0, found.Grams)
+			})
+		})
+	}
+
+	t.Run("Persistence", func(t *testing.T) {
+		t.Setenv("STORAGE_MODE", StoreYMLFile)
+		t.Setenv("WITS_DIR", t.TempDir())
+		p := testPrescription()
+		require.NoError(t, NewPrescriptionStore().AddPrescription(p))
+
+		persisted, err := NewPrescriptionStore().FindPrescription(p.ID)
+
+		require.NoError(t, err)
+		assert.Equal(t, p, persisted)
+	})
+}
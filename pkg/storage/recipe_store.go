@@ -0,0 +1,153 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"sync"
+
+	can "github.com/TheDonDope/wits-tui/pkg/cannabis"
+	"github.com/google/uuid"
+	"gopkg.in/yaml.v3"
+)
+
+const recipesFile = "recipes.yml"
+
+// ErrRecipeAlreadyExists is returned when a recipe with the same ID already exists in the store.
+var ErrRecipeAlreadyExists = errors.New("Recipe with that ID already exists")
+
+// RecipeStore is an interface for storing recipes.
+type RecipeStore interface {
+	AddRecipe(r *can.Recipe) error
+	GetRecipes() []*can.Recipe
+}
+
+// RecipeStoreInMemory is the in memory implementation of the RecipeStore
+// interface.
+type RecipeStoreInMemory struct {
+	mu      sync.Mutex
+	recipes map[uuid.UUID]*can.Recipe
+}
+
+// AddRecipe adds a recipe to the store, using its ID as the key.
+func (rsim *RecipeStoreInMemory) AddRecipe(r *can.Recipe) error {
+	log.Printf("💬 💾  (pkg/storage/recipe_store.go) AddRecipe(r *can.Recipe: %v) \n", r.ID)
+	rsim.mu.Lock()
+	defer rsim.mu.Unlock()
+
+	if _, exists := rsim.recipes[r.ID]; exists {
+		log.Printf("🚨 💾  (pkg/storage/recipe_store.go) 🗒️  Failed to add already existing recipe: %v \n", r.ID)
+		return ErrRecipeAlreadyExists
+	}
+	rsim.recipes[r.ID] = r
+	log.Printf("✅ 💾  (pkg/storage/recipe_store.go) AddRecipe() -> len(rsim.recipes): %v \n", len(rsim.recipes))
+	return nil
+}
+
+// GetRecipes returns all recipes in the store as a slice.
+func (rsim *RecipeStoreInMemory) GetRecipes() []*can.Recipe {
+	log.Println("💬 💾  (pkg/storage/recipe_store.go) GetRecipes()")
+	rsim.mu.Lock()
+	defer rsim.mu.Unlock()
+
+	var recipes []*can.Recipe
+	for _, r := range rsim.recipes {
+		recipes = append(recipes, r)
+	}
+	sortByName(recipes)
+	log.Printf("✅ 💾  (pkg/storage/recipe_store.go) GetRecipes() -> len(recipes): %v \n", len(recipes))
+	return recipes
+}
+
+// RecipeStoreYMLFile is the yaml file storage implementation of the
+// RecipeStore interface.
+type RecipeStoreYMLFile struct {
+	mu      sync.Mutex
+	recipes map[uuid.UUID]*can.Recipe
+}
+
+// AddRecipe adds a recipe to the store, using its ID as the key.
+func (rsyf *RecipeStoreYMLFile) AddRecipe(r *can.Recipe) error {
+	log.Printf("💬 💾  (pkg/storage/recipe_store.go) AddRecipe(r *can.Recipe: %v) \n", r.ID)
+	rsyf.mu.Lock()
+	defer rsyf.mu.Unlock()
+
+	if _, exists := rsyf.recipes[r.ID]; exists {
+		log.Printf("🚨 💾  (pkg/storage/recipe_store.go) 🗒️  Failed to add already existing recipe: %v \n", r.ID)
+		return ErrRecipeAlreadyExists
+	}
+	rsyf.recipes[r.ID] = r
+	log.Println("✅ 💾  (pkg/storage/recipe_store.go) AddRecipe()")
+	return rsyf.persist()
+}
+
+// persist writes all recipes to the recipes file. The caller must hold the
+// lock.
+func (rsyf *RecipeStoreYMLFile) persist() error {
+	data, err := yaml.Marshal(rsyf.recipes)
+	if err != nil {
+		log.Printf("🚨 💾  (pkg/storage/recipe_store.go) 🗒️  Failed to marshal recipe with error: %v \n", err)
+		return err
+	}
+	return os.WriteFile(fmt.Sprintf("%s/%s", os.Getenv("WITS_DIR"), recipesFile), data, 0644)
+}
+
+// GetRecipes returns all recipes in the store as a slice.
+func (rsyf *RecipeStoreYMLFile) GetRecipes() []*can.Recipe {
+	log.Println("💬 💾  (pkg/storage/recipe_store.go) GetRecipes()")
+	rsyf.mu.Lock()
+	defer rsyf.mu.Unlock()
+
+	var recipes []*can.Recipe
+	for _, r := range rsyf.recipes {
+		recipes = append(recipes, r)
+	}
+	sortByName(recipes)
+	log.Printf("✅ 💾  (pkg/storage/recipe_store.go) GetRecipes() -> len(recipes): %v \n", len(recipes))
+	return recipes
+}
+
+// sortByName sorts the given recipes by their name, so that stores
+// always return recipes in a stable order.
+func sortByName(recipes []*can.Recipe) {
+	sort.Slice(recipes, func(i, j int) bool {
+		if recipes[i].Name != recipes[j].Name {
+			return recipes[i].Name < recipes[j].Name
+		}
+		return recipes[i].ID.String() < recipes[j].ID.String()
+	})
+}
+
+// NewRecipeStore returns a new RecipeStore implementation depending on the
+// configured storage mode in the environment variable.
+func NewRecipeStore() RecipeStore {
+	storageMode := os.Getenv("STORAGE_MODE")
+	log.Printf("💬 💾  (pkg/storage/recipe_store.go) NewRecipeStore() -> storageMode: %v \n", storageMode)
+	switch storageMode {
+	case StoreInMemory:
+		return &RecipeStoreInMemory{
+			recipes: make(map[uuid.UUID]*can.Recipe),
+		}
+	case StoreYMLFile:
+		rsyf := &RecipeStoreYMLFile{
+			recipes: make(map[uuid.UUID]*can.Recipe),
+		}
+		data, err := os.ReadFile(fmt.Sprintf("%s/%s", os.Getenv("WITS_DIR"), recipesFile))
+		if err != nil {
+			if os.IsNotExist(err) {
+				log.Println("ℹ️  💾  (pkg/storage/recipe_store.go) 🗒️  Recipe file not existing. Returning new empty store.")
+				return rsyf
+			}
+		}
+		err = yaml.Unmarshal(data, rsyf.recipes)
+		if err != nil {
+			log.Printf("🚨 💾  (pkg/storage/recipe_store.go) 🗒️  Failed unmarshal recipe data with error: %v. Returning new empty store. \n", err)
+			return rsyf
+		}
+		log.Printf("✅ 💾  (pkg/storage/recipe_store.go) NewRecipeStore() -> len(recipes): %v \n", len(rsyf.recipes))
+		return rsyf
+	}
+	return nil
+}
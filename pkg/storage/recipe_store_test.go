@@ -0,0 +1,80 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	can "github.com/TheDonDope/wits-tui/pkg/cannabis"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testRecipe generates a consistent test recipe with fixed values
+func testRecipe() *can.Recipe {
+	testUUID := uuid.MustParse("9da7b810-9dad-11d1-80b4-00c04fd430c8")
+	testTime := time.Date(2023, time.October, 5, 20, 0, 0, 0, time.UTC)
+
+	return &can.Recipe{
+		ID:   testUUID,
+		Name: "Brownies",
+		Ingredients: []*can.Ingredient{{
+			StrainID: uuid.MustParse("6ba7b810-9dad-11d1-80b4-00c04fd430c8"),
+			Strain:   "Test Strain",
+			Grams:    2,
+			THC:      20,
+			CBD:      1,
+		}},
+		Temperature: 120,
+		Minutes:     30,
+		Carrier:     can.CoconutOil,
+		Servings:    12,
+		Notes:       "Test Notes",
+		CreatedAt:   testTime,
+		UpdatedAt:   testTime,
+	}
+}
+
+// TestRecipeStores runs all tests for both recipe store implementations
+func TestRecipeStores(t *testing.T) {
+	stores := map[string]func(t *testing.T) RecipeStore{
+		"InMemory": func(t *testing.T) RecipeStore {
+			t.Setenv("STORAGE_MODE", StoreInMemory)
+			return NewRecipeStore()
+		},
+		"YMLFile": func(t *testing.T) RecipeStore {
+			t.Setenv("STORAGE_MODE", StoreYMLFile)
+			t.Setenv("WITS_DIR", t.TempDir())
+			return NewRecipeStore()
+		},
+	}
+
+	for name, newStore := range stores {
+		t.Run(name, func(t *testing.T) {
+			store := newStore(t)
+			assert.Empty(t, store.GetRecipes())
+
+			other := testRecipe()
+			other.ID = uuid.New()
+			other.Name = "Cookies"
+			require.NoError(t, store.AddRecipe(other))
+			require.NoError(t, store.AddRecipe(testRecipe()))
+			assert.ErrorIs(t, store.AddRecipe(testRecipe()), ErrRecipeAlreadyExists)
+
+			recipes := store.GetRecipes()
+			require.Len(t, recipes, 2)
+			assert.Equal(t, testRecipe().ID, recipes[0].ID)
+		})
+	}
+
+	t.Run("Persistence", func(t *testing.T) {
+		t.Setenv("STORAGE_MODE", StoreYMLFile)
+		t.Setenv("WITS_DIR", t.TempDir())
+		require.NoError(t, NewRecipeStore().AddRecipe(testRecipe()))
+
+		recipes := NewRecipeStore().GetRecipes()
+
+		require.Len(t, recipes, 1)
+		assert.Equal(t, testRecipe(), recipes[0])
+	})
+}
@@ -0,0 +1,250 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"sync"
+
+	can "github.com/TheDonDope/wits-tui/pkg/cannabis"
+	"github.com/google/uuid"
+	"gopkg.in/yaml.v3"
+)
+
+const remindersFile = "reminders.yml"
+
+var (
+	// ErrReminderNotFound is returned when a reminder is not found in the store.
+	ErrReminderNotFound = errors.New("Reminder with that ID not found")
+	// ErrReminderAlreadyExists is returned when a reminder with the same ID already exists in the store.
+	ErrReminderAlreadyExists = errors.New("Reminder with that ID already exists")
+)
+
+// ReminderStore is an interface for storing reminders.
+type ReminderStore interface {
+	AddReminder(r *can.Reminder) error
+	UpdateReminder(r *can.Reminder) error
+	GetReminders() []*can.Reminder
+	FindReminder(id uuid.UUID) (*can.Reminder, error)
+	RemoveReminder(id uuid.UUID) error
+}
+
+// ReminderStoreInMemory is the in memory implementation of the
+// ReminderStore interface.
+type ReminderStoreInMemory struct {
+	mu        sync.Mutex
+	reminders map[uuid.UUID]*can.Reminder
+}
+
+// AddReminder adds a reminder to the store, using its ID as the key.
+func (rsim *ReminderStoreInMemory) AddReminder(r *can.Reminder) error {
+	log.Printf("💬 💾  (pkg/storage/reminder_store.go) AddReminder(r *can.Reminder: %v) \n", r.ID)
+	rsim.mu.Lock()
+	defer rsim.mu.Unlock()
+
+	if _, exists := rsim.reminders[r.ID]; exists {
+		log.Printf("🚨 💾  (pkg/storage/reminder_store.go) 🗒️  Failed to add already existing reminder: %v \n", r.ID)
+		return ErrReminderAlreadyExists
+	}
+	rsim.reminders[r.ID] = r
+	log.Printf("✅ 💾  (pkg/storage/reminder_store.go) AddReminder() -> len(rsim.reminders): %v \n", len(rsim.reminders))
+	return nil
+}
+
+// UpdateReminder replaces the reminder with the same ID in the store.
+func (rsim *ReminderStoreInMemory) UpdateReminder(r *can.Reminder) error {
+	log.Printf("💬 💾  (pkg/storage/reminder_store.go) UpdateReminder(r *can.Reminder: %v) \n", r.ID)
+	rsim.mu.Lock()
+	defer rsim.mu.Unlock()
+
+	if _, exists := rsim.reminders[r.ID]; !exists {
+		log.Printf("🚨 💾  (pkg/storage/reminder_store.go) 🗒️  Failed to update non existing reminder: %v \n", r.ID)
+		return ErrReminderNotFound
+	}
+	rsim.reminders[r.ID] = r
+	log.Println("✅ 💾  (pkg/storage/reminder_store.go) UpdateReminder()")
+	return nil
+}
+
+// GetReminders returns all reminders in the store as a slice.
+func (rsim *ReminderStoreInMemory) GetReminders() []*can.Reminder {
+	log.Println("💬 💾  (pkg/storage/reminder_store.go) GetReminders()")
+	rsim.mu.Lock()
+	defer rsim.mu.Unlock()
+
+	var reminders []*can.Reminder
+	for _, r := range rsim.reminders {
+		reminders = append(reminders, r)
+	}
+	sortByCreationDate(reminders)
+	log.Printf("✅ 💾  (pkg/storage/reminder_store.go) GetReminders() -> len(reminders): %v \n", len(reminders))
+	return reminders
+}
+
+// FindReminder finds a reminder in the store by its ID.
+func (rsim *ReminderStoreInMemory) FindReminder(id uuid.UUID) (*can.Reminder, error) {
+	log.Printf("💬 💾  (pkg/storage/reminder_store.go) FindReminder(id uuid.UUID: %v) \n", id)
+	rsim.mu.Lock()
+	defer rsim.mu.Unlock()
+
+	r, exists := rsim.reminders[id]
+	if !exists {
+		log.Printf("🚨 💾  (pkg/storage/reminder_store.go) 🗒️  Reminder with ID %v does not exist. \n", id)
+		return nil, ErrReminderNotFound
+	}
+	log.Printf("✅ 💾  (pkg/storage/reminder_store.go) FindReminder() -> reminder: %v \n", r.ID)
+	return r, nil
+}
+
+// RemoveReminder removes the reminder with the given ID from the store.
+func (rsim *ReminderStoreInMemory) RemoveReminder(id uuid.UUID) error {
+	log.Printf("💬 💾  (pkg/storage/reminder_store.go) RemoveReminder(id uuid.UUID: %v) \n", id)
+	rsim.mu.Lock()
+	defer rsim.mu.Unlock()
+
+	if _, exists := rsim.reminders[id]; !exists {
+		log.Printf("🚨 💾  (pkg/storage/reminder_store.go) 🗒️  Failed to remove non existing reminder: %v \n", id)
+		return ErrReminderNotFound
+	}
+	delete(rsim.reminders, id)
+	log.Println("✅ 💾  (pkg/storage/reminder_store.go) RemoveReminder()")
+	return nil
+}
+
+// ReminderStoreYMLFile is the yaml file storage implementation of the
+// ReminderStore interface.
+type ReminderStoreYMLFile struct {
+	mu        sync.Mutex
+	reminders map[uuid.UUID]*can.Reminder
+}
+
+// AddReminder adds a reminder to the store, using its ID as the key.
+func (rsyf *ReminderStoreYMLFile) AddReminder(r *can.Reminder) error {
+	log.Printf("💬 💾  (pkg/storage/reminder_store.go) AddReminder(r *can.Reminder: %v) \n", r.ID)
+	rsyf.mu.Lock()
+	defer rsyf.mu.Unlock()
+
+	if _, exists := rsyf.reminders[r.ID]; exists {
+		log.Printf("🚨 💾  (pkg/storage/reminder_store.go) 🗒️  Failed to add already existing reminder: %v \n", r.ID)
+		return ErrReminderAlreadyExists
+	}
+	rsyf.reminders[r.ID] = r
+	log.Println("✅ 💾  (pkg/storage/reminder_store.go) AddReminder()")
+	return rsyf.persist()
+}
+
+// UpdateReminder replaces the reminder with the same ID in the store.
+func (rsyf *ReminderStoreYMLFile) UpdateReminder(r *can.Reminder) error {
+	log.Printf("💬 💾  (pkg/storage/reminder_store.go) UpdateReminder(r *can.Reminder: %v) \n", r.ID)
+	rsyf.mu.Lock()
+	defer rsyf.mu.Unlock()
+
+	if _, exists := rsyf.reminders[r.ID]; !exists {
+		log.Printf("🚨 💾  (pkg/storage/reminder_store.go) 🗒️  Failed to update non existing reminder: %v \n", r.ID)
+		return ErrReminderNotFound
+	}
+	rsyf.reminders[r.ID] = r
+	log.Println("✅ 💾  (pkg/storage/reminder_store.go) UpdateReminder()")
+	return rsyf.persist()
+}
+
+// RemoveReminder removes the reminder with the given ID from the store.
+func (rsyf *ReminderStoreYMLFile) RemoveReminder(id uuid.UUID) error {
+	log.Printf("💬 💾  (pkg/storage/reminder_store.go) RemoveReminder(id uuid.UUID: %v) \n", id)
+	rsyf.mu.Lock()
+	defer rsyf.mu.Unlock()
+
+	if _, exists := rsyf.reminders[id]; !exists {
+		log.Printf("🚨 💾  (pkg/storage/reminder_store.go) 🗒️  Failed to remove non existing reminder: %v \n", id)
+		return ErrReminderNotFound
+	}
+	delete(rsyf.reminders, id)
+	log.Println("✅ 💾  (pkg/storage/reminder_store.go) RemoveReminder()")
+	return rsyf.persist()
+}
+
+// persist writes all reminders to the reminders file. The caller must
+// hold the lock.
+func (rsyf *ReminderStoreYMLFile) persist() error {
+	data, err := yaml.Marshal(rsyf.reminders)
+	if err != nil {
+		log.Printf("🚨 💾  (pkg/storage/reminder_store.go) 🗒️  Failed to marshal reminder with error: %v \n", err)
+		return err
+	}
+	return os.WriteFile(fmt.Sprintf("%s/%s", os.Getenv("WITS_DIR"), remindersFile), data, 0644)
+}
+
+// GetReminders returns all reminders in the store as a slice.
+func (rsyf *ReminderStoreYMLFile) GetReminders() []*can.Reminder {
+	log.Println("💬 💾  (pkg/storage/reminder_store.go) GetReminders()")
+	rsyf.mu.Lock()
+	defer rsyf.mu.Unlock()
+
+	var reminders []*can.Reminder
+	for _, r := range rsyf.reminders {
+		reminders = append(reminders, r)
+	}
+	sortByCreationDate(reminders)
+	log.Printf("✅ 💾  (pkg/storage/reminder_store.go) GetReminders() -> len(reminders): %v \n", len(reminders))
+	return reminders
+}
+
+// FindReminder finds a reminder in the store by its ID.
+func (rsyf *ReminderStoreYMLFile) FindReminder(id uuid.UUID) (*can.Reminder, error) {
+	log.Printf("💬 💾  (pkg/storage/reminder_store.go) FindReminder(id uuid.UUID: %v) \n", id)
+	rsyf.mu.Lock()
+	defer rsyf.mu.Unlock()
+
+	r, exists := rsyf.reminders[id]
+	if !exists {
+		log.Printf("🚨 💾  (pkg/storage/reminder_store.go) 🗒️  Reminder with ID %v does not exist. \n", id)
+		return nil, ErrReminderNotFound
+	}
+	log.Printf("✅ 💾  (pkg/storage/reminder_store.go) FindReminder() -> reminder: %v \n", r.ID)
+	return r, nil
+}
+
+// sortByCreationDate sorts the given reminders by their creation date, oldest
+// first, so that stores always return reminders in a stable order.
+func sortByCreationDate(reminders []*can.Reminder) {
+	sort.Slice(reminders, func(i, j int) bool {
+		if !reminders[i].CreatedAt.Equal(reminders[j].CreatedAt) {
+			return reminders[i].CreatedAt.Before(reminders[j].CreatedAt)
+		}
+		return reminders[i].ID.String() < reminders[j].ID.String()
+	})
+}
+
+// NewReminderStore returns a new ReminderStore implementation depending
+// on the configured storage mode in the environment variable.
+func NewReminderStore() ReminderStore {
+	storageMode := os.Getenv("STORAGE_MODE")
+	log.Printf("💬 💾  (pkg/storage/reminder_store.go) NewReminderStore() -> storageMode: %v \n", storageMode)
+	switch storageMode {
+	case StoreInMemory:
+		return &ReminderStoreInMemory{
+			reminders: make(map[uuid.UUID]*can.Reminder),
+		}
+	case StoreYMLFile:
+		rsyf := &ReminderStoreYMLFile{
+			reminders: make(map[uuid.UUID]*can.Reminder),
+		}
+		data, err := os.ReadFile(fmt.Sprintf("%s/%s", os.Getenv("WITS_DIR"), remindersFile))
+		if err != nil {
+			if os.IsNotExist(err) {
+				log.Println("ℹ️  💾  (pkg/storage/reminder_store.go) 🗒️  Reminder file not existing. Returning new empty store.")
+				return rsyf
+			}
+		}
+		err = yaml.Unmarshal(data, rsyf.reminders)
+		if err != nil {
+			log.Printf("🚨 💾  (pkg/storage/reminder_store.go) 🗒️  Failed unmarshal reminder data with error: %v. Returning new empty store. \n", err)
+			return rsyf
+		}
+		log.Printf("✅ 💾  (pkg/storage/reminder_store.go) NewReminderStore() -> len(reminders): %v \n", len(rsyf.reminders))
+		return rsyf
+	}
+	return nil
+}
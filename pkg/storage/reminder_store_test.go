@@ -0,0 +1,111 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	can "github.com/TheDonDope/wits-tui/pkg/cannabis"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testReminder generates a consistent test reminder with fixed values
+func testReminder() *can.Reminder {
+	testUUID := uuid.MustParse("9ea7b810-9dad-11d1-80b4-00c04fd430c8")
+	testTime := time.Date(2023, time.October, 5, 0, 0, 0, 0, time.UTC)
+
+	return &can.Reminder{
+		ID:        testUUID,
+		Kind:      can.DoseReminder,
+		Times:     []string{"08:00", "20:00"},
+		Weekdays:  []time.Weekday{time.Monday, time.Thursday},
+		Strain:    "Test Strain",
+		Grams:     0.1,
+		CreatedAt: testTime,
+	}
+}
+
+// TestReminderStores runs all tests for both reminder store implementations
+func TestReminderStores(t *testing.T) {
+	stores := map[string]func(t *testing.T) ReminderStore{
+		"InMemory": func(t *testing.T) ReminderStore {
+			t.Setenv("STORAGE_MODE", StoreInMemory)
+			return NewReminderStore()
+		},
+		"YMLFile": func(t *testing.T) ReminderStore {
+			t.Setenv("STORAGE_MODE", StoreYMLFile)
+			t.Setenv("WITS_DIR", t.TempDir())
+			return NewReminderStore()
+		},
+	}
+
+	for name, newStore := range stores {
+		t.Run(name, func(t *testing.T) {
+			t.Run("AddReminder", func(t *testing.T) {
+				store := newStore(t)
+				r := testReminder()
+
+				require.NoError(t, store.AddReminder(r))
+				assert.ErrorIs(t, store.AddReminder(r), ErrReminderAlreadyExists)
+				assert.Len(t, store.GetReminders(), 1)
+			})
+
+			t.Run("GetReminders", func(t *testing.T) {
+				store := newStore(t)
+				assert.Empty(t, store.GetReminders())
+
+				older := testReminder()
+				newer := testReminder()
+				newer.ID = uuid.New()
+				newer.CreatedAt = older.CreatedAt.AddDate(0, 1, 0)
+				require.NoError(t, store.AddReminder(newer))
+				require.NoError(t, store.AddReminder(older))
+
+				reminders := store.GetReminders()
+				require.Len(t, reminders, 2)
+				assert.Equal(t, older.ID, reminders[0].ID)
+			})
+
+			t.Run("FindAndUpdateReminder", func(t *testing.T) {
+				store := newStore(t)
+				r := testReminder()
+
+				_, err := store.FindReminder(r.ID)
+				assert.ErrorIs(t, err, ErrReminderNotFound)
+				assert.ErrorIs(t, store.UpdateReminder(r), ErrReminderNotFound)
+
+				require.NoError(t, store.AddReminder(r))
+				updated := testReminder()
+				updated.Grams = 0.2
+				require.NoError(t, store.UpdateReminder(updated))
+
+				found, err := store.FindReminder(r.ID)
+				require.NoError(t, err)
+				assert.Equal(t, 0.2, found.Grams)
+			})
+
+			t.Run("RemoveReminder", func(t *testing.T) {
+				store := newStore(t)
+				r := testReminder()
+
+				assert.ErrorIs(t, store.RemoveReminder(r.ID), ErrReminderNotFound)
+				require.NoError(t, store.AddReminder(r))
+				require.NoError(t, store.RemoveReminder(r.ID))
+				assert.Empty(t, store.GetReminders())
+			})
+		})
+	}
+
+	t.Run("Persistence", func(t *testing.T) {
+		t.Setenv("STORAGE_MODE", StoreYMLFile)
+		t.Setenv("WITS_DIR", t.TempDir())
+		r := testReminder()
+		require.NoError(t, NewReminderStore().AddReminder(r))
+
+		persisted, err := NewReminderStore().FindReminder(r.ID)
+
+		require.NoError(t, err)
+		assert.Equal(t, r, persisted)
+	})
+}
@@ -0,0 +1,153 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"sync"
+
+	can "github.com/TheDonDope/wits-tui/pkg/cannabis"
+	"github.com/google/uuid"
+	"gopkg.in/yaml.v3"
+)
+
+const sessionsFile = "sessions.yml"
+
+// ErrSessionAlreadyExists is returned when a session with the same ID already exists in the store.
+var ErrSessionAlreadyExists = errors.New("Session with that ID already exists")
+
+// SessionStore is an interface for storing consumption sessions.
+type SessionStore interface {
+	AddSession(s *can.Session) error
+	GetSessions() []*can.Session
+}
+
+// SessionStoreInMemory is the in memory implementation of the SessionStore
+// interface.
+type SessionStoreInMemory struct {
+	mu       sync.Mutex
+	sessions map[uuid.UUID]*can.Session
+}
+
+// AddSession adds a session to the store, using its ID as the key.
+func (ssim *SessionStoreInMemory) AddSession(s *can.Session) error {
+	log.Printf("💬 💾  (pkg/storage/session_store.go) AddSession(s *can.Session: %v) \n", s.ID)
+	ssim.mu.Lock()
+	defer ssim.mu.Unlock()
+
+	if _, exists := ssim.sessions[s.ID]; exists {
+		log.Printf("🚨 💾  (pkg/storage/session_store.go) 🗒️  Failed to add already existing session: %v \n", s.ID)
+		return ErrSessionAlreadyExists
+	}
+	ssim.sessions[s.ID] = s
+	log.Printf("✅ 💾  (pkg/storage/session_store.go) AddSession() -> len(ssim.sessions): %v \n", len(ssim.sessions))
+	return nil
+}
+
+// GetSessions returns all sessions in the store as a slice.
+func (ssim *SessionStoreInMemory) GetSessions() []*can.Session {
+	log.Println("💬 💾  (pkg/storage/session_store.go) GetSessions()")
+	ssim.mu.Lock()
+	defer ssim.mu.Unlock()
+
+	var sessions []*can.Session
+	for _, s := range ssim.sessions {
+		sessions = append(sessions, s)
+	}
+	sortByStart(sessions)
+	log.Printf("✅ 💾  (pkg/storage/session_store.go) GetSessions() -> len(sessions): %v \n", len(sessions))
+	return sessions
+}
+
+// SessionStoreYMLFile is the yaml file storage implementation of the
+// SessionStore interface.
+type SessionStoreYMLFile struct {
+	mu       sync.Mutex
+	sessions map[uuid.UUID]*can.Session
+}
+
+// AddSession adds a session to the store, using its ID as the key.
+func (ssyf *SessionStoreYMLFile) AddSession(s *can.Session) error {
+	log.Printf("💬 💾  (pkg/storage/session_store.go) AddSession(s *can.Session: %v) \n", s.ID)
+	ssyf.mu.Lock()
+	defer ssyf.mu.Unlock()
+
+	if _, exists := ssyf.sessions[s.ID]; exists {
+		log.Printf("🚨 💾  (pkg/storage/session_store.go) 🗒️  Failed to add already existing session: %v \n", s.ID)
+		return ErrSessionAlreadyExists
+	}
+	ssyf.sessions[s.ID] = s
+	log.Println("✅ 💾  (pkg/storage/session_store.go) AddSession()")
+	return ssyf.persist()
+}
+
+// persist writes all sessions to the sessions file. The caller must hold the
+// lock.
+func (ssyf *SessionStoreYMLFile) persist() error {
+	data, err := yaml.Marshal(ssyf.sessions)
+	if err != nil {
+		log.Printf("🚨 💾  (pkg/storage/session_store.go) 🗒️  Failed to marshal session with error: %v \n", err)
+		return err
+	}
+	return os.WriteFile(fmt.Sprintf("%s/%s", os.Getenv("WITS_DIR"), sessionsFile), data, 0644)
+}
+
+// GetSessions returns all sessions in the store as a slice.
+func (ssyf *SessionStoreYMLFile) GetSessions() []*can.Session {
+	log.Println("💬 💾  (pkg/storage/session_store.go) GetSessions()")
+	ssyf.mu.Lock()
+	defer ssyf.mu.Unlock()
+
+	var sessions []*can.Session
+	for _, s := range ssyf.sessions {
+		sessions = append(sessions, s)
+	}
+	sortByStart(sessions)
+	log.Printf("✅ 💾  (pkg/storage/session_store.go) GetSessions() -> len(sessions): %v \n", len(sessions))
+	return sessions
+}
+
+// sortByStart sorts the given sessions by their start, earliest first, so
+// that stores always return sessions in a stable order.
+func sortByStart(sessions []*can.Session) {
+	sort.Slice(sessions, func(i, j int) bool {
+		if !sessions[i].StartedAt.Equal(sessions[j].StartedAt) {
+			return sessions[i].StartedAt.Before(sessions[j].StartedAt)
+		}
+		return sessions[i].ID.String() < sessions[j].ID.String()
+	})
+}
+
+// NewSessionStore returns a new SessionStore implementation depending on the
+// configured storage mode in the environment variable.
+func NewSessionStore() SessionStore {
+	storageMode := os.Getenv("STORAGE_MODE")
+	log.Printf("💬 💾  (pkg/storage/session_store.go) NewSessionStore() -> storageMode: %v \n", storageMode)
+	switch storageMode {
+	case StoreInMemory:
+		return &SessionStoreInMemory{
+			sessions: make(map[uuid.UUID]*can.Session),
+		}
+	case StoreYMLFile:
+		ssyf := &SessionStoreYMLFile{
+			sessions: make(map[uuid.UUID]*can.Session),
+		}
+		data, err := os.ReadFile(fmt.Sprintf("%s/%s", os.Getenv("WITS_DIR"), sessionsFile))
+		if err != nil {
+			if os.IsNotExist(err) {
+				log.Println("ℹ️  💾  (pkg/storage/session_store.go) 🗒️  Session file not existing. Returning new empty store.")
+				return ssyf
+			}
+		}
+		err = yaml.Unmarshal(data, ssyf.sessions)
+		if err != nil {
+			log.Printf("🚨 💾  (pkg/storage/session_store.go) 🗒️  Failed unmarshal session data with error: %v. Returning new empty store. \n", err)
+			return ssyf
+		}
+		log.Printf("✅ 💾  (pkg/storage/session_store.go) NewSessionStore() -> len(sessions): %v \n", len(ssyf.sessions))
+		return ssyf
+	}
+	return nil
+}
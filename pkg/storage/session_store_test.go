@@ -0,0 +1,72 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	can "github.com/TheDonDope/wits-tui/pkg/cannabis"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testSession generates a consistent test session with fixed values
+func testSession() *can.Session {
+	testUUID := uuid.MustParse("8da7b810-9dad-11d1-80b4-00c04fd430c8")
+	testTime := time.Date(2023, time.October, 5, 20, 0, 0, 0, time.UTC)
+
+	return &can.Session{
+		ID:        testUUID,
+		Strain:    "Test Strain",
+		Grams:     0.2,
+		StartedAt: testTime,
+		Notes:     "Test Notes",
+		CreatedAt: testTime,
+		Symptoms:  []*can.SymptomScore{{Symptom: "pain", Before: 7, After: 3}},
+		Effects:   []string{"relaxed"},
+	}
+}
+
+// TestSessionStores runs all tests for both session store implementations
+func TestSessionStores(t *testing.T) {
+	stores := map[string]func(t *testing.T) SessionStore{
+		"InMemory": func(t *testing.T) SessionStore {
+			t.Setenv("STORAGE_MODE", StoreInMemory)
+			return NewSessionStore()
+		},
+		"YMLFile": func(t *testing.T) SessionStore {
+			t.Setenv("STORAGE_MODE", StoreYMLFile)
+			t.Setenv("WITS_DIR", t.TempDir())
+			return NewSessionStore()
+		},
+	}
+
+	for name, newStore := range stores {
+		t.Run(name, func(t *testing.T) {
+			store := newStore(t)
+			assert.Empty(t, store.GetSessions())
+
+			later := testSession()
+			later.ID = uuid.New()
+			later.StartedAt = later.StartedAt.Add(time.Hour)
+			require.NoError(t, store.AddSession(later))
+			require.NoError(t, store.AddSession(testSession()))
+			assert.ErrorIs(t, store.AddSession(testSession()), ErrSessionAlreadyExists)
+
+			sessions := store.GetSessions()
+			require.Len(t, sessions, 2)
+			assert.Equal(t, testSession().ID, sessions[0].ID)
+		})
+	}
+
+	t.Run("Persistence", func(t *testing.T) {
+		t.Setenv("STORAGE_MODE", StoreYMLFile)
+		t.Setenv("WITS_DIR", t.TempDir())
+		require.NoError(t, NewSessionStore().AddSession(testSession()))
+
+		sessions := NewSessionStore().GetSessions()
+
+		require.Len(t, sessions, 1)
+		assert.Equal(t, testSession(), sessions[0])
+	})
+}
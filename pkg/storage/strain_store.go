@@ -4,7 +4,10 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"maps"
 	"os"
+	"slices"
+	"sort"
 	"sync"
 
 	can "github.com/TheDonDope/wits-tui/pkg/cannabis"
@@ -18,11 +21,14 @@ var (
 	ErrStrainNotFound = errors.New("Strain with that product name not found")
 	// ErrStrainAlreadyExists is returned when a strain with the same product name already exists in the store.
 	ErrStrainAlreadyExists = errors.New("Strain with that product name already exists")
+	// ErrStrainFileUnreadable is returned when changing a store whose strains file could not be read, so that the file is not overwritten.
+	ErrStrainFileUnreadable = errors.New("Strain file could not be read")
 )
 
 // StrainStore is an interface for storing strains.
 type StrainStore interface {
 	AddStrain(s *can.Strain) error
+	UpdateStrain(s *can.Strain) error
 	GetStrains() []*can.Strain
 	FindStrainByProduct(p string) (*can.Strain, error)
 }
@@ -48,6 +54,21 @@ func (ssim *StrainStoreInMemory) AddStrain(s *can.Strain) error {
 	return nil
 }
 
+// UpdateStrain replaces the strain with the same product name in the store.
+func (ssim *StrainStoreInMemory) UpdateStrain(s *can.Strain) error {
+	log.Printf("💬 💾  (pkg/storage/strain_store.go) UpdateStrain(s *can.Strain: %v) \n", s.ID)
+	ssim.mu.Lock()
+	defer ssim.mu.Unlock()
+
+	if _, exists := ssim.strains[s.Strain]; !exists {
+		log.Printf("🚨 💾  (pkg/storage/strain_store.go) 🗒️  Failed to update non existing strain: %v \n", s.ID)
+		return ErrStrainNotFound
+	}
+	ssim.strains[s.Strain] = s
+	log.Println("✅ 💾  (pkg/storage/strain_store.go) UpdateStrain()")
+	return nil
+}
+
 // GetStrains returns all strains in the store as a slice.
 func (ssim *StrainStoreInMemory) GetStrains() []*can.Strain {
 	log.Println("💬 💾  (pkg/storage/strain_store.go) GetStrains()")
@@ -58,6 +79,7 @@ func (ssim *StrainStoreInMemory) GetStrains() []*can.Strain {
 	for _, s := range ssim.strains {
 		strains = append(strains, s)
 	}
+	sortByProduct(strains)
 	log.Printf("✅ 💾  (pkg/storage/strain_store.go) GetStrains() -> len(strains): %v \n", len(strains))
 	return strains
 }
@@ -95,6 +117,7 @@ func (ssim *StrainStoreInMemory) String() string {
 type StrainStoreYMLFile struct {
 	mu      sync.Mutex
 	strains map[string]*can.Strain
+	loadErr error // The error reading the strains file, which makes the store read-only
 }
 
 // AddStrain adds a strain to the store, using its product name as the key.
@@ -103,21 +126,61 @@ func (ssyf *StrainStoreYMLFile) AddStrain(s *can.Strain) error {
 	ssyf.mu.Lock()
 	defer ssyf.mu.Unlock()
 
+	if ssyf.loadErr != nil {
+		log.Printf("🚨 💾  (pkg/storage/strain_store.go) 🗒️  Failed to add strain to unreadable strain file: %v \n", ssyf.loadErr)
+		return ssyf.loadErr
+	}
 	if _, exists := ssyf.strains[s.Strain]; exists {
 		log.Printf("🚨 💾  (pkg/storage/strain_store.go) 🗒️  Failed to add already existing strain: %v \n", s.ID)
 		return ErrStrainAlreadyExists
 	}
 	ssyf.strains[s.Strain] = s
+	log.Println("✅ 💾  (pkg/storage/strain_store.go) AddStrain()")
+	return ssyf.persist()
+}
 
+// UpdateStrain replaces the strain with the same product name in the store.
+func (ssyf *StrainStoreYMLFile) UpdateStrain(s *can.Strain) error {
+	log.Printf("💬 💾  (pkg/storage/strain_store.go) UpdateStrain(s *can.Strain: %v) \n", s.ID)
+	ssyf.mu.Lock()
+	defer ssyf.mu.Unlock()
+
+	if ssyf.loadErr != nil {
+		log.Printf("🚨 💾  (pkg/storage/strain_store.go) 🗒️  Failed to update strain in unreadable strain file: %v \n", ssyf.loadErr)
+		return ssyf.loadErr
+	}
+	if _, exists := ssyf.strains[s.Strain]; !exists {
+		log.Printf("🚨 💾  (pkg/storage/strain_store.go) 🗒️  Failed to update non existing strain: %v \n", s.ID)
+		return ErrStrainNotFound
+	}
+	ssyf.strains[s.Strain] = s
+	log.Println("✅ 💾  (pkg/storage/strain_store.go) UpdateStrain()")
+	return ssyf.persist()
+}
+
+// persist writes all strains to the strains file. The caller must hold the
+// lock.
+func (ssyf *StrainStoreYMLFile) persist() error {
 	data, err := yaml.Marshal(ssyf.strains)
 	if err != nil {
 		log.Printf("🚨 💾  (pkg/storage/strain_store.go) 🗒️  Failed to marshal strain with error: %v \n", err)
 		return err
 	}
-	log.Println("✅ 💾  (pkg/storage/strain_store.go) AddStrain()")
 	return os.WriteFile(fmt.Sprintf("%s/%s", os.Getenv("WITS_DIR"), strainsFile), data, 0644)
 }
 
+// migrate rewrites the strains file if a strain was read in the format of an
+// older version, e.g. embedding full terpenes instead of their identifiers.
+func (ssyf *StrainStoreYMLFile) migrate() {
+	if !slices.ContainsFunc(slices.Collect(maps.Values(ssyf.strains)), (*can.Strain).Legacy) {
+		return
+	}
+	log.Println("ℹ️  💾  (pkg/storage/strain_store.go) 🗒️  Migrating strain file to the current format.")
+	if err := ssyf.persist(); err != nil {
+		log.Printf("🚨 💾  (pkg/storage/strain_store.go) 🗒️  Failed to migrate strain file with error: %v \n", err)
+	}
+}
+
 // GetStrains returns all strains in the store as a slice.
 func (ssyf *StrainStoreYMLFile) GetStrains() []*can.Strain {
 	log.Println("💬 💾  (pkg/storage/strain_store.go) GetStrains()")
@@ -128,6 +191,7 @@ func (ssyf *StrainStoreYMLFile) GetStrains() []*can.Strain {
 	for _, s := range ssyf.strains {
 		strains = append(strains, s)
 	}
+	sortByProduct(strains)
 	log.Printf("✅ 💾  (pkg/storage/strain_store.go) GetStrains() -> len(strains): %v \n", len(strains))
 	return strains
 }
@@ -160,8 +224,18 @@ func (ssyf *StrainStoreYMLFile) String() string {
 	return fmt.Sprintf("StrainStoreYMLFile: len(strains): %v", len(strains))
 }
 
+// sortByProduct sorts the given strains by their product name, so that stores
+// always return strains in a stable order.
+func sortByProduct(strains []*can.Strain) {
+	sort.Slice(strains, func(i, j int) bool {
+		return strains[i].Strain < strains[j].Strain
+	})
+}
+
 // NewStrainStore returns a new StrainStore implementation depending on the
-// configured storage mode in the environment variable.
+// configured storage mode in the environment variable. If the strains file
+// exists but cannot be read, the returned store is empty and refuses all
+// changes with ErrStrainFileUnreadable, so that the file is not overwritten.
 func NewStrainStore() StrainStore {
 	storageMode := os.Getenv("STORAGE_MODE")
 	log.Printf("💬 💾  (pkg/storage/strain_store.go) NewStrainStore() -> storageMode: %v \n", storageMode)
@@ -180,12 +254,23 @@ func NewStrainStore() StrainStore {
 				log.Println("ℹ️  💾  (pkg/storage/strain_store.go) 🗒️  Strain file not existing. Returning new empty store.")
 				return ssyf
 			}
+			log.Printf("🚨 💾  (pkg/storage/strain_store.go) 🗒️  Failed to read strain file with error: %v. Returning read-only empty store. \n", err)
+			ssyf.loadErr = fmt.Errorf("%w: %v", ErrStrainFileUnreadable, err)
+			return ssyf
 		}
 		err = yaml.Unmarshal(data, ssyf.strains)
 		if err != nil {
-			log.Printf("🚨 💾  (pkg/storage/strain_store.go) 🗒️  Failed unmarshal strain data with error: %v. Returning new empty store. \n", err)
+			log.Printf("🚨 💾  (pkg/storage/strain_store.go) 🗒️  Failed unmarshal strain data with error: %v. Returning read-only empty store. \n", err)
+			ssyf.strains = make(map[string]*can.Strain)
+			ssyf.loadErr = fmt.Errorf("%w: %v", ErrStrainFileUnreadable, err)
 			return ssyf
 		}
+		for _, s := range ssyf.strains {
+			if ids := s.MissingCatalogIDs(); len(ids) > 0 {
+				log.Printf("🚨 💾  (pkg/storage/strain_store.go) 🗒️  Strain %v refers to entries missing from the catalog: %v \n", s.Strain, ids)
+			}
+		}
+		ssyf.migrate()
 		log.Printf("✅ 💾  (pkg/storage/strain_store.go) NewStrainStore() -> store: %v \n", ssyf)
 		return ssyf
 	}
@@ -4,6 +4,7 @@ import (
 	"io"
 	"log"
     "os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -32,11 +33,13 @@ func testStrain() *can.Strain {
 		Manufacturer: "Test Manufacturer",
 		Country:      "Test Country",
 		Genetic:      can.Sativa,
-		Radiated:     false,
+		Treatment:    can.Treatment{Type: can.Untreated},
 		THC:          20.0,
 		CBD:          0.5,
-		Terpenes:     []*can.Terpene{},
+		Cannabinoids: map[can.CannabinoidType]float64{can.THCA: 21.5},
+		Terpenes:     []*can.StrainTerpene{{Type: can.Limonene, Percent: 0.4}},
 		Amount:       3.5,
+		Batches:      []*can.Batch{},
 		CreatedAt:    testTime,
 		UpdatedAt:    testTime,
 	}
@@ -58,6 +61,11 @@ func TestInMemoryStore(t *testing.T) {
 		store := &StrainStoreInMemory{strains: make(map[string]*can.Strain)}
 		testFindStrainByProduct(t, store)
 	})
+
+	t.Run("UpdateStrain", func(t *testing.T) {
+		store := &StrainStoreInMemory{strains: make(map[string]*can.Strain)}
+		testUpdateStrain(t, store)
+	})
 }
 
 // TestYAMLFileStore runs all tests for the YAML file store implementation
@@ -86,6 +94,19 @@ func TestYAMLFileStore(t *testing.T) {
 		testFindStrainByProduct(t, store)
 	})
 
+	t.Run("UpdateStrain", func(t *testing.T) {
+		tempDir := t.TempDir()
+		t.Setenv("STORAGE_MODE", StoreYMLFile)
+		t.Setenv("WITS_DIR", tempDir)
+		store := NewStrainStore().(*StrainStoreYMLFile)
+		testUpdateStrain(t, store)
+
+		// Verify the update was persisted
+		persisted, err := NewStrainStore().FindStrainByProduct(testStrain().Strain)
+		require.NoError(t, err)
+		assert.Len(t, persisted.Batches, 2)
+	})
+
 	t.Run("Persistence", func(t *testing.T) {
 		tempDir := t.TempDir()
 		t.Setenv("STORAGE_MODE", StoreYMLFile)
@@ -102,6 +123,94 @@ func TestYAMLFileStore(t *testing.T) {
 		require.NoError(t, err)
 		assert.Equal(t, strain, persistedStrain)
 	})
+
+	t.Run("Migration", func(t *testing.T) {
+		tempDir := t.TempDir()
+		t.Setenv("STORAGE_MODE", StoreYMLFile)
+		t.Setenv("WITS_DIR", tempDir)
+		legacy := `Test Strain:
+    strain: Test Strain
+    cannabinoids:
+        0: 21.5
+    terpenes:
+        - name: Limonene
+          effects:
+            - anti-depressant
+          flavors:
+            - citrus
+          boilingpoint: 175
+          percent: 0.4
+`
+		require.NoError(t, os.WriteFile(filepath.Join(tempDir, strainsFile), []byte(legacy), 0644))
+
+		strain, err := NewStrainStore().FindStrainByProduct("Test Strain")
+		require.NoError(t, err)
+		assert.Equal(t, map[can.CannabinoidType]float64{can.THCA: 21.5}, strain.Cannabinoids)
+		assert.Equal(t, []*can.StrainTerpene{{Type: can.Limonene, Percent: 0.4}}, strain.Terpenes)
+
+		data, err := os.ReadFile(filepath.Join(tempDir, strainsFile))
+		require.NoError(t, err)
+		assert.Contains(t, string(data), "thca: 21.5")
+		assert.Contains(t, string(data), "- id: limonene")
+		assert.NotContains(t, string(data), "boilingpoint")
+	})
+
+	t.Run("CurrentFormat", func(t *testing.T) {
+		tempDir := t.TempDir()
+		t.Setenv("STORAGE_MODE", StoreYMLFile)
+		t.Setenv("WITS_DIR", tempDir)
+		// Formatted differently than written by the store, but no legacy format
+		current := `# edited by hand
+Test Strain: {strain: Test Strain, cannabinoids: {thca: 21.5}, terpenes: [{id: limonene, percent: 0.4}]}
+`
+		require.NoError(t, os.WriteFile(filepath.Join(tempDir, strainsFile), []byte(current), 0644))
+
+		strain, err := NewStrainStore().FindStrainByProduct("Test Strain")
+		require.NoError(t, err)
+		assert.Equal(t, []*can.StrainTerpene{{Type: can.Limonene, Percent: 0.4}}, strain.Terpenes)
+
+		data, err := os.ReadFile(filepath.Join(tempDir, strainsFile))
+		require.NoError(t, err)
+		assert.Equal(t, current, string(data))
+	})
+
+	t.Run("Unreadable", func(t *testing.T) {
+		tempDir := t.TempDir()
+		t.Setenv("STORAGE_MODE", StoreYMLFile)
+		t.Setenv("WITS_DIR", tempDir)
+		invalid := "Test Strain: [not a strain\n"
+		require.NoError(t, os.WriteFile(filepath.Join(tempDir, strainsFile), []byte(invalid), 0644))
+
+		store := NewStrainStore()
+
+		assert.Empty(t, store.GetStrains())
+		assert.ErrorIs(t, store.AddStrain(testStrain()), ErrStrainFileUnreadable)
+		data, err := os.ReadFile(filepath.Join(tempDir, strainsFile))
+		require.NoError(t, err)
+		assert.Equal(t, invalid, string(data))
+	})
+
+	t.Run("MissingTerpene", func(t *testing.T) {
+		tempDir := t.TempDir()
+		t.Setenv("STORAGE_MODE", StoreYMLFile)
+		t.Setenv("WITS_DIR", tempDir)
+		stored := `Test Strain:
+    strain: Test Strain
+    terpenes:
+        - id: unobtainium
+          percent: 0.3
+`
+		require.NoError(t, os.WriteFile(filepath.Join(tempDir, strainsFile), []byte(stored), 0644))
+		store := NewStrainStore()
+
+		strain, err := store.FindStrainByProduct("Test Strain")
+		require.NoError(t, err)
+		require.NoError(t, store.UpdateStrain(strain))
+
+		data, err := os.ReadFile(filepath.Join(tempDir, strainsFile))
+		require.NoError(t, err)
+		assert.Contains(t, string(data), "- id: unobtainium\n          percent: 0.3")
+	})
 }
 
 // testAddStrain tests strain addition functionality
@@ -137,6 +246,10 @@ func testGetStrains(t *testing.T, store StrainStore) {
 	// Verify retrieval
 	strains := store.GetStrains()
 	assert.Len(t, strains, 2)
+
+	// Verify stable order by product name
+	assert.Equal(t, "Strain 1", strains[0].Strain)
+	assert.Equal(t, "Strain 2", strains[1].Strain)
 }
 
 // testFindStrainByProduct tests product-based strain lookup
@@ -153,3 +266,22 @@ func testFindStrainByProduct(t *testing.T, store StrainStore) {
 	require.NoError(t, err)
 	assert.Equal(t, strain, found)
 }
+
+// testUpdateStrain tests strain update functionality
+func testUpdateStrain(t *testing.T, store StrainStore) {
+	strain := testStrain()
+
+	// Test not found case
+	err := store.UpdateStrain(strain)
+	assert.ErrorIs(t, err, ErrStrainNotFound)
+
+	// Add and verify update
+	require.NoError(t, store.AddStrain(strain))
+	updated := testStrain()
+	updated.AddBatch(&can.Batch{ID: uuid.New(), Number: "B-1", Grams: 10, Remaining: 10, THC: 22})
+	require.NoError(t, store.UpdateStrain(updated))
+
+	found, err := store.FindStrainByProduct(strain.Strain)
+	require.NoError(t, err)
+	assert.Len(t, found.Batches, 2)
+}
@@ -0,0 +1,219 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"sync"
+
+	can "github.com/TheDonDope/wits-tui/pkg/cannabis"
+	"github.com/google/uuid"
+	"gopkg.in/yaml.v3"
+)
+
+const tbreaksFile = "tbreaks.yml"
+
+var (
+	// ErrBreakNotFound is returned when a tolerance break is not found in the store.
+	ErrBreakNotFound = errors.New("Tolerance break with that ID not found")
+	// ErrBreakAlreadyExists is returned when a tolerance break with the same ID already exists in the store.
+	ErrBreakAlreadyExists = errors.New("Tolerance break with that ID already exists")
+)
+
+// BreakStore is an interface for storing breaks.
+type BreakStore interface {
+	AddBreak(b *can.ToleranceBreak) error
+	UpdateBreak(b *can.ToleranceBreak) error
+	GetBreaks() []*can.ToleranceBreak
+	FindBreak(id uuid.UUID) (*can.ToleranceBreak, error)
+}
+
+// BreakStoreInMemory is the in memory implementation of the
+// BreakStore interface.
+type BreakStoreInMemory struct {
+	mu     sync.Mutex
+	breaks map[uuid.UUID]*can.ToleranceBreak
+}
+
+// AddBreak adds a tolerance break to the store, using its ID as the key.
+func (bsim *BreakStoreInMemory) AddBreak(b *can.ToleranceBreak) error {
+	log.Printf("💬 💾  (pkg/storage/tbreak_store.go) AddBreak(b *can.ToleranceBreak: %v) \n", b.ID)
+	bsim.mu.Lock()
+	defer bsim.mu.Unlock()
+
+	if _, exists := bsim.breaks[b.ID]; exists {
+		log.Printf("🚨 💾  (pkg/storage/tbreak_store.go) 🗒️  Failed to add already existing tolerance break: %v \n", b.ID)
+		return ErrBreakAlreadyExists
+	}
+	bsim.breaks[b.ID] = b
+	log.Printf("✅ 💾  (pkg/storage/tbreak_store.go) AddBreak() -> len(bsim.breaks): %v \n", len(bsim.breaks))
+	return nil
+}
+
+// UpdateBreak replaces the tolerance break with the same ID in the store.
+func (bsim *BreakStoreInMemory) UpdateBreak(b *can.ToleranceBreak) error {
+	log.Printf("💬 💾  (pkg/storage/tbreak_store.go) UpdateBreak(b *can.ToleranceBreak: %v) \n", b.ID)
+	bsim.mu.Lock()
+	defer bsim.mu.Unlock()
+
+	if _, exists := bsim.breaks[b.ID]; !exists {
+		log.Printf("🚨 💾  (pkg/storage/tbreak_store.go) 🗒️  Failed to update non existing tolerance break: %v \n", b.ID)
+		return ErrBreakNotFound
+	}
+	bsim.breaks[b.ID] = b
+	log.Println("✅ 💾  (pkg/storage/tbreak_store.go) UpdateBreak()")
+	return nil
+}
+
+// GetBreaks returns all breaks in the store as a slice.
+func (bsim *BreakStoreInMemory) GetBreaks() []*can.ToleranceBreak {
+	log.Println("💬 💾  (pkg/storage/tbreak_store.go) GetBreaks()")
+	bsim.mu.Lock()
+	defer bsim.mu.Unlock()
+
+	var breaks []*can.ToleranceBreak
+	for _, b := range bsim.breaks {
+		breaks = append(breaks, b)
+	}
+	sortByStartDate(breaks)
+	log.Printf("✅ 💾  (pkg/storage/tbreak_store.go) GetBreaks() -> len(breaks): %v \n", len(breaks))
+	return breaks
+}
+
+// FindBreak finds a tolerance break in the store by its ID.
+func (bsim *BreakStoreInMemory) FindBreak(id uuid.UUID) (*can.ToleranceBreak, error) {
+	log.Printf("💬 💾  (pkg/storage/tbreak_store.go) FindBreak(id uuid.UUID: %v) \n", id)
+	bsim.mu.Lock()
+	defer bsim.mu.Unlock()
+
+	b, exists := bsim.breaks[id]
+	if !exists {
+		log.Printf("🚨 💾  (pkg/storage/tbreak_store.go) 🗒️  Tolerance break with ID %v does not exist. \n", id)
+		return nil, ErrBreakNotFound
+	}
+	log.Printf("✅ 💾  (pkg/storage/tbreak_store.go) FindBreak() -> tolerance break: %v \n", b.ID)
+	return b, nil
+}
+
+// BreakStoreYMLFile is the yaml file storage implementation of the
+// BreakStore interface.
+type BreakStoreYMLFile struct {
+	mu     sync.Mutex
+	breaks map[uuid.UUID]*can.ToleranceBreak
+}
+
+// AddBreak adds a tolerance break to the store, using its ID as the key.
+func (bsyf *BreakStoreYMLFile) AddBreak(b *can.ToleranceBreak) error {
+	log.Printf("💬 💾  (pkg/storage/tbreak_store.go) AddBreak(b *can.ToleranceBreak: %v) \n", b.ID)
+	bsyf.mu.Lock()
+	defer bsyf.mu.Unlock()
+
+	if _, exists := bsyf.breaks[b.ID]; exists {
+		log.Printf("🚨 💾  (pkg/storage/tbreak_store.go) 🗒️  Failed to add already existing tolerance break: %v \n", b.ID)
+		return ErrBreakAlreadyExists
+	}
+	bsyf.breaks[b.ID] = b
+	log.Println("✅ 💾  (pkg/storage/tbreak_store.go) AddBreak()")
+	return bsyf.persist()
+}
+
+// UpdateBreak replaces the tolerance break with the same ID in the store.
+func (bsyf *BreakStoreYMLFile) UpdateBreak(b *can.ToleranceBreak) error {
+	log.Printf("💬 💾  (pkg/storage/tbreak_store.go) UpdateBreak(b *can.ToleranceBreak: %v) \n", b.ID)
+	bsyf.mu.Lock()
+	defer bsyf.mu.Unlock()
+
+	if _, exists := bsyf.breaks[b.ID]; !exists {
+		log.Printf("🚨 💾  (pkg/storage/tbreak_store.go) 🗒️  Failed to update non existing tolerance break: %v \n", b.ID)
+		return ErrBreakNotFound
+	}
+	bsyf.breaks[b.ID] = b
+	log.Println("✅ 💾  (pkg/storage/tbreak_store.go) UpdateBreak()")
+	return bsyf.persist()
+}
+
+// persist writes all breaks to the breaks file. The caller must
+// hold the lock.
+func (bsyf *BreakStoreYMLFile) persist() error {
+	data, err := yaml.Marshal(bsyf.breaks)
+	if err != nil {
+		log.Printf("🚨 💾  (pkg/storage/tbreak_store.go) 🗒️  Failed to marshal tolerance break with error: %v \n", err)
+		return err
+	}
+	return os.WriteFile(fmt.Sprintf("%s/%s", os.Getenv("WITS_DIR"), tbreaksFile), data, 0644)
+}
+
+// GetBreaks returns all breaks in the store as a slice.
+func (bsyf *BreakStoreYMLFile) GetBreaks() []*can.ToleranceBreak {
+	log.Println("💬 💾  (pkg/storage/tbreak_store.go) GetBreaks()")
+	bsyf.mu.Lock()
+	defer bsyf.mu.Unlock()
+
+	var breaks []*can.ToleranceBreak
+	for _, b := range bsyf.breaks {
+		breaks = append(breaks, b)
+	}
+	sortByStartDate(breaks)
+	log.Printf("✅ 💾  (pkg/storage/tbreak_store.go) GetBreaks() -> len(breaks): %v \n", len(breaks))
+	return breaks
+}
+
+// FindBreak finds a tolerance break in the store by its ID.
+func (bsyf *BreakStoreYMLFile) FindBreak(id uuid.UUID) (*can.ToleranceBreak, error) {
+	log.Printf("💬 💾  (pkg/storage/tbreak_store.go) FindBreak(id uuid.UUID: %v) \n", id)
+	bsyf.mu.Lock()
+	defer bsyf.mu.Unlock()
+
+	b, exists := bsyf.breaks[id]
+	if !exists {
+		log.Printf("🚨 💾  (pkg/storage/tbreak_store.go) 🗒️  Tolerance break with ID %v does not exist. \n", id)
+		return nil, ErrBreakNotFound
+	}
+	log.Printf("✅ 💾  (pkg/storage/tbreak_store.go) FindBreak() -> tolerance break: %v \n", b.ID)
+	return b, nil
+}
+
+// sortByStartDate sorts the given breaks by their start date, latest
+// first, so that stores always return breaks in a stable order.
+func sortByStartDate(breaks []*can.ToleranceBreak) {
+	sort.Slice(breaks, func(i, j int) bool {
+		if !breaks[i].StartsAt.Equal(breaks[j].StartsAt) {
+			return breaks[i].StartsAt.After(breaks[j].StartsAt)
+		}
+		return breaks[i].ID.String() < breaks[j].ID.String()
+	})
+}
+
+// NewBreakStore returns a new BreakStore implementation depending
+// on the configured storage mode in the environment variable.
+func NewBreakStore() BreakStore {
+	storageMode := os.Getenv("STORAGE_MODE")
+	log.Printf("💬 💾  (pkg/storage/tbreak_store.go) NewBreakStore() -> storageMode: %v \n", storageMode)
+	switch storageMode {
+	case StoreInMemory:
+		return &BreakStoreInMemory{
+			breaks: make(map[uuid.UUID]*can.ToleranceBreak),
+		}
+	case StoreYMLFile:
+		bsyf := &BreakStoreYMLFile{
+			breaks: make(map[uuid.UUID]*can.ToleranceBreak),
+		}
+		data, err := os.ReadFile(fmt.Sprintf("%s/%s", os.Getenv("WITS_DIR"), tbreaksFile))
+		if err != nil {
+			if os.IsNotExist(err) {
+				log.Println("ℹ️  💾  (pkg/storage/tbreak_store.go) 🗒️  Tolerance break file not existing. Returning new empty store.")
+				return bsyf
+			}
+		}
+		err = yaml.Unmarshal(data, bsyf.breaks)
+		if err != nil {
+			log.Printf("🚨 💾  (pkg/storage/tbreak_store.go) 🗒️  Failed unmarshal tolerance break data with error: %v. Returning new empty store. \n", err)
+			return bsyf
+		}
+		log.Printf("✅ 💾  (pkg/storage/tbreak_store.go) NewBreakStore() -> len(breaks): %v \n", len(bsyf.breaks))
+		return bsyf
+	}
+	return nil
+}
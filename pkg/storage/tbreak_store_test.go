@@ -0,0 +1,104 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	can "github.com/TheDonDope/wits-tui/pkg/cannabis"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testBreak generates a consistent test tolerance break with fixed values
+func testBreak() *can.ToleranceBreak {
+	testUUID := uuid.MustParse("8da7b810-9dad-11d1-80b4-00c04fd430c8")
+	testTime := time.Date(2023, time.October, 5, 0, 0, 0, 0, time.UTC)
+
+	return &can.ToleranceBreak{
+		ID:          testUUID,
+		StartsAt:    testTime,
+		Days:        14,
+		TaperDays:   3,
+		BaselineTHC: 40,
+		CheckIns: []*can.CheckIn{
+			{Day: testTime, Abstained: true, Craving: 6, Notes: "Restless"},
+		},
+		CreatedAt: testTime,
+	}
+}
+
+// TestBreakStores runs all tests for both tolerance break store
+// implementations
+func TestBreakStores(t *testing.T) {
+	stores := map[string]func(t *testing.T) BreakStore{
+		"InMemory": func(t *testing.T) BreakStore {
+			t.Setenv("STORAGE_MODE", StoreInMemory)
+			return NewBreakStore()
+		},
+		"YMLFile": func(t *testing.T) BreakStore {
+			t.Setenv("STORAGE_MODE", StoreYMLFile)
+			t.Setenv("WITS_DIR", t.TempDir())
+			return NewBreakStore()
+		},
+	}
+
+	for name, newStore := range stores {
+		t.Run(name, func(t *testing.T) {
+			t.Run("AddBreak", func(t *testing.T) {
+				store := newStore(t)
+				b := testBreak()
+
+				require.NoError(t, store.AddBreak(b))
+				assert.ErrorIs(t, store.AddBreak(b), ErrBreakAlreadyExists)
+				assert.Len(t, store.GetBreaks(), 1)
+			})
+
+			t.Run("GetBreaks", func(t *testing.T) {
+				store := newStore(t)
+				assert.Empty(t, store.GetBreaks())
+
+				older := testBreak()
+				newer := testBreak()
+				newer.ID = uuid.New()
+				newer.StartsAt = older.StartsAt.AddDate(0, 2, 0)
+				require.NoError(t, store.AddBreak(older))
+				require.NoError(t, store.AddBreak(newer))
+
+				breaks := store.GetBreaks()
+				require.Len(t, breaks, 2)
+				assert.Equal(t, newer.ID, breaks[0].ID)
+			})
+
+			t.Run("FindAndUpdateBreak", func(t *testing.T) {
+				store := newStore(t)
+				b := testBreak()
+
+				_, err := store.FindBreak(b.ID)
+				assert.ErrorIs(t, err, ErrBreakNotFound)
+				assert.ErrorIs(t, store.UpdateBreak(b), ErrBreakNotFound)
+
+				require.NoError(t, store.AddBreak(b))
+				updated := testBreak()
+				updated.Days = 21
+				require.NoError(t, store.UpdateBreak(updated))
+
+				found, err := store.FindBreak(b.ID)
+				require.NoError(t, err)
+				assert.Equal(t, 21, found.Days)
+			})
+		})
+	}
+
+	t.Run("Persistence", func(t *testing.T) {
+		t.Setenv("STORAGE_MODE", StoreYMLFile)
+		t.Setenv("WITS_DIR", t.TempDir())
+		b := testBreak()
+		require.NoError(t, NewBreakStore().AddBreak(b))
+
+		persisted, err := NewBreakStore().FindBreak(b.ID)
+
+		require.NoError(t, err)
+		assert.Equal(t, b, persisted)
+	})
+}
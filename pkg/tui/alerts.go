@@ -0,0 +1,69 @@
+package tui
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type alertLevel int
+
+const (
+	warningAlert alertLevel = iota
+	errorAlert
+	reminderAlert
+)
+
+// alert is a message shown to the user in an AlertBarModel.
+type alert struct {
+	level alertLevel
+	text  string
+}
+
+// AlertBarModel is a tea.Model rendering a status line followed by warnings
+// and errors as a bar.
+type AlertBarModel struct {
+	styles *Styles
+	status string
+	alerts []alert
+}
+
+// initialAlertBarModel creates a new alert bar without any alerts.
+func initialAlertBarModel() *AlertBarModel {
+	return &AlertBarModel{styles: NewStyles(lipgloss.DefaultRenderer())}
+}
+
+// AlertBarModel implementation of tea.Model interface -------------------------
+
+// Init is the first function that will be called. It returns an optional
+// initial command. To not perform an initial command return nil.
+func (abm *AlertBarModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update is called when a message is received. Use it to inspect messages
+// and, in response, update the model and/or send a command.
+func (abm *AlertBarModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	return abm, nil
+}
+
+// View renders the AlertBarModel UI, which is just a string. The view is
+// rendered after every Update.
+func (abm *AlertBarModel) View() string {
+	var lines []string
+	if abm.status != "" {
+		lines = append(lines, abm.styles.StatusHeader.Render(abm.status))
+	}
+	for _, a := range abm.alerts {
+		switch a.level {
+		case errorAlert:
+			lines = append(lines, abm.styles.ErrorHeaderText.Render("⛔ "+a.text))
+		case reminderAlert:
+			lines = append(lines, abm.styles.Highlight.Render("🔔 "+a.text))
+		default:
+			lines = append(lines, abm.styles.Highlight.Render("⚠️  "+a.text))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
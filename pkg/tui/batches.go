@@ -0,0 +1,330 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	can "github.com/TheDonDope/wits-tui/pkg/cannabis"
+	"github.com/TheDonDope/wits-tui/pkg/i18n"
+	"github.com/TheDonDope/wits-tui/pkg/service"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/huh"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/google/uuid"
+)
+
+// dateLayout is the layout of dates entered in forms.
+const dateLayout = "2006-01-02"
+
+// onBatchAdded runs the form to add a batch to the given strain and on
+// submission sends a message with the parsed batch data from the form. If
+// prescription options are given, the prescription the batch is dispensed on
+// can be selected. The given pharmacy names are suggested.
+func onBatchAdded(strain *can.Strain, prescriptions []huh.Option[uuid.UUID], pharmacies []string) tea.Cmd {
+	form := initialBatchForm(strain, prescriptions, pharmacies)
+
+	if err := form.Run(); err != nil {
+		fmt.Fprint(os.Stderr, i18n.T("Error running batch creation form: %v\n", err))
+		return nil
+	}
+
+	batch := parseBatch(form)
+	if batch == nil {
+		return nil
+	}
+	product := strain.Strain
+	return func() tea.Msg { return batchSubmittedMsg{product: product, batch: batch} }
+}
+
+// initialBatchForm returns a form for adding a batch to the given strain,
+// offering the given prescription options if any and suggesting the given
+// pharmacy names.
+func initialBatchForm(strain *can.Strain, prescriptions []huh.Option[uuid.UUID], pharmacies []string) *huh.Form {
+	fields := []huh.Field{
+		huh.NewNote().
+			Title(i18n.T("New batch of %s", strain.Strain)),
+
+		huh.NewInput().
+			Key("number").
+			Title(i18n.T("Batch number")).
+			Description(i18n.T("The batch number as printed on the package")),
+
+		huh.NewInput().
+			Key("purchased").
+			Title(i18n.T("Purchase date")).
+			Description(i18n.T("The purchase date (YYYY-MM-DD)")).
+			Value(ptr(time.Now().Format(dateLayout))).
+			Validate(validateDate),
+
+		huh.NewInput().
+			Key("pharmacy").
+			Title(i18n.T("Pharmacy")).
+			Description(i18n.T("The dispensing pharmacy")).
+			Suggestions(pharmacies),
+
+		huh.NewInput().
+			Key("price").
+			Title(i18n.T("Price")).
+			Description(i18n.T("The price paid for the batch")).
+			Validate(validateFloat),
+
+		huh.NewInput().
+			Key("grams").
+			Title(i18n.T("Amount (g)")).
+			Description(i18n.T("The purchased weight")).
+			Validate(validateFloat),
+
+		huh.NewInput().
+			Key("thc").
+			Title(i18n.T("THC (%)")).
+			Description(i18n.T("The tested THC content")).
+			Validate(validateFloat),
+
+		huh.NewInput().
+			Key("cbd").
+			Title(i18n.T("CBD (%)")).
+			Description(i18n.T("The tested CBD content")).
+			Validate(validateFloat),
+
+		huh.NewInput().
+			Key("expires").
+			Title(i18n.T("Best before")).
+			Description(i18n.T("The expiry date (YYYY-MM-DD), leave empty if unknown")).
+			Validate(validateDate),
+	}
+	if len(prescriptions) > 0 {
+		fields = append(fields, huh.NewSelect[uuid.UUID]().
+			Key("prescription").
+			Options(prescriptions...).
+			Title(i18n.T("Prescription")).
+			Description(i18n.T("The prescription the batch is dispensed on")))
+	}
+	return huh.NewForm(huh.NewGroup(fields...)).WithTheme(formTheme())
+}
+
+// ptr returns a pointer to the given value.
+func ptr[T any](v T) *T {
+	return &v
+}
+
+// validateDate returns an error if the given non-empty input is no date.
+func validateDate(input string) error {
+	if strings.TrimSpace(input) == "" {
+		return nil
+	}
+	if _, err := time.Parse(dateLayout, strings.TrimSpace(input)); err != nil {
+		return errors.New(i18n.T("Please enter a date as YYYY-MM-DD"))
+	}
+	return nil
+}
+
+// validateFloat returns an error if the given non-empty input is no number.
+func validateFloat(input string) error {
+	if strings.TrimSpace(input) == "" {
+		return nil
+	}
+	if _, err := i18n.ParseFloat(input); err != nil {
+		return errors.New(i18n.T("Please enter a number"))
+	}
+	return nil
+}
+
+// parseDate parses the given input as a date. If the input is empty or
+// invalid the zero time is returned.
+func parseDate(input string) time.Time {
+	t, err := time.ParseInLocation(dateLayout, strings.TrimSpace(input), time.Local)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+// parseBatch creates a new batch entity from the given form data.
+func parseBatch(form *huh.Form) *can.Batch {
+	grams := parseFloatWithDefault(form.GetString("grams"), 0)
+	var prescription uuid.UUID
+	if val, ok := form.Get("prescription").(uuid.UUID); ok {
+		prescription = val
+	}
+	return &can.Batch{
+		ID:             uuid.New(),
+		Number:         form.GetString("number"),
+		PurchasedAt:    parseDate(form.GetString("purchased")),
+		Pharmacy:       form.GetString("pharmacy"),
+		Price:          parseFloatWithDefault(form.GetString("price"), 0),
+		Grams:          grams,
+		Remaining:      grams,
+		THC:            parseFloatWithDefault(form.GetString("thc"), 0),
+		CBD:            parseFloatWithDefault(form.GetString("cbd"), 0),
+		ExpiresAt:      parseDate(form.GetString("expires")),
+		PrescriptionID: prescription,
+	}
+}
+
+// StrainPreviewModel is a tea.Model rendering the details, recipes and batches
+// of the selected strain, the strains most similar to it or the lineage of its
+// cultivar.
+type StrainPreviewModel struct {
+	styles      *Styles
+	strain      *can.Strain
+	cultivar    *can.Cultivar
+	recipes     map[uuid.UUID][]*can.Recipe
+	showSimilar bool
+	similar     []service.StrainSimilarity
+	showLineage bool
+	lineage     *service.LineageNode
+}
+
+// initialStrainPreviewModel creates a new preview without a selected strain.
+func initialStrainPreviewModel() *StrainPreviewModel {
+	return &StrainPreviewModel{styles: NewStyles(lipgloss.DefaultRenderer())}
+}
+
+// StrainPreviewModel implementation of tea.Model interface --------------------
+
+// Init is the first function that will be called. It returns an optional
+// initial command. To not perform an initial command return nil.
+func (spm *StrainPreviewModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update is called when a message is received. Use it to inspect messages
+// and, in response, update the model and/or send a command.
+func (spm *StrainPreviewModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	return spm, nil
+}
+
+// View renders the StrainPreviewModel UI, which is just a string. The view is
+// rendered after every Update.
+func (spm *StrainPreviewModel) View() string {
+	if spm.strain == nil {
+		return ""
+	}
+	s := spm.styles
+	var b strings.Builder
+	b.WriteString(s.StatusHeader.Render(spm.strain.Strain) + "\n")
+	b.WriteString(i18n.T("Amount: %s g, THC/CBD: %s%% / %s%%, Genetic: %s",
+		i18n.FormatFloat(spm.strain.Amount, 1),
+		i18n.FormatFloat(spm.strain.THC, 1),
+		i18n.FormatFloat(spm.strain.CBD, 1),
+		i18n.Term(can.Genetics[spm.strain.Genetic])) + "\n")
+	if spm.strain.Cultivar != "" {
+		cultivar := spm.strain.Cultivar
+		if genetics := cultivarGenetics(spm.cultivar); genetics != "" {
+			cultivar += " (" + genetics + ")"
+		}
+		b.WriteString(i18n.T("Cultivar: %s", cultivar) + "\n")
+	}
+	b.WriteString(i18n.T("Treatment: %s", treatmentLabel(spm.strain.Treatment)) + "\n")
+	if len(spm.strain.Cannabinoids) > 0 {
+		b.WriteString(i18n.T("Cannabinoids: %s", cannabinoidProfile(spm.strain)) + "\n")
+	}
+	if len(spm.strain.Terpenes) > 0 {
+		b.WriteString(i18n.T("Terpenes: %s", terpeneProfile(spm.strain)) + "\n")
+	}
+	if avg := spm.strain.AverageRatings(); avg.Count > 0 {
+		if aspects := ratingAspects(avg); aspects != "" {
+			b.WriteString(i18n.T("Rating: %s, %d ratings", aspects, avg.Count) + "\n")
+		}
+		if tags := spm.strain.Tags(); len(tags) > 0 {
+			b.WriteString(i18n.T("Tags: %s", strings.Join(tags, ", ")) + "\n")
+		}
+		if notes := latestNotes(spm.strain); notes != "" {
+			b.WriteString(i18n.T("Tasting notes: %s", notes) + "\n")
+		}
+	}
+	if perGram, ok := spm.strain.CostPerGram(); ok {
+		perMg, _ := spm.strain.CostPerMgTHC()
+		b.WriteString(i18n.T("Spent: %s, %s per g, %s per mg THC",
+			i18n.FormatMoney(spm.strain.Spent(), currency()),
+			i18n.FormatMoney(perGram, currency()),
+			i18n.FormatFloat(perMg, 4)+" "+currency()) + "\n")
+	}
+	if recipes := spm.recipes[spm.strain.ID]; len(recipes) > 0 {
+		b.WriteString(i18n.T("Recipes: %s", recipeNames(recipes)) + "\n")
+	}
+	if spm.showSimilar {
+		b.WriteString("\n" + s.Highlight.Render(i18n.T("Similar strains")) + "\n")
+		lines := similarStrainLines(spm.similar)
+		if len(lines) == 0 {
+			lines = []string{i18n.T("No other strains to compare.")}
+		}
+		b.WriteString(strings.Join(lines, "\n"))
+		return s.Status.Render(b.String())
+	}
+	if spm.showLineage {
+		b.WriteString("\n" + s.Highlight.Render(i18n.T("Lineage")) + "\n")
+		if spm.lineage == nil {
+			b.WriteString(i18n.T("The strain has no cultivar."))
+		} else {
+			b.WriteString(strings.Join(lineageLines(spm.lineage), "\n"))
+		}
+		return s.Status.Render(b.String())
+	}
+	if len(spm.strain.Batches) == 0 {
+		b.WriteString(s.Help.Render(i18n.T("No batches, press %s to add one.", keys.AddBatch.Help().Key)))
+		return s.Status.Render(b.String())
+	}
+	b.WriteString("\n" + s.Highlight.Render(i18n.T("Batches")) + "\n")
+	now := time.Now()
+	for _, batch := range spm.strain.Batches {
+		b.WriteString(batchLine(batch, now) + "\n")
+	}
+	return s.Status.Render(strings.TrimSuffix(b.String(), "\n"))
+}
+
+// cannabinoidProfile returns the lab tested cannabinoids of the given strain,
+// highest content first.
+func cannabinoidProfile(strain *can.Strain) string {
+	var parts []string
+	for _, c := range strain.SortedCannabinoids() {
+		parts = append(parts, fmt.Sprintf("%s %s%%",
+			can.Cannabinoids[c].ShortName,
+			i18n.FormatFloat(strain.Cannabinoids[c], 1)))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// terpeneProfile returns the terpenes of the given strain, highest content
+// first. Terpenes of unknown content are listed by name only.
+func terpeneProfile(strain *can.Strain) string {
+	var parts []string
+	for _, t := range strain.SortedTerpenes() {
+		part := i18n.Term(t.Name())
+		if t.Percent > 0 {
+			part += " " + i18n.FormatFloat(t.Percent, 2) + "%"
+		}
+		parts = append(parts, part)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// batchLine returns the summary of the given batch as a single line.
+func batchLine(b *can.Batch, now time.Time) string {
+	number := b.Number
+	if number == "" {
+		number = "–"
+	}
+	line := i18n.T("%s: %s / %s g, THC/CBD: %s%% / %s%%",
+		number,
+		i18n.FormatFloat(b.Remaining, 1),
+		i18n.FormatFloat(b.Grams, 1),
+		i18n.FormatFloat(b.THC, 1),
+		i18n.FormatFloat(b.CBD, 1))
+	if b.Pharmacy != "" {
+		line += ", " + b.Pharmacy
+	}
+	if b.Price > 0 {
+		line += ", " + i18n.FormatMoney(b.Price, currency())
+	}
+	if !b.ExpiresAt.IsZero() {
+		line += ", " + i18n.T("best before %s", b.ExpiresAt.Format(dateLayout))
+	}
+	if b.Expired(now) {
+		line += " ⚠️ " + i18n.T("expired")
+	}
+	return line
+}
@@ -0,0 +1,62 @@
+package tui
+
+import (
+	"testing"
+	"time"
+
+	can "github.com/TheDonDope/wits-tui/pkg/cannabis"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateDate(t *testing.T) {
+	assert.NoError(t, validateDate(""))
+	assert.NoError(t, validateDate("2024-05-17"))
+	assert.Error(t, validateDate("17.05.2024"))
+}
+
+func TestValidateFloat(t *testing.T) {
+	assert.NoError(t, validateFloat(""))
+	assert.NoError(t, validateFloat("12.5"))
+	assert.Error(t, validateFloat("twelve"))
+}
+
+func TestParseDate(t *testing.T) {
+	assert.True(t, parseDate("").IsZero())
+	assert.Equal(t, time.Date(2024, 5, 17, 0, 0, 0, 0, time.Local), parseDate("2024-05-17"))
+}
+
+func TestStrainPreviewModel_View(t *testing.T) {
+	t.Run("NoStrain", func(t *testing.T) {
+		spm := initialStrainPreviewModel()
+		assert.Empty(t, spm.View())
+	})
+
+	t.Run("Batches", func(t *testing.T) {
+		strain := &can.Strain{ID: uuid.New(), Strain: "Pink Kush"}
+		strain.AddBatch(&can.Batch{Number: "B-123", Grams: 10, Remaining: 5, THC: 22,
+			ExpiresAt: time.Now().AddDate(0, 0, -1)})
+		spm := initialStrainPreviewModel()
+		spm.strain = strain
+
+		view := spm.View()
+
+		assert.Contains(t, view, "Pink Kush")
+		assert.Contains(t, view, "B-123")
+		assert.Contains(t, view, "expired")
+	})
+	t.Run("Profile", func(t *testing.T) {
+		strain := &can.Strain{ID: uuid.New(), Strain: "Pink Kush",
+			Cannabinoids: map[can.CannabinoidType]float64{can.CBG: 0.8, can.THCA: 22.1},
+			Terpenes: []*can.StrainTerpene{
+				{Type: can.Limonene},
+				{Type: can.BetaMyrcene, Percent: 0.75}}}
+		spm := initialStrainPreviewModel()
+		spm.strain = strain
+
+		view := spm.View()
+
+		assert.Contains(t, view, "Cannabinoids: THCA 22.1%, CBG 0.8%")
+		assert.Contains(t, view, "Terpenes: β-Myrcene 0.75%, Limonene")
+	})
+}
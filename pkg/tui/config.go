@@ -0,0 +1,116 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	can "github.com/TheDonDope/wits-tui/pkg/cannabis"
+	"github.com/TheDonDope/wits-tui/pkg/i18n"
+	"github.com/TheDonDope/wits-tui/pkg/service"
+	"github.com/TheDonDope/wits-tui/pkg/settings"
+)
+
+// userSettings are the settings applied by the last call to Configure.
+var userSettings = settings.Default()
+
+// Configure applies the given user settings to the tui. It returns an error
+// if the settings are invalid, e.g. if the configured keybindings conflict or
+// the configured theme or locale does not exist.
+func Configure(s *settings.Settings) error {
+	log.Println("💬 💾  (pkg/tui/config.go) Configure()")
+	// The locale is applied first, as the keys contain translated help texts
+	if err := i18n.UseLocale(i18n.Locale(s.Locale)); err != nil {
+		log.Printf("🚨 💾  (pkg/tui/config.go) 🗒️  Failed to apply locale with error: %v \n", err)
+		return err
+	}
+	km, err := NewKeyMap(s.Keybindings)
+	if err != nil {
+		log.Printf("🚨 💾  (pkg/tui/config.go) 🗒️  Failed to apply keybindings with error: %v \n", err)
+		return err
+	}
+	if conflicts := km.Conflicts(); len(conflicts) > 0 {
+		var errs []error
+		for _, c := range conflicts {
+			errs = append(errs, c)
+		}
+		err := errors.Join(errs...)
+		log.Printf("🚨 💾  (pkg/tui/config.go) 🗒️  Conflicting keybindings: %v \n", err)
+		return err
+	}
+	t, err := resolveTheme(s.Appearance.Theme)
+	if err != nil {
+		log.Printf("🚨 💾  (pkg/tui/config.go) 🗒️  Failed to apply theme with error: %v \n", err)
+		return err
+	}
+	if _, err := service.ParseStrainSortOrder(s.Strains.SortOrder); err != nil {
+		log.Printf("🚨 💾  (pkg/tui/config.go) 🗒️  Failed to apply sort order with error: %v \n", err)
+		return err
+	}
+	if err := validateStrainColumns(s.Strains.Columns); err != nil {
+		log.Printf("🚨 💾  (pkg/tui/config.go) 🗒️  Failed to apply strains table columns with error: %v \n", err)
+		return err
+	}
+	if err := validateCurrency(s.Currency); err != nil {
+		log.Printf("🚨 💾  (pkg/tui/config.go) 🗒️  Failed to apply currency with error: %v \n", err)
+		return err
+	}
+	if _, err := parseBioavailability(s.Dosage.Bioavailability); err != nil {
+		log.Printf("🚨 💾  (pkg/tui/config.go) 🗒️  Failed to apply bioavailability with error: %v \n", err)
+		return err
+	}
+	keys = km
+	theme = t
+	userSettings = s
+	log.Println("✅ 💾  (pkg/tui/config.go) Configure()")
+	return nil
+}
+
+// validateCurrency returns an error if the given non-empty currency is no
+// three letter ISO 4217 code.
+func validateCurrency(c string) error {
+	if c == "" {
+		return nil
+	}
+	if len(c) != 3 || strings.ToUpper(c) != c || strings.Trim(c, "ABCDEFGHIJKLMNOPQRSTUVWXYZ") != "" {
+		return fmt.Errorf("invalid currency %q, expected an ISO 4217 code like EUR", c)
+	}
+	return nil
+}
+
+// currency returns the configured currency code.
+func currency() string {
+	if userSettings.Currency == "" {
+		return settings.DefaultCurrency
+	}
+	return userSettings.Currency
+}
+
+// parseBioavailability returns the given bioavailability overrides keyed by
+// their consumption method. It returns an error if a method is unknown or a
+// factor is not within (0, 1].
+func parseBioavailability(b map[string]float64) (map[can.ConsumptionMethod]float64, error) {
+	parsed := map[can.ConsumptionMethod]float64{}
+	for name, f := range b {
+		m, err := can.ParseConsumptionMethod(name)
+		if err != nil {
+			return nil, err
+		}
+		if f <= 0 || f > 1 {
+			return nil, fmt.Errorf("invalid bioavailability %v for %s, expected a factor between 0 and 1", f, name)
+		}
+		parsed[m] = f
+	}
+	return parsed, nil
+}
+
+// dosageCalculator returns a dosage calculator applying the configured
+// bioavailability.
+func dosageCalculator() *can.DosageCalculator {
+	b, err := parseBioavailability(userSettings.Dosage.Bioavailability)
+	if err != nil {
+		return can.NewDosageCalculator(nil)
+	}
+	return can.NewDosageCalculator(b)
+}
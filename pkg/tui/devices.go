@@ -1,6 +1,8 @@
 package tui
 
 import (
+	"github.com/TheDonDope/wits-tui/pkg/i18n"
+	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 )
 
@@ -32,7 +34,7 @@ func initialDevicesHomeModel() *DevicesHomeModel {
 	d := &DevicesHomeModel{
 		hm: initialHomeModel(),
 	}
-	d.hm.Title(breadcrumbTitle(d.hm.title, devicesTitle))
+	d.hm.Title(breadcrumbTitle(d.hm.title, i18n.T(devicesTitle)))
 	return d
 }
 
@@ -49,10 +51,10 @@ func (dhm *DevicesHomeModel) Init() tea.Cmd {
 func (dhm *DevicesHomeModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "q", "ctrl+c":
+		switch {
+		case key.Matches(msg, keys.Quit):
 			return dhm, tea.Quit
-		case "esc":
+		case key.Matches(msg, keys.Back):
 			return InitialMenuModel(), nil
 		}
 	}
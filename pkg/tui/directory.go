@@ -0,0 +1,232 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	can "github.com/TheDonDope/wits-tui/pkg/cannabis"
+	"github.com/TheDonDope/wits-tui/pkg/i18n"
+	"github.com/TheDonDope/wits-tui/pkg/service"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/huh"
+	"github.com/google/uuid"
+)
+
+type directorySubmittedMsg struct {
+	manufacturer *can.Manufacturer
+	pharmacy     *can.Pharmacy
+}
+
+// directoryChoice is an entry of the directory to edit, or a new one if it has
+// no ID.
+type directoryChoice struct {
+	pharmacy bool
+	id       uuid.UUID
+}
+
+// directoryEntry holds the fields shared by manufacturers and pharmacies, as
+// edited in the directory form.
+type directoryEntry struct {
+	name, aliases, country, website, notes string
+}
+
+// onDirectoryEdited runs the form to choose an entry of the given directory,
+// preselecting the manufacturer of the given name, and then the form to edit
+// it. On submission it sends a message with the edited entry.
+func onDirectoryEdited(manufacturers []*can.Manufacturer, pharmacies []*can.Pharmacy, manufacturer string) tea.Cmd {
+	var choice directoryChoice
+	for _, m := range manufacturers {
+		if m.Matches(manufacturer) {
+			choice.id = m.ID
+		}
+	}
+	if err := initialDirectoryChoiceForm(manufacturers, pharmacies, &choice).Run(); err != nil {
+		fmt.Fprint(os.Stderr, i18n.T("Error running directory form: %v\n", err))
+		return nil
+	}
+
+	var entry directoryEntry
+	var title string
+	m := &can.Manufacturer{}
+	p := &can.Pharmacy{}
+	switch {
+	case choice.pharmacy:
+		title = i18n.T("New pharmacy")
+		for _, found := range pharmacies {
+			if found.ID == choice.id {
+				p, title = found, i18n.T("Pharmacy %s", found.Name)
+				entry = directoryEntry{found.Name, strings.Join(found.Aliases, ", "), countryLabel(found.Country), found.Website, found.Notes}
+			}
+		}
+	default:
+		title = i18n.T("New manufacturer")
+		for _, found := range manufacturers {
+			if found.ID == choice.id {
+				m, title = found, i18n.T("Manufacturer %s", found.Name)
+				entry = directoryEntry{found.Name, strings.Join(found.Aliases, ", "), countryLabel(found.Country), found.Website, found.Notes}
+			}
+		}
+	}
+	if err := initialDirectoryForm(title, &entry).Run(); err != nil {
+		fmt.Fprint(os.Stderr, i18n.T("Error running directory form: %v\n", err))
+		return nil
+	}
+
+	if choice.pharmacy {
+		edited := *p
+		entry.apply(&edited.Name, &edited.Aliases, &edited.Country, &edited.Website, &edited.Notes)
+		return func() tea.Msg { return directorySubmittedMsg{pharmacy: &edited} }
+	}
+	edited := *m
+	entry.apply(&edited.Name, &edited.Aliases, &edited.Country, &edited.Website, &edited.Notes)
+	return func() tea.Msg { return directorySubmittedMsg{manufacturer: &edited} }
+}
+
+// initialDirectoryChoiceForm returns a form to choose one of the given
+// manufacturers or pharmacies, or to add a new one.
+func initialDirectoryChoiceForm(manufacturers []*can.Manufacturer, pharmacies []*can.Pharmacy, choice *directoryChoice) *huh.Form {
+	options := []huh.Option[directoryChoice]{
+		huh.NewOption("➕ "+i18n.T("New manufacturer"), directoryChoice{}),
+		huh.NewOption("➕ "+i18n.T("New pharmacy"), directoryChoice{pharmacy: true}),
+	}
+	for _, m := range manufacturers {
+		options = append(options, huh.NewOption("🏭 "+m.Name, directoryChoice{id: m.ID}))
+	}
+	for _, p := range pharmacies {
+		options = append(options, huh.NewOption("💊 "+p.Name, directoryChoice{pharmacy: true, id: p.ID}))
+	}
+	return huh.NewForm(
+		huh.NewGroup(
+			huh.NewSelect[directoryChoice]().
+				Key("entry").
+				Options(options...).
+				Title(i18n.T("Directory")).
+				Description(i18n.T("The manufacturer or pharmacy to edit")).
+				Value(choice),
+		),
+	).WithTheme(formTheme())
+}
+
+// initialDirectoryForm returns a form with the given title for editing the
+// given directory entry.
+func initialDirectoryForm(title string, entry *directoryEntry) *huh.Form {
+	return huh.NewForm(
+		huh.NewGroup(
+			huh.NewNote().
+				Title(title),
+
+			huh.NewInput().
+				Key("name").
+				Title(i18n.T("Name")).
+				Validate(validateName).
+				Value(&entry.name),
+
+			huh.NewInput().
+				Key("aliases").
+				Title(i18n.T("Aliases")).
+				Description(i18n.T("Comma separated other names (e.g. Aurora Cannabis)")).
+				Value(&entry.aliases),
+
+			huh.NewInput().
+				Key("country").
+				Title(i18n.T("Country")).
+				Description(i18n.T("The country name or ISO 3166 code (e.g. CA)")).
+				Suggestions(countrySuggestions()).
+				Validate(validateCountry).
+				Value(&entry.country),
+
+			huh.NewInput().
+				Key("website").
+				Title(i18n.T("Website")).
+				Value(&entry.website),
+
+			huh.NewText().
+				Key("notes").
+				Title(i18n.T("Notes")).
+				Value(&entry.notes),
+		),
+	).WithTheme(formTheme())
+}
+
+// apply sets the given fields of a manufacturer or pharmacy to the values of
+// the entry.
+func (e directoryEntry) apply(name *string, aliases *[]string, country, website, notes *string) {
+	*name = strings.TrimSpace(e.name)
+	*aliases = nil
+	for _, a := range strings.Split(e.aliases, ",") {
+		if a = strings.TrimSpace(a); a != "" {
+			*aliases = append(*aliases, a)
+		}
+	}
+	*country = parseCountry(e.country)
+	*website = strings.TrimSpace(e.website)
+	*notes = strings.TrimSpace(e.notes)
+}
+
+// validateName returns an error if the given input is empty.
+func validateName(input string) error {
+	if strings.TrimSpace(input) == "" {
+		return errors.New(i18n.T("Please enter a name"))
+	}
+	return nil
+}
+
+// validateCountry returns an error if the given non-empty input is no country.
+func validateCountry(input string) error {
+	if strings.TrimSpace(input) == "" {
+		return nil
+	}
+	if _, ok := service.ParseCountry(input); !ok {
+		return errors.New(i18n.T("Please enter a country name or ISO 3166 code"))
+	}
+	return nil
+}
+
+// parseCountry returns the ISO 3166 code of the given country input, or the
+// trimmed input if it is no country.
+func parseCountry(input string) string {
+	if code, ok := service.ParseCountry(input); ok {
+		return code
+	}
+	return strings.TrimSpace(input)
+}
+
+// countryLabel returns the translated name of the country with the given
+// code, or the given code if it is unknown.
+func countryLabel(code string) string {
+	if name, ok := can.Countries[code]; ok {
+		return i18n.Term(name)
+	}
+	return code
+}
+
+// countrySuggestions returns the translated names of all countries, sorted.
+func countrySuggestions() []string {
+	var names []string
+	for _, name := range can.Countries {
+		names = append(names, i18n.Term(name))
+	}
+	sort.Strings(names)
+	return names
+}
+
+// manufacturerNames returns the names of the given manufacturers.
+func manufacturerNames(manufacturers []*can.Manufacturer) []string {
+	names := make([]string, len(manufacturers))
+	for i, m := range manufacturers {
+		names[i] = m.Name
+	}
+	return names
+}
+
+// pharmacyNames returns the names of the given pharmacies.
+func pharmacyNames(pharmacies []*can.Pharmacy) []string {
+	names := make([]string, len(pharmacies))
+	for i, p := range pharmacies {
+		names[i] = p.Name
+	}
+	return names
+}
@@ -0,0 +1,46 @@
+package tui
+
+import (
+	"testing"
+
+	can "github.com/TheDonDope/wits-tui/pkg/cannabis"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateCountry(t *testing.T) {
+	assert.NoError(t, validateCountry(""))
+	assert.NoError(t, validateCountry("ca"))
+	assert.NoError(t, validateCountry("Netherlands"))
+	assert.Error(t, validateCountry("Atlantis"))
+}
+
+func TestParseCountry(t *testing.T) {
+	assert.Equal(t, "CA", parseCountry(" Canada "))
+	assert.Equal(t, "NL", parseCountry("nl"))
+	assert.Equal(t, "Atlantis", parseCountry(" Atlantis"))
+	assert.Empty(t, parseCountry(""))
+}
+
+func TestCountryLabel(t *testing.T) {
+	assert.Equal(t, "Canada", countryLabel("CA"))
+	assert.Equal(t, "Atlantis", countryLabel("Atlantis"))
+}
+
+func TestDirectoryEntry_Apply(t *testing.T) {
+	m := &can.Manufacturer{Name: "Aurora", Aliases: []string{"Old"}}
+	entry := directoryEntry{name: " Aurora ", aliases: "Aurora Cannabis, , Aurora Deutschland", country: "canada", website: " https://example.com"}
+
+	entry.apply(&m.Name, &m.Aliases, &m.Country, &m.Website, &m.Notes)
+
+	assert.Equal(t, "Aurora", m.Name)
+	assert.Equal(t, []string{"Aurora Cannabis", "Aurora Deutschland"}, m.Aliases)
+	assert.Equal(t, "CA", m.Country)
+	assert.Equal(t, "https://example.com", m.Website)
+	assert.Empty(t, m.Notes)
+}
+
+func TestDirectoryNames(t *testing.T) {
+	assert.Equal(t, []string{"Aurora", "Bedrocan"}, manufacturerNames([]*can.Manufacturer{{Name: "Aurora"}, {Name: "Bedrocan"}}))
+	assert.Equal(t, []string{"Linden-Apotheke"}, pharmacyNames([]*can.Pharmacy{{Name: "Linden-Apotheke"}}))
+	assert.Empty(t, pharmacyNames(nil))
+}
@@ -4,6 +4,10 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/table"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
@@ -13,12 +17,6 @@ const (
 	homeTitle = "🥦 Wits"
 )
 
-var (
-	red    = lipgloss.AdaptiveColor{Light: "#FE5F86", Dark: "#FE5F86"}
-	indigo = lipgloss.AdaptiveColor{Light: "#5A56E0", Dark: "#7571F9"}
-	green  = lipgloss.AdaptiveColor{Light: "#02BA84", Dark: "#02BF87"}
-)
-
 // Styles defines the used lipgloss styles
 type Styles struct {
 	Base,
@@ -27,35 +25,124 @@ type Styles struct {
 	StatusHeader,
 	Highlight,
 	ErrorHeaderText,
-	Help lipgloss.Style
+	Help,
+	MenuHeader,
+	MenuItem,
+	MenuSelectedItem,
+	MenuContainer lipgloss.Style
 }
 
-// NewStyles returns the new configured styles using the given renderer.
+// NewStyles returns the new configured styles of the active theme using the
+// given renderer.
 func NewStyles(lg *lipgloss.Renderer) *Styles {
 	s := Styles{}
 	s.Base = lg.NewStyle().
 		Padding(1, 4, 0, 1)
 	s.HeaderText = lg.NewStyle().
-		Foreground(indigo).
+		Foreground(theme.Primary).
 		Bold(true).
 		Padding(0, 1, 0, 2)
 	s.Status = lg.NewStyle().
 		Border(lipgloss.RoundedBorder()).
-		BorderForeground(indigo).
+		BorderForeground(theme.Primary).
 		PaddingLeft(1).
 		MarginTop(1)
 	s.StatusHeader = lg.NewStyle().
-		Foreground(green).
+		Foreground(theme.Success).
 		Bold(true)
 	s.Highlight = lg.NewStyle().
-		Foreground(lipgloss.Color("212"))
+		Foreground(theme.Highlight)
 	s.ErrorHeaderText = s.HeaderText.
-		Foreground(red)
+		Foreground(theme.Error)
 	s.Help = lg.NewStyle().
-		Foreground(lipgloss.Color("240"))
+		Foreground(theme.Muted)
+	s.MenuHeader = lg.NewStyle().
+		Bold(true).
+		Foreground(theme.Text).
+		Background(theme.Banner).
+		Padding(1, 4).
+		Border(lipgloss.RoundedBorder(), true).
+		Align(lipgloss.Center).
+		Width(40)
+	s.MenuItem = lg.NewStyle().
+		Border(lipgloss.NormalBorder(), true).
+		Padding(0, 1).
+		Width(30).
+		Align(lipgloss.Center).
+		Foreground(theme.Muted).
+		Background(theme.Background)
+	s.MenuSelectedItem = lg.NewStyle().
+		Bold(true).
+		Border(lipgloss.RoundedBorder(), true).
+		Padding(0, 1).
+		Width(30).
+		Align(lipgloss.Center).
+		Foreground(theme.Text).
+		Background(theme.Surface)
+	s.MenuContainer = lg.NewStyle().
+		Width(80).
+		Align(lipgloss.Center)
 	return &s
 }
 
+// helpModel returns a new help.Model rendered with the styles.
+func (s *Styles) helpModel() help.Model {
+	h := help.New()
+	h.Styles.ShortKey = s.Help.Bold(true)
+	h.Styles.ShortDesc = s.Help
+	h.Styles.ShortSeparator = s.Help
+	h.Styles.FullKey = s.Help.Bold(true)
+	h.Styles.FullDesc = s.Help
+	h.Styles.FullSeparator = s.Help
+	h.Styles.Ellipsis = s.Help
+	return h
+}
+
+// tableStyles returns the table styles rendered with the styles.
+func (s *Styles) tableStyles() table.Styles {
+	ts := table.DefaultStyles()
+	ts.Header = ts.Header.
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderForeground(theme.Muted).
+		BorderBottom(true).
+		Bold(true)
+	ts.Selected = ts.Selected.
+		Foreground(theme.Text).
+		Background(theme.Primary)
+	return ts
+}
+
+// listDelegate returns a list delegate rendered with the styles.
+func (s *Styles) listDelegate() list.DefaultDelegate {
+	d := list.NewDefaultDelegate()
+	d.Styles.SelectedTitle = d.Styles.SelectedTitle.
+		Foreground(theme.Primary).
+		BorderForeground(theme.Primary)
+	d.Styles.SelectedDesc = d.Styles.SelectedDesc.
+		Foreground(theme.Primary).
+		BorderForeground(theme.Primary)
+	d.Styles.NormalDesc = d.Styles.NormalDesc.
+		Foreground(theme.Muted)
+	d.Styles.DimmedDesc = d.Styles.DimmedDesc.
+		Foreground(theme.Muted)
+	return d
+}
+
+// listStyles returns the list styles rendered with the styles.
+func (s *Styles) listStyles() list.Styles {
+	ls := list.DefaultStyles()
+	ls.Title = ls.Title.
+		Foreground(theme.Text).
+		Background(theme.Primary)
+	ls.FilterPrompt = ls.FilterPrompt.
+		Foreground(theme.Success)
+	ls.FilterCursor = ls.FilterCursor.
+		Foreground(theme.Highlight)
+	ls.StatusBar = ls.StatusBar.
+		Foreground(theme.Muted)
+	return ls
+}
+
 // HomeModelBuilder builds home models from a set of given mandatory and optional components:
 // title, list (table), list buttons, preview.
 type HomeModelBuilder interface {
@@ -63,12 +150,16 @@ type HomeModelBuilder interface {
 	Title(t string)
 	// List sets the list to render
 	List(l tea.Model)
+	// Table sets the table to render as an alternative to the list
+	Table(t tea.Model)
 	// Bar sets the list bar to render
 	Bar(b tea.Model)
 	// Extras sets the extras to render
 	Extras(e tea.Model)
 	// Preview sets the preview to render
 	Preview(p tea.Model)
+	// Keys sets the key bindings to show in the help
+	Keys(k help.KeyMap)
 }
 
 // HomeModel implements both tui.HomeModelBuilder and tea.Model interfaces to
@@ -81,9 +172,16 @@ type HomeModel struct {
 	title string
 
 	listView   tea.Model
+	tableView  tea.Model
+	showTable  bool
 	listBar    tea.Model
 	listExtras tea.Model
 	preview    tea.Model
+	reminders  *AlertBarModel
+
+	help     help.Model
+	keys     help.KeyMap
+	showHelp bool
 }
 
 // initialHomeModel returns a new HomeModel with empty content.
@@ -91,6 +189,8 @@ func initialHomeModel() *HomeModel {
 	m := &HomeModel{width: maxWidth, title: homeTitle}
 	m.lg = lipgloss.DefaultRenderer()
 	m.styles = NewStyles(m.lg)
+	m.help = m.styles.helpModel()
+	m.keys = keys.applianceHelp()
 	return m
 }
 
@@ -106,6 +206,11 @@ func (hm *HomeModel) List(l tea.Model) {
 	hm.listView = l
 }
 
+// Table sets the table to render as an alternative to the list
+func (hm *HomeModel) Table(t tea.Model) {
+	hm.tableView = t
+}
+
 // Bar sets the list bar to render
 func (hm *HomeModel) Bar(b tea.Model) {
 	hm.listBar = b
@@ -121,6 +226,11 @@ func (hm *HomeModel) Preview(p tea.Model) {
 	hm.preview = p
 }
 
+// Keys sets the key bindings to show in the help
+func (hm *HomeModel) Keys(k help.KeyMap) {
+	hm.keys = k
+}
+
 // HomeModel implementation of tea.Model interface ------------------------------
 
 // Init is the first function that will be called. It returns an optional
@@ -132,16 +242,47 @@ func (hm *HomeModel) Init() tea.Cmd {
 // Update is called when a message is received. Use it to inspect messages
 // and, in response, update the model and/or send a command.
 func (hm *HomeModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
-	switch msg := msg.(type) {
-	case tea.KeyMsg:
-		switch msg.String() {
-		case "q", "ctrl+c":
+	if msg, ok := msg.(remindersDueMsg); ok {
+		hm.reminders = initialAlertBarModel()
+		hm.reminders.alerts = reminderAlerts(msg.due)
+		return hm, nil
+	}
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch {
+		case key.Matches(msg, keys.Quit):
 			return hm, tea.Quit
+		case key.Matches(msg, keys.Help):
+			hm.showHelp = !hm.showHelp
+			return hm, nil
+		case key.Matches(msg, keys.ToggleView) && hm.tableView != nil:
+			hm.showTable = !hm.showTable
+			return hm, nil
+		}
+		// Keys are only handled by the visible view
+		if hm.tableShown() {
+			var cmd tea.Cmd
+			hm.tableView, cmd = hm.tableView.Update(msg)
+			return hm, cmd
 		}
-	case strainsListedMsg:
-		return hm.listView.Update(msg)
 	}
-	return hm, nil
+
+	var cmds []tea.Cmd
+	if hm.listView != nil {
+		var cmd tea.Cmd
+		hm.listView, cmd = hm.listView.Update(msg)
+		cmds = append(cmds, cmd)
+	}
+	if _, isKey := msg.(tea.KeyMsg); !isKey && hm.tableView != nil {
+		var cmd tea.Cmd
+		hm.tableView, cmd = hm.tableView.Update(msg)
+		cmds = append(cmds, cmd)
+	}
+	return hm, tea.Batch(cmds...)
+}
+
+// tableShown reports whether the table is rendered instead of the list.
+func (hm *HomeModel) tableShown() bool {
+	return hm.showTable && hm.tableView != nil
 }
 
 // View renders the HomeModel, which is just a string. The view is
@@ -150,8 +291,11 @@ func (hm *HomeModel) View() string {
 	s := hm.styles
 
 	header := hm.appBoundaryView(hm.title)
+	if hm.reminders != nil && len(hm.reminders.alerts) > 0 {
+		header += "\n" + hm.reminders.View()
+	}
 
-	body := lipgloss.JoinVertical(lipgloss.Left, hm.decoratedList(), hm.decoratedListBarAndExtras(), hm.decoratedPreview())
+	body := lipgloss.JoinVertical(lipgloss.Left, hm.decoratedList(), hm.decoratedListBarAndExtras(), hm.decoratedPreview(), hm.helpView())
 
 	return s.Base.Render(header + "\n" + body)
 }
@@ -163,13 +307,16 @@ func (hm *HomeModel) appBoundaryView(text string) string {
 		lipgloss.Left,
 		hm.styles.HeaderText.Render(text),
 		lipgloss.WithWhitespaceChars("/"),
-		lipgloss.WithWhitespaceForeground(indigo),
+		lipgloss.WithWhitespaceForeground(theme.Primary),
 	)
 }
 
-// decoratedList returns the rendered list view, if existing, or otherwise empty
-// content.
+// decoratedList returns the rendered table or list view, if existing, or
+// otherwise empty content.
 func (hm *HomeModel) decoratedList() string {
+	if hm.tableShown() {
+		return hm.tableView.View() + "\n\n"
+	}
 	if hm.listView == nil {
 		return "\n\n"
 	}
@@ -198,6 +345,15 @@ func (hm *HomeModel) decoratedPreview() string {
 	return hm.preview.View() + "\n\n"
 }
 
+// helpView returns the rendered short help, or the full help if toggled.
+func (hm *HomeModel) helpView() string {
+	if hm.keys == nil {
+		return ""
+	}
+	hm.help.ShowAll = hm.showHelp
+	return hm.help.View(hm.keys)
+}
+
 // markedText returns an string with its marked character (denoted by an `&`)
 // underlined by using ANSI escape codes
 func markedText(s string) string {
@@ -163,3 +163,34 @@ func TestHomeModelBuilder(t *testing.T) {
 		assert.Equal(t, preview, model.preview)
 	})
 }
+
+func TestHomeModel_Help(t *testing.T) {
+	model := initialHomeModel()
+	msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'?'}}
+
+	_, cmd := model.Update(msg)
+	assert.Nil(t, cmd)
+	assert.True(t, model.showHelp, "Should toggle the full help on")
+	assert.Contains(t, model.View(), "toggle help")
+
+	model.Update(msg)
+	assert.False(t, model.showHelp, "Should toggle the full help off")
+}
+
+func TestHomeModel_ToggleView(t *testing.T) {
+	model := initialHomeModel()
+	model.List(mockModel{view: "LIST"})
+	msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'t'}}
+
+	model.Update(msg)
+	assert.False(t, model.tableShown(), "Should not toggle without a table")
+
+	model.Table(mockModel{view: "TABLE"})
+	model.Update(msg)
+	assert.True(t, model.tableShown())
+	assert.Contains(t, model.View(), "TABLE")
+	assert.NotContains(t, model.View(), "LIST")
+
+	model.Update(msg)
+	assert.Contains(t, model.View(), "LIST")
+}
@@ -0,0 +1,207 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	can "github.com/TheDonDope/wits-tui/pkg/cannabis"
+	"github.com/TheDonDope/wits-tui/pkg/i18n"
+	"github.com/TheDonDope/wits-tui/pkg/service"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/huh"
+)
+
+// reliefShown is the number of best groups shown per symptom.
+const reliefShown = 3
+
+type reliefCorrelatedMsg struct {
+	report service.ReliefReport
+}
+
+// journalGroups returns the form groups to rate the configured symptoms before
+// and after a session and to choose the perceived effects and side effects.
+// Nothing is asked for if none are configured.
+func journalGroups() []*huh.Group {
+	symptoms := userSettings.Journal.Symptoms
+	before := make([]huh.Field, len(symptoms))
+	after := make([]huh.Field, len(symptoms))
+	for i, s := range symptoms {
+		before[i] = scoreInput(symptomKey(s, "before"), s)
+		after[i] = scoreInput(symptomKey(s, "after"), s)
+	}
+	var groups []*huh.Group
+	if len(symptoms) > 0 {
+		groups = append(groups,
+			huh.NewGroup(append([]huh.Field{huh.NewNote().
+				Title(i18n.T("Symptoms before")).
+				Description(i18n.T("Rate the severity from 0 (none) to %d (worst), leave empty if not present", can.MaxSymptomScore))},
+				before...)...),
+			huh.NewGroup(append([]huh.Field{huh.NewNote().
+				Title(i18n.T("Symptoms after")).
+				Description(i18n.T("Rate the severity from 0 (none) to %d (worst), leave empty if not present", can.MaxSymptomScore))},
+				after...)...))
+	}
+	var effects []huh.Field
+	if len(userSettings.Journal.Effects) > 0 {
+		effects = append(effects, huh.NewMultiSelect[string]().
+			Key("effects").
+			Options(journalOptions(userSettings.Journal.Effects)...).
+			Title(i18n.T("Effects")).
+			Description(i18n.T("The perceived effects")))
+	}
+	if len(userSettings.Journal.SideEffects) > 0 {
+		effects = append(effects, huh.NewMultiSelect[string]().
+			Key("side_effects").
+			Options(journalOptions(userSettings.Journal.SideEffects)...).
+			Title(i18n.T("Side effects")).
+			Description(i18n.T("The perceived side effects")))
+	}
+	if len(effects) > 0 {
+		groups = append(groups, huh.NewGroup(effects...))
+	}
+	return groups
+}
+
+// scoreInput returns an input for the score of the given symptom.
+func scoreInput(key, symptom string) *huh.Input {
+	return huh.NewInput().
+		Key(key).
+		Title(i18n.Term(symptom)).
+		Validate(validateScore)
+}
+
+// symptomKey returns the key of the score of the given symptom in the given
+// phase (before or after the session) within the session form.
+func symptomKey(symptom, phase string) string {
+	return fmt.Sprintf("symptom_%s_%s", phase, symptom)
+}
+
+// journalOptions returns the given configured terms as options, in their
+// configured order.
+func journalOptions(terms []string) []huh.Option[string] {
+	options := make([]huh.Option[string], len(terms))
+	for i, t := range terms {
+		options[i] = huh.NewOption(i18n.Term(t), t)
+	}
+	return options
+}
+
+// validateScore returns an error if the given non-empty input is no symptom
+// score.
+func validateScore(input string) error {
+	if strings.TrimSpace(input) == "" {
+		return nil
+	}
+	if _, ok := parseScore(input); !ok {
+		return errors.New(i18n.T("Please enter a number from 0 to %d", can.MaxSymptomScore))
+	}
+	return nil
+}
+
+// parseScore parses the given input as a symptom score. It returns false if
+// the input is empty or no valid score.
+func parseScore(input string) (int, bool) {
+	score, err := strconv.Atoi(strings.TrimSpace(input))
+	if err != nil || score < 0 || score > can.MaxSymptomScore {
+		return 0, false
+	}
+	return score, true
+}
+
+// parseJournal adds the journal entered in the given form to the given
+// session. Symptoms are only recorded if they are rated before and after.
+func parseJournal(form *huh.Form, session *can.Session) {
+	for _, s := range userSettings.Journal.Symptoms {
+		before, bok := parseScore(form.GetString(symptomKey(s, "before")))
+		after, aok := parseScore(form.GetString(symptomKey(s, "after")))
+		if bok && aok {
+			session.Symptoms = append(session.Symptoms, &can.SymptomScore{Symptom: s, Before: before, After: after})
+		}
+	}
+	if effects, ok := form.Get("effects").([]string); ok {
+		session.Effects = effects
+	}
+	if sideEffects, ok := form.Get("side_effects").([]string); ok {
+		session.SideEffects = sideEffects
+	}
+}
+
+// ReliefModel is a tea.Model rendering the relief of the journaled symptoms,
+// correlated with the strains, terpenes and genetics.
+type ReliefModel struct {
+	styles *Styles
+	report service.ReliefReport
+}
+
+// ReliefModel implementation of tea.Model interface ---------------------------
+
+// Init is the first function that will be called. It returns an optional
+// initial command. To not perform an initial command return nil.
+func (rm *ReliefModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update is called when a message is received. Use it to inspect messages
+// and, in response, update the model and/or send a command.
+func (rm *ReliefModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	return rm, nil
+}
+
+// View renders the ReliefModel UI, which is just a string. The view is
+// rendered after every Update.
+func (rm *ReliefModel) View() string {
+	s := rm.styles
+	var b strings.Builder
+	b.WriteString(s.StatusHeader.Render(i18n.T("Symptom Relief")) + "\n")
+	if rm.report.Journaled == 0 {
+		b.WriteString(s.Help.Render(i18n.T("No symptoms rated yet, rate them when logging a session.")))
+		return s.Status.Render(b.String())
+	}
+	b.WriteString(i18n.T("%d sessions with rated symptoms", rm.report.Journaled) + "\n")
+	for _, overall := range rm.report.Overall {
+		b.WriteString("\n" + s.Highlight.Render(i18n.Term(overall.Symptom)) + "  " +
+			i18n.T("%s points relief on average", reliefText(overall.Relief)) + "\n")
+		for _, line := range []string{
+			reliefLine(i18n.T("Strains"), overall.Symptom, rm.report.ByStrain, false),
+			reliefLine(i18n.T("Terpenes"), overall.Symptom, rm.report.ByTerpene, true),
+			reliefLine(i18n.T("Genetics"), overall.Symptom, rm.report.ByGenetic, true),
+		} {
+			if line != "" {
+				b.WriteString(line + "\n")
+			}
+		}
+	}
+	return s.Status.Render(strings.TrimSuffix(b.String(), "\n"))
+}
+
+// reliefLine returns the groups relieving the given symptom best as a single
+// labeled line, or an empty string if no group relieves it. Catalog terms are
+// translated if requested.
+func reliefLine(label, symptom string, reliefs []service.SymptomRelief, terms bool) string {
+	var parts []string
+	for _, r := range reliefs {
+		if r.Symptom != symptom || len(parts) == reliefShown {
+			continue
+		}
+		group := r.Group
+		if terms {
+			group = i18n.Term(group)
+		}
+		parts = append(parts, fmt.Sprintf("%s %s (%d)", group, reliefText(r.Relief), r.Sessions))
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return "  " + label + ": " + strings.Join(parts, ", ")
+}
+
+// reliefText returns the given relief with its sign.
+func reliefText(relief float64) string {
+	text := i18n.FormatFloat(relief, 1)
+	if relief > 0 {
+		text = "+" + text
+	}
+	return text
+}
@@ -0,0 +1,62 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/TheDonDope/wits-tui/pkg/service"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseScore(t *testing.T) {
+	score, ok := parseScore(" 7 ")
+	assert.True(t, ok)
+	assert.Equal(t, 7, score)
+
+	for _, input := range []string{"", "-1", "11", "2.5", "x"} {
+		_, ok := parseScore(input)
+		assert.False(t, ok, input)
+	}
+	assert.NoError(t, validateScore(""))
+	assert.NoError(t, validateScore("0"))
+	assert.Error(t, validateScore("11"))
+}
+
+func TestJournalGroups(t *testing.T) {
+	assert.Len(t, journalGroups(), 3)
+
+	journal := userSettings.Journal
+	t.Cleanup(func() { userSettings.Journal = journal })
+	userSettings.Journal.Symptoms = nil
+	userSettings.Journal.SideEffects = nil
+	assert.Len(t, journalGroups(), 1, "only the effects are asked for")
+}
+
+func TestReliefModel_View(t *testing.T) {
+	styles := NewStyles(lipgloss.DefaultRenderer())
+
+	t.Run("Empty", func(t *testing.T) {
+		rm := &ReliefModel{styles: styles}
+		assert.Contains(t, rm.View(), "No symptoms rated yet")
+	})
+
+	t.Run("Report", func(t *testing.T) {
+		rm := &ReliefModel{styles: styles, report: service.ReliefReport{
+			Journaled: 3,
+			Overall:   []service.SymptomRelief{{Symptom: "pain", Relief: 3, Sessions: 3}},
+			ByStrain: []service.SymptomRelief{
+				{Symptom: "pain", Group: "Kush", Relief: 5, Sessions: 2},
+				{Symptom: "pain", Group: "Haze", Relief: -1, Sessions: 1},
+			},
+			ByGenetic: []service.SymptomRelief{{Symptom: "pain", Group: "Indica", Relief: 5, Sessions: 2}},
+		}}
+
+		view := rm.View()
+
+		assert.Contains(t, view, "3 sessions with rated symptoms")
+		assert.Contains(t, view, "+3.0 points relief on average")
+		assert.Contains(t, view, "Strains: Kush +5.0 (2), Haze -1.0 (1)")
+		assert.Contains(t, view, "Genetics: Indica +5.0 (2)")
+		assert.NotContains(t, view, "Terpenes:")
+	})
+}
@@ -0,0 +1,415 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/TheDonDope/wits-tui/pkg/i18n"
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+)
+
+// keyScope is the scope in which a key binding is active. Bindings of the
+// global scope are active in every appliance.
+type keyScope string
+
+const (
+	globalScope   keyScope = "global"
+	menuScope     keyScope = "menu"
+	strainsScope  keyScope = "strains"
+	settingsScope keyScope = "settings"
+	statsScope    keyScope = "statistics"
+	rxScope       keyScope = "prescriptions"
+	recipesScope  keyScope = "recipes"
+	refScope      keyScope = "reference"
+)
+
+// KeyMap defines the key bindings of all appliances.
+type KeyMap struct {
+	Quit            key.Binding
+	Back            key.Binding
+	Help            key.Binding
+	ToggleView      key.Binding
+	Up              key.Binding
+	Down            key.Binding
+	Select          key.Binding
+	AddStrain       key.Binding
+	AddBatch        key.Binding
+	LogSession      key.Binding
+	Filter          key.Binding
+	Sort            key.Binding
+	SortColumnLeft  key.Binding
+	SortColumnRight key.Binding
+	ReverseSort     key.Binding
+	Similar         key.Binding
+	Recommend       key.Binding
+	RateStrain      key.Binding
+	Favorite        key.Binding
+	Lineage         key.Binding
+	EditCultivar    key.Binding
+	Directory       key.Binding
+	Appearance      key.Binding
+	Localization    key.Binding
+	Currency        key.Binding
+	AddReminder     key.Binding
+	Spending        key.Binding
+	DosageTracker   key.Binding
+	SymptomRelief   key.Binding
+	Tolerance       key.Binding
+	PlanBreak       key.Binding
+	CheckIn         key.Binding
+	AddPrescription key.Binding
+	AddRecipe       key.Binding
+	FilterEffect    key.Binding
+	FilterFlavor    key.Binding
+	SortByBoiling   key.Binding
+}
+
+// keys is the active KeyMap, which can be replaced by Configure.
+var keys = DefaultKeyMap()
+
+// DefaultKeyMap returns the KeyMap with the default key bindings.
+func DefaultKeyMap() KeyMap {
+	return KeyMap{
+		Quit: key.NewBinding(
+			key.WithKeys("q", "ctrl+c"),
+			key.WithHelp("q/ctrl+c", i18n.T("quit"))),
+		Back: key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", i18n.T("back"))),
+		Help: key.NewBinding(
+			key.WithKeys("?"),
+			key.WithHelp("?", i18n.T("toggle help"))),
+		ToggleView: key.NewBinding(
+			key.WithKeys("t"),
+			key.WithHelp("t", i18n.T("toggle list/table"))),
+		Up: key.NewBinding(
+			key.WithKeys("up", "k"),
+			key.WithHelp("↑/k", i18n.T("up"))),
+		Down: key.NewBinding(
+			key.WithKeys("down", "j"),
+			key.WithHelp("↓/j", i18n.T("down"))),
+		Select: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", i18n.T("select"))),
+		AddStrain: key.NewBinding(
+			key.WithKeys("alt+n", "ctrl+n"),
+			key.WithHelp("alt+n/ctrl+n", i18n.T("add strain"))),
+		AddBatch: key.NewBinding(
+			key.WithKeys("alt+b"),
+			key.WithHelp("alt+b", i18n.T("add batch"))),
+		LogSession: key.NewBinding(
+			key.WithKeys("alt+l"),
+			key.WithHelp("alt+l", i18n.T("log session"))),
+		Filter: key.NewBinding(
+			key.WithKeys("/"),
+			key.WithHelp("/", i18n.T("filter (e.g. thc>20 terp:limonene)"))),
+		Sort: key.NewBinding(
+			key.WithKeys("s"),
+			key.WithHelp("s", i18n.T("sort"))),
+		SortColumnLeft: key.NewBinding(
+			key.WithKeys("<"),
+			key.WithHelp("<", i18n.T("sort by previous column"))),
+		SortColumnRight: key.NewBinding(
+			key.WithKeys(">"),
+			key.WithHelp(">", i18n.T("sort by next column"))),
+		ReverseSort: key.NewBinding(
+			key.WithKeys("r"),
+			key.WithHelp("r", i18n.T("reverse column sort"))),
+		Similar: key.NewBinding(
+			key.WithKeys("m"),
+			key.WithHelp("m", i18n.T("similar strains"))),
+		Recommend: key.NewBinding(
+			key.WithKeys("alt+r"),
+			key.WithHelp("alt+r", i18n.T("recommend strain"))),
+		RateStrain: key.NewBinding(
+			key.WithKeys("alt+t"),
+			key.WithHelp("alt+t", i18n.T("rate strain"))),
+		Favorite: key.NewBinding(
+			key.WithKeys("f"),
+			key.WithHelp("f", i18n.T("toggle favorite"))),
+		Lineage: key.NewBinding(
+			key.WithKeys("i"),
+			key.WithHelp("i", i18n.T("lineage"))),
+		EditCultivar: key.NewBinding(
+			key.WithKeys("alt+g"),
+			key.WithHelp("alt+g", i18n.T("edit cultivar"))),
+		Directory: key.NewBinding(
+			key.WithKeys("alt+y"),
+			key.WithHelp("alt+y", i18n.T("edit directory"))),
+		Appearance: key.NewBinding(
+			key.WithKeys("alt+a"),
+			key.WithHelp("alt+a", i18n.T("appearance"))),
+		Localization: key.NewBinding(
+			key.WithKeys("alt+l"),
+			key.WithHelp("alt+l", i18n.T("localization"))),
+		Currency: key.NewBinding(
+			key.WithKeys("alt+c"),
+			key.WithHelp("alt+c", i18n.T("currency"))),
+		AddReminder: key.NewBinding(
+			key.WithKeys("alt+m"),
+			key.WithHelp("alt+m", i18n.T("add reminder"))),
+		Spending: key.NewBinding(
+			key.WithKeys("alt+s"),
+			key.WithHelp("alt+s", i18n.T("spending"))),
+		DosageTracker: key.NewBinding(
+			key.WithKeys("alt+d"),
+			key.WithHelp("alt+d", i18n.T("dosage tracker"))),
+		SymptomRelief: key.NewBinding(
+			key.WithKeys("alt+j"),
+			key.WithHelp("alt+j", i18n.T("symptom relief"))),
+		Tolerance: key.NewBinding(
+			key.WithKeys("alt+o"),
+			key.WithHelp("alt+o", i18n.T("tolerance"))),
+		PlanBreak: key.NewBinding(
+			key.WithKeys("alt+p"),
+			key.WithHelp("alt+p", i18n.T("plan T-break"))),
+		CheckIn: key.NewBinding(
+			key.WithKeys("alt+k"),
+			key.WithHelp("alt+k", i18n.T("check in"))),
+		AddPrescription: key.NewBinding(
+			key.WithKeys("alt+n", "ctrl+n"),
+			key.WithHelp("alt+n/ctrl+n", i18n.T("add prescription"))),
+		AddRecipe: key.NewBinding(
+			key.WithKeys("alt+n", "ctrl+n"),
+			key.WithHelp("alt+n/ctrl+n", i18n.T("add recipe"))),
+		FilterEffect: key.NewBinding(
+			key.WithKeys("alt+e"),
+			key.WithHelp("alt+e", i18n.T("filter by effect"))),
+		FilterFlavor: key.NewBinding(
+			key.WithKeys("alt+f"),
+			key.WithHelp("alt+f", i18n.T("filter by flavor"))),
+		SortByBoiling: key.NewBinding(
+			key.WithKeys("s"),
+			key.WithHelp("s", i18n.T("sort by boiling point"))),
+	}
+}
+
+// NewKeyMap returns the default KeyMap with the given overrides applied. The
+// overrides are keyed by the action name, as listed by KeyMap.Actions.
+func NewKeyMap(overrides map[string][]string) (KeyMap, error) {
+	km := DefaultKeyMap()
+	bindings := km.bindings()
+	for action, ks := range overrides {
+		b, ok := bindings[action]
+		if !ok {
+			return km, fmt.Errorf("unknown keybinding action %q", action)
+		}
+		if len(ks) == 0 {
+			return km, fmt.Errorf("no keys given for keybinding action %q", action)
+		}
+		b.binding.SetKeys(ks...)
+		b.binding.SetHelp(strings.Join(ks, "/"), b.binding.Help().Desc)
+	}
+	return km, nil
+}
+
+// scopedBinding is a key binding together with the scope it is active in.
+type scopedBinding struct {
+	scope   keyScope
+	binding *key.Binding
+}
+
+// bindings returns all bindings of the KeyMap, keyed by their action name.
+func (km *KeyMap) bindings() map[string]scopedBinding {
+	return map[string]scopedBinding{
+		"quit":              {globalScope, &km.Quit},
+		"back":              {globalScope, &km.Back},
+		"help":              {globalScope, &km.Help},
+		"toggle_view":       {globalScope, &km.ToggleView},
+		"up":                {menuScope, &km.Up},
+		"down":              {menuScope, &km.Down},
+		"select":            {menuScope, &km.Select},
+		"add_strain":        {strainsScope, &km.AddStrain},
+		"add_batch":         {strainsScope, &km.AddBatch},
+		"log_session":       {strainsScope, &km.LogSession},
+		"filter":            {strainsScope, &km.Filter},
+		"sort":              {strainsScope, &km.Sort},
+		"sort_column_left":  {strainsScope, &km.SortColumnLeft},
+		"sort_column_right": {strainsScope, &km.SortColumnRight},
+		"reverse_sort":      {strainsScope, &km.ReverseSort},
+		"similar":           {strainsScope, &km.Similar},
+		"recommend":         {strainsScope, &km.Recommend},
+		"rate_strain":       {strainsScope, &km.RateStrain},
+		"favorite":          {strainsScope, &km.Favorite},
+		"lineage":           {strainsScope, &km.Lineage},
+		"edit_cultivar":     {strainsScope, &km.EditCultivar},
+		"directory":         {strainsScope, &km.Directory},
+		"appearance":        {settingsScope, &km.Appearance},
+		"localization":      {settingsScope, &km.Localization},
+		"currency":          {settingsScope, &km.Currency},
+		"add_reminder":      {settingsScope, &km.AddReminder},
+		"spending":          {statsScope, &km.Spending},
+		"dosage_tracker":    {statsScope, &km.DosageTracker},
+		"symptom_relief":    {statsScope, &km.SymptomRelief},
+		"tolerance":         {statsScope, &km.Tolerance},
+		"plan_break":        {statsScope, &km.PlanBreak},
+		"check_in":          {statsScope, &km.CheckIn},
+		"add_prescription":  {rxScope, &km.AddPrescription},
+		"add_recipe":        {recipesScope, &km.AddRecipe},
+		"filter_effect":     {refScope, &km.FilterEffect},
+		"filter_flavor":     {refScope, &km.FilterFlavor},
+		"sort_boiling":      {refScope, &km.SortByBoiling},
+	}
+}
+
+// Actions returns the sorted names of all actions which can be rebound.
+func (km KeyMap) Actions() []string {
+	var actions []string
+	for action := range km.bindings() {
+		actions = append(actions, action)
+	}
+	sort.Strings(actions)
+	return actions
+}
+
+// KeyConflict describes a key which is bound to more than one action in the
+// same scope.
+type KeyConflict struct {
+	Key     string
+	Actions []string
+}
+
+// Error returns the description of the conflict.
+func (kc KeyConflict) Error() string {
+	return fmt.Sprintf("key %q is bound to multiple actions: %s", kc.Key, strings.Join(kc.Actions, ", "))
+}
+
+// Conflicts returns all keys which are bound to more than one action that can
+// be active at the same time. Global bindings conflict with every scope.
+func (km KeyMap) Conflicts() []KeyConflict {
+	bindings := km.bindings()
+	var conflicts []KeyConflict
+	seen := map[string]bool{}
+	for _, a := range km.Actions() {
+		for _, k := range bindings[a].binding.Keys() {
+			if seen[k] {
+				continue
+			}
+			seen[k] = true
+			actions := []string{a}
+			for _, b := range km.Actions() {
+				if a == b || !containsKey(bindings[b].binding.Keys(), k) {
+					continue
+				}
+				sa, sb := bindings[a].scope, bindings[b].scope
+				if sa == sb || sa == globalScope || sb == globalScope {
+					actions = append(actions, b)
+				}
+			}
+			if len(actions) > 1 {
+				conflicts = append(conflicts, KeyConflict{Key: k, Actions: actions})
+			}
+		}
+	}
+	return conflicts
+}
+
+// containsKey reports whether the given key is part of the given keys.
+func containsKey(ks []string, k string) bool {
+	for _, c := range ks {
+		if c == k {
+			return true
+		}
+	}
+	return false
+}
+
+// keyHelp implements the help.KeyMap interface for a set of bindings.
+type keyHelp struct {
+	short []key.Binding
+	full  [][]key.Binding
+}
+
+// ShortHelp returns the bindings for the short help view.
+func (kh keyHelp) ShortHelp() []key.Binding {
+	return kh.short
+}
+
+// FullHelp returns the bindings for the full help view.
+func (kh keyHelp) FullHelp() [][]key.Binding {
+	return kh.full
+}
+
+// menuHelp returns the help for the main menu.
+func (km KeyMap) menuHelp() help.KeyMap {
+	return keyHelp{
+		short: []key.Binding{km.Up, km.Down, km.Select, km.Help, km.Quit},
+		full: [][]key.Binding{
+			{km.Up, km.Down, km.Select},
+			{km.Back, km.Help, km.Quit}},
+	}
+}
+
+// strainsHelp returns the help for the Strains appliance.
+func (km KeyMap) strainsHelp() help.KeyMap {
+	return keyHelp{
+		short: []key.Binding{km.AddStrain, km.AddBatch, km.LogSession, km.RateStrain, km.Filter, km.Sort, km.ToggleView, km.Back, km.Help, km.Quit},
+		full: [][]key.Binding{
+			{km.AddStrain, km.AddBatch, km.LogSession, km.RateStrain, km.Filter, km.Sort},
+			{km.Favorite, km.Similar, km.Recommend, km.Lineage, km.EditCultivar, km.Directory},
+			{km.ToggleView, km.SortColumnLeft, km.SortColumnRight, km.ReverseSort},
+			{km.Back, km.Help, km.Quit}},
+	}
+}
+
+// settingsHelp returns the help for the Settings appliance.
+func (km KeyMap) settingsHelp() help.KeyMap {
+	return keyHelp{
+		short: []key.Binding{km.Appearance, km.Localization, km.Currency, km.AddReminder, km.Back, km.Help, km.Quit},
+		full: [][]key.Binding{
+			{km.Appearance, km.Localization, km.Currency, km.AddReminder},
+			{km.Back, km.Help, km.Quit}},
+	}
+}
+
+// statisticsHelp returns the help for the Statistics appliance.
+func (km KeyMap) statisticsHelp() help.KeyMap {
+	return keyHelp{
+		short: []key.Binding{km.Spending, km.DosageTracker, km.SymptomRelief, km.Tolerance, km.Back, km.Help, km.Quit},
+		full: [][]key.Binding{
+			{km.Spending, km.DosageTracker, km.SymptomRelief},
+			{km.Tolerance, km.PlanBreak, km.CheckIn},
+			{km.Back, km.Help, km.Quit}},
+	}
+}
+
+// prescriptionsHelp returns the help for the Prescriptions appliance.
+func (km KeyMap) prescriptionsHelp() help.KeyMap {
+	return keyHelp{
+		short: []key.Binding{km.AddPrescription, km.Back, km.Help, km.Quit},
+		full: [][]key.Binding{
+			{km.AddPrescription},
+			{km.Back, km.Help, km.Quit}},
+	}
+}
+
+// recipesHelp returns the help for the Recipes appliance.
+func (km KeyMap) recipesHelp() help.KeyMap {
+	return keyHelp{
+		short: []key.Binding{km.AddRecipe, km.Back, km.Help, km.Quit},
+		full: [][]key.Binding{
+			{km.AddRecipe},
+			{km.Back, km.Help, km.Quit}},
+	}
+}
+
+// referenceHelp returns the help for the Reference appliance.
+func (km KeyMap) referenceHelp() help.KeyMap {
+	return keyHelp{
+		short: []key.Binding{km.FilterEffect, km.FilterFlavor, km.SortByBoiling, km.Back, km.Help, km.Quit},
+		full: [][]key.Binding{
+			{km.FilterEffect, km.FilterFlavor, km.SortByBoiling},
+			{km.Back, km.Help, km.Quit}},
+	}
+}
+
+// applianceHelp returns the help for appliances without additional bindings.
+func (km KeyMap) applianceHelp() help.KeyMap {
+	return keyHelp{
+		short: []key.Binding{km.Back, km.Help, km.Quit},
+		full: [][]key.Binding{
+			{km.Back, km.Help, km.Quit}},
+	}
+}
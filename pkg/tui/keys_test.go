@@ -0,0 +1,109 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/TheDonDope/wits-tui/pkg/i18n"
+	"github.com/TheDonDope/wits-tui/pkg/settings"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyMap(t *testing.T) {
+	t.Run("DefaultHasNoConflicts", func(t *testing.T) {
+		assert.Empty(t, DefaultKeyMap().Conflicts())
+	})
+
+	t.Run("Overrides", func(t *testing.T) {
+		km, err := NewKeyMap(map[string][]string{"quit": {"ctrl+q"}})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"ctrl+q"}, km.Quit.Keys())
+		assert.Equal(t, "ctrl+q", km.Quit.Help().Key)
+		assert.True(t, key.Matches(tea.KeyMsg{Type: tea.KeyCtrlQ}, km.Quit))
+	})
+
+	t.Run("UnknownAction", func(t *testing.T) {
+		_, err := NewKeyMap(map[string][]string{"fly": {"f"}})
+		assert.Error(t, err)
+	})
+
+	t.Run("EmptyKeys", func(t *testing.T) {
+		_, err := NewKeyMap(map[string][]string{"quit": {}})
+		assert.Error(t, err)
+	})
+
+	t.Run("Conflicts", func(t *testing.T) {
+		tests := []struct {
+			name      string
+			overrides map[string][]string
+			conflicts int
+		}{
+			{"SameScope", map[string][]string{"up": {"j"}}, 1},
+			{"GlobalScope", map[string][]string{"add_strain": {"q"}}, 1},
+			{"DifferentScopes", map[string][]string{"add_strain": {"enter"}}, 0},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				km, err := NewKeyMap(tt.overrides)
+				require.NoError(t, err)
+				assert.Len(t, km.Conflicts(), tt.conflicts)
+			})
+		}
+	})
+}
+
+func TestConfigure(t *testing.T) {
+	t.Cleanup(func() { keys = DefaultKeyMap() })
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LANG", "C")
+
+	t.Run("Conflicting", func(t *testing.T) {
+		s := settings.Default()
+		s.Keybindings["help"] = []string{"q"}
+
+		err := Configure(s)
+
+		var conflict KeyConflict
+		assert.ErrorAs(t, err, &conflict)
+		assert.Equal(t, "q", conflict.Key)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		s := settings.Default()
+		s.Keybindings["help"] = []string{"h"}
+
+		require.NoError(t, Configure(s))
+		assert.Equal(t, []string{"h"}, keys.Help.Keys())
+	})
+
+	t.Run("ConfiguredLocale", func(t *testing.T) {
+		t.Cleanup(func() { _ = i18n.SetLocale(i18n.English) })
+		// The environment is not consulted if the locale is configured
+		t.Setenv("LANG", ".")
+		s := settings.Default()
+		s.Locale = "de"
+
+		require.NoError(t, Configure(s))
+		assert.Equal(t, i18n.German, i18n.Current())
+	})
+
+	t.Run("InvalidCurrency", func(t *testing.T) {
+		s := settings.Default()
+		s.Currency = "euro"
+
+		assert.Error(t, Configure(s))
+	})
+
+	t.Run("InvalidBioavailability", func(t *testing.T) {
+		for _, b := range []map[string]float64{{"dabbing": 0.5}, {"oral": 1.5}} {
+			s := settings.Default()
+			s.Dosage.Bioavailability = b
+
+			assert.Error(t, Configure(s))
+		}
+	})
+}
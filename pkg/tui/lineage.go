@@ -0,0 +1,167 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	can "github.com/TheDonDope/wits-tui/pkg/cannabis"
+	"github.com/TheDonDope/wits-tui/pkg/i18n"
+	"github.com/TheDonDope/wits-tui/pkg/service"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/huh"
+)
+
+type cultivarSubmittedMsg struct {
+	cultivar *can.Cultivar
+}
+
+// cultivarGenetics describes the genetics of the given cultivar, e.g.
+// `70/30, indica-dominant hybrid`, or returns an empty string if its ratio is
+// unknown.
+func cultivarGenetics(c *can.Cultivar) string {
+	if c == nil || !c.RatioKnown() {
+		return ""
+	}
+	genetic, _ := c.Genetic()
+	kind := i18n.Term(can.Genetics[genetic])
+	if genetic == can.Hybrid {
+		kind = i18n.T("balanced hybrid")
+		if dominant, ok := c.Dominant(); ok {
+			kind = i18n.T("%s-dominant hybrid", i18n.Term(can.Genetics[dominant]))
+		}
+	}
+	return c.Ratio() + ", " + kind
+}
+
+// lineageLines returns the parents and children of the given lineage as
+// trees, a line per cultivar.
+func lineageLines(root *service.LineageNode) []string {
+	lines := []string{i18n.T("Parents")}
+	parents := lineageTree(root.Parents, func(n *service.LineageNode) []*service.LineageNode { return n.Parents }, "")
+	if len(parents) == 0 {
+		parents = []string{i18n.T("No known parents.")}
+	}
+	lines = append(lines, parents...)
+	lines = append(lines, i18n.T("Children"))
+	children := lineageTree(root.Children, func(n *service.LineageNode) []*service.LineageNode { return n.Children }, "")
+	if len(children) == 0 {
+		children = []string{i18n.T("No known children.")}
+	}
+	return append(lines, children...)
+}
+
+// lineageTree returns a line per given node and, indented below it, per node
+// of its branch, which is walked by the given function.
+func lineageTree(nodes []*service.LineageNode, branch func(*service.LineageNode) []*service.LineageNode, indent string) []string {
+	var lines []string
+	for i, n := range nodes {
+		connector, next := "├─ ", "│  "
+		if i == len(nodes)-1 {
+			connector, next = "└─ ", "   "
+		}
+		lines = append(lines, indent+connector+lineageLabel(n))
+		lines = append(lines, lineageTree(branch(n), branch, indent+next)...)
+	}
+	return lines
+}
+
+// lineageLabel returns the name of the cultivar of the given node with its
+// ratio, if known, and its strains in the inventory.
+func lineageLabel(n *service.LineageNode) string {
+	label := n.Name
+	if n.Cultivar != nil && n.Cultivar.RatioKnown() {
+		label += " (" + n.Cultivar.Ratio() + ")"
+	}
+	if len(n.Strains) > 0 {
+		names := make([]string, len(n.Strains))
+		for i, s := range n.Strains {
+			names[i] = s.Strain
+		}
+		label += ": " + strings.Join(names, ", ")
+	}
+	return label
+}
+
+// cultivarNames returns the names of the given cultivars.
+func cultivarNames(cultivars []*can.Cultivar) []string {
+	names := make([]string, len(cultivars))
+	for i, c := range cultivars {
+		names[i] = c.Name
+	}
+	return names
+}
+
+// onCultivarEdited runs the form to edit the given cultivar and on submission
+// sends a message with the parsed cultivar from the form.
+func onCultivarEdited(cultivar *can.Cultivar) tea.Cmd {
+	form := initialCultivarForm(cultivar)
+
+	if err := form.Run(); err != nil {
+		fmt.Fprint(os.Stderr, i18n.T("Error running cultivar form: %v\n", err))
+		return nil
+	}
+
+	edited := parseCultivar(form, cultivar)
+	return func() tea.Msg { return cultivarSubmittedMsg{edited} }
+}
+
+// initialCultivarForm returns a form for editing the breeder, genetics,
+// parents and notes of the given cultivar, prefilled with its current ones.
+func initialCultivarForm(c *can.Cultivar) *huh.Form {
+	breeder, ratio, parents, notes := c.Breeder, c.Ratio(), strings.Join(c.Parents, ", "), c.Notes
+	return huh.NewForm(
+		huh.NewGroup(
+			huh.NewNote().
+				Title(i18n.T("Cultivar %s", c.Name)),
+
+			huh.NewInput().
+				Key("breeder").
+				Title(i18n.T("Breeder")).
+				Description(i18n.T("Who created the cultivar")).
+				Value(&breeder),
+
+			huh.NewInput().
+				Key("ratio").
+				Title(i18n.T("Indica/Sativa")).
+				Description(i18n.T("The ratio of the genetics (e.g. 70/30), leave empty if unknown")).
+				Validate(validateRatio).
+				Value(&ratio),
+
+			huh.NewInput().
+				Key("parents").
+				Title(i18n.T("Parents")).
+				Description(i18n.T("Comma separated parent cultivars (e.g. Sunset Sherbet, Thin Mint GSC)")).
+				Value(&parents),
+
+			huh.NewText().
+				Key("notes").
+				Title(i18n.T("Notes")).
+				Value(&notes),
+		),
+	).WithTheme(formTheme())
+}
+
+// validateRatio validates the input of an indica/sativa ratio.
+func validateRatio(input string) error {
+	if _, _, err := can.ParseRatio(input); err != nil {
+		return errors.New(i18n.T("Please enter a ratio like 70/30"))
+	}
+	return nil
+}
+
+// parseCultivar returns the given cultivar with the data of the given form.
+func parseCultivar(form *huh.Form, c *can.Cultivar) *can.Cultivar {
+	edited := *c
+	edited.Breeder = strings.TrimSpace(form.GetString("breeder"))
+	edited.Indica, edited.Sativa, _ = can.ParseRatio(form.GetString("ratio"))
+	edited.Parents = nil
+	for _, p := range strings.Split(form.GetString("parents"), ",") {
+		if p = strings.TrimSpace(p); p != "" && !strings.EqualFold(p, c.Name) && !edited.HasParent(p) {
+			edited.Parents = append(edited.Parents, p)
+		}
+	}
+	edited.Notes = strings.TrimSpace(form.GetString("notes"))
+	return &edited
+}
@@ -0,0 +1,65 @@
+package tui
+
+import (
+	"testing"
+
+	can "github.com/TheDonDope/wits-tui/pkg/cannabis"
+	"github.com/TheDonDope/wits-tui/pkg/service"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCultivarGenetics(t *testing.T) {
+	assert.Empty(t, cultivarGenetics(nil))
+	assert.Empty(t, cultivarGenetics(&can.Cultivar{Name: "Gelato"}))
+	assert.Equal(t, "70/30, Indica-dominant hybrid", cultivarGenetics(&can.Cultivar{Indica: 70, Sativa: 30}))
+	assert.Equal(t, "50/50, balanced hybrid", cultivarGenetics(&can.Cultivar{Indica: 50, Sativa: 50}))
+	assert.Equal(t, "0/100, Sativa", cultivarGenetics(&can.Cultivar{Sativa: 100}))
+}
+
+func TestValidateRatio(t *testing.T) {
+	assert.NoError(t, validateRatio(""))
+	assert.NoError(t, validateRatio("70/30"))
+	assert.Error(t, validateRatio("70/40"))
+}
+
+func TestLineageLines(t *testing.T) {
+	cultivars := []*can.Cultivar{
+		{Name: "Gelato", Indica: 55, Sativa: 45, Parents: []string{"Sunset Sherbet", "Thin Mint GSC"}},
+		{Name: "Thin Mint GSC", Parents: []string{"OG Kush", "Durban Poison"}},
+	}
+	strains := []*can.Strain{{Strain: "Gelato Aurora", Cultivar: "Gelato"}}
+
+	assert.Equal(t, []string{
+		"Parents",
+		"├─ Sunset Sherbet",
+		"└─ Thin Mint GSC",
+		"   ├─ OG Kush",
+		"   └─ Durban Poison",
+		"Children",
+		"No known children.",
+	}, lineageLines(service.BuildLineage("Gelato", cultivars, strains)))
+
+	assert.Equal(t, []string{
+		"Parents",
+		"No known parents.",
+		"Children",
+		"└─ Thin Mint GSC",
+		"   └─ Gelato (55/45): Gelato Aurora",
+	}, lineageLines(service.BuildLineage("OG Kush", cultivars, strains)))
+}
+
+func TestStrainPreviewModel_Lineage(t *testing.T) {
+	spm := initialStrainPreviewModel()
+	spm.strain = &can.Strain{ID: uuid.New(), Strain: "Gelato Aurora", Cultivar: "Gelato"}
+	spm.cultivar = &can.Cultivar{Name: "Gelato", Indica: 55, Sativa: 45}
+
+	assert.Contains(t, spm.View(), "Cultivar: Gelato (55/45, Indica-dominant hybrid)")
+
+	spm.showLineage = true
+	spm.lineage = service.BuildLineage("Gelato", []*can.Cultivar{spm.cultivar}, nil)
+	view := spm.View()
+	assert.Contains(t, view, "Lineage")
+	assert.Contains(t, view, "No known parents.")
+	assert.NotContains(t, view, "No batches")
+}
@@ -1,6 +1,9 @@
 package tui
 
 import (
+	"github.com/TheDonDope/wits-tui/pkg/i18n"
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
@@ -10,18 +13,32 @@ var appliances = []string{
 	"[=== Devices ===]",
 	"[=== Settings ===]",
 	"[=== Statistics ===]",
+	"[=== Prescriptions ===]",
+	"[=== Recipes ===]",
+	"[=== Reference ===]",
 }
 
 // MenuModel is the tea.Model for the main menu.
 type MenuModel struct {
-	cursor int
-	items  []string
+	cursor   int
+	items    []string
+	styles   *Styles
+	help     help.Model
+	alerts   *AlertBarModel
+	showHelp bool
 }
 
-// InitialMenuModel returns the initial model for the main menu.
+// InitialMenuModel returns the initial model for the main menu, with a banner
+// showing the current stock and prescription warnings.
 func InitialMenuModel() MenuModel {
+	styles := NewStyles(lipgloss.DefaultRenderer())
+	alerts := initialAlertBarModel()
+	alerts.alerts = menuAlerts()
 	return MenuModel{
-		items: appliances,
+		items:  appliances,
+		styles: styles,
+		help:   styles.helpModel(),
+		alerts: alerts,
 	}
 }
 
@@ -36,81 +53,55 @@ func (m MenuModel) Init() tea.Cmd {
 func (m MenuModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "ctrl+c", "q":
+		switch {
+		case key.Matches(msg, keys.Quit):
 			return m, tea.Quit
-		case "up", "k":
+		case key.Matches(msg, keys.Help):
+			m.showHelp = !m.showHelp
+		case key.Matches(msg, keys.Up):
 			m.cursor--
 			if m.cursor < 0 {
 				m.cursor = len(m.items) - 1 // Wrap to last item
 			}
-		case "down", "j":
+		case key.Matches(msg, keys.Down):
 			m.cursor++
 			if m.cursor >= len(m.items) {
 				m.cursor = 0 // Wrap to first item
 			}
-		case "enter":
+		case key.Matches(msg, keys.Select):
 			return onMenuSelected(m)
-		case "esc":
+		case key.Matches(msg, keys.Back):
 			return InitialMenuModel(), nil
 		}
+	case remindersDueMsg:
+		m.alerts.alerts = append(reminderAlerts(msg.due), m.alerts.alerts...)
 	}
 	return m, nil
 }
 
-// View renders the program's UI using Lipgloss for styling.
+// View renders the program's UI using the Lipgloss styles of the active theme.
 func (m MenuModel) View() string {
-	// Create a fancy header style using Lipgloss.
-	headerStyle := lipgloss.NewStyle().
-		Bold(true).
-		Foreground(lipgloss.Color("230")).     // Light text color.
-		Background(lipgloss.Color("#4CAF50")). // Calm emerald green background.
-		Padding(1, 4).
-		Border(lipgloss.RoundedBorder(), true).
-		Align(lipgloss.Center).
-		Width(40)
-	header := headerStyle.Render(" Wits")
-
-	// Set a fixed width for items.
-	itemWidth := 30
-
-	// Define a style for unselected menu items.
-	itemStyle := lipgloss.NewStyle().
-		Border(lipgloss.NormalBorder(), true).
-		Padding(0, 1).
-		Width(itemWidth).
-		Align(lipgloss.Center).
-		Foreground(lipgloss.Color("240")).
-		Background(lipgloss.Color("0"))
-
-	// Define a style for the selected menu item: bold with highlighted background.
-	selectedStyle := lipgloss.NewStyle().
-		Bold(true).
-		Border(lipgloss.RoundedBorder(), true).
-		Padding(0, 1).
-		Width(itemWidth).
-		Align(lipgloss.Center).
-		Foreground(lipgloss.Color("230")). // Light text color for contrast.
-		Background(lipgloss.Color("236"))  // Highlight background.
+	header := m.styles.MenuHeader.Render(" Wits")
 
 	// Render each menu item.
 	s := header + "\n\n"
+	if banner := m.alerts.View(); banner != "" {
+		s += banner + "\n\n"
+	}
 	for i, item := range m.items {
 		var rendered string
 		if m.cursor == i {
-			rendered = selectedStyle.Render(item)
+			rendered = m.styles.MenuSelectedItem.Render(i18n.T(item))
 		} else {
-			rendered = itemStyle.Render(item)
+			rendered = m.styles.MenuItem.Render(i18n.T(item))
 		}
 		s += rendered + "\n\n"
 	}
-	s += "\nPress ctrl+c or q to quit."
+	m.help.ShowAll = m.showHelp
+	s += "\n" + m.help.View(keys.menuHelp())
 
 	// Wrap the entire view in a container style that centers the block.
-	containerStyle := lipgloss.NewStyle().
-		Width(80). // Set the container width to 80 (adjust as needed)
-		Align(lipgloss.Center)
-	return containerStyle.Render(s)
+	return m.styles.MenuContainer.Render(s)
 }
 
 // onMenuSelected returns a model for the selected menu.
@@ -126,6 +117,15 @@ func onMenuSelected(m MenuModel) (tea.Model, tea.Cmd) {
 		return initialSettingsModel(), nil
 	case 3:
 		return initialStatisticsHomeModel(), nil
+	case 4:
+		phm := initialPrescriptionsHomeModel()
+		return phm, phm.onPrescriptionsListed()
+	case 5:
+		rhm := initialRecipesHomeModel()
+		return rhm, rhm.onRecipesListed()
+	case 6:
+		rhm := initialReferenceHomeModel()
+		return rhm, rhm.onReferenceListed()
 	}
 	return m, nil
 }
@@ -0,0 +1,334 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	can "github.com/TheDonDope/wits-tui/pkg/cannabis"
+	"github.com/TheDonDope/wits-tui/pkg/i18n"
+	"github.com/TheDonDope/wits-tui/pkg/service"
+	"github.com/TheDonDope/wits-tui/pkg/storage"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/huh"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/google/uuid"
+)
+
+const prescriptionsTitle = "📜 Prescriptions"
+
+type prescriptionsListedMsg struct {
+	items  []list.Item
+	alerts []alert
+}
+
+type prescriptionSubmittedMsg struct {
+	prescription *can.Prescription
+}
+
+// PrescriptionsHomeModel is the tea.Model for the Prescriptions appliance.
+type PrescriptionsHomeModel struct {
+	hm            *HomeModel
+	prescriptions service.PrescriptionService
+	strains       service.StrainService
+	alerts        *AlertBarModel
+}
+
+// initialPrescriptionsHomeModel returns a new PrescriptionsHomeModel, with the
+// following contents:
+//   - rendered title
+func initialPrescriptionsHomeModel() *PrescriptionsHomeModel {
+	log.Println("💬 💾  (pkg/tui/prescriptions.go) initialPrescriptionsHomeModel()")
+	p := &PrescriptionsHomeModel{
+		hm:            initialHomeModel(),
+		prescriptions: service.NewPrescriptionService(storage.NewPrescriptionStore()),
+		strains:       service.NewStrainService(storage.NewStrainStore()),
+		alerts:        initialAlertBarModel(),
+	}
+	p.hm.Title(breadcrumbTitle(p.hm.title, i18n.T(prescriptionsTitle)))
+	p.hm.List(initialPrescriptionListModel())
+	p.hm.Bar(p.alerts)
+	p.hm.Keys(keys.prescriptionsHelp())
+	return p
+}
+
+// PrescriptionsHomeModel implementation of tea.Model interface ----------------
+
+// Init is the first function that will be called. It returns an optional
+// initial command. To not perform an initial command return nil.
+func (phm *PrescriptionsHomeModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update is called when a message is received. Use it to inspect messages
+// and, in response, update the model and/or send a command.
+func (phm *PrescriptionsHomeModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, keys.Back):
+			return InitialMenuModel(), nil
+		case key.Matches(msg, keys.AddPrescription):
+			return phm, onPrescriptionAdded(phm.strains.GetStrains())
+		}
+	case prescriptionSubmittedMsg:
+		if err := phm.prescriptions.AddPrescription(msg.prescription); err != nil {
+			log.Printf("🚨 💾  (pkg/tui/prescriptions.go) 🗒️  Failed to add prescription with error: %v \n", err)
+		}
+		return phm, phm.onPrescriptionsListed()
+	case prescriptionsListedMsg:
+		phm.alerts.alerts = msg.alerts
+	}
+
+	var cmd tea.Cmd
+	hm, cmd := phm.hm.Update(msg)
+	phm.hm = hm.(*HomeModel)
+	return phm, cmd
+}
+
+// View renders the PrescriptionsHomeModel UI, which is just a string. The view
+// is rendered after every Update.
+func (phm *PrescriptionsHomeModel) View() string {
+	return phm.hm.View()
+}
+
+// onPrescriptionsListed retrieves all prescriptions from the service and
+// returns a message containing the results as a slice of list items, together
+// with the warnings about them.
+func (phm *PrescriptionsHomeModel) onPrescriptionsListed() tea.Cmd {
+	return func() tea.Msg {
+		strains := phm.strains.GetStrains()
+		now := time.Now()
+		items := []list.Item{}
+		for _, p := range phm.prescriptions.GetPrescriptions() {
+			items = append(items, PrescriptionListItem{value: p, remaining: p.Remaining(strains, now), valid: p.Valid(now)})
+		}
+		return prescriptionsListedMsg{items: items, alerts: prescriptionAlerts(phm.prescriptions.Warnings(strains, now))}
+	}
+}
+
+// prescriptionAlerts returns the alerts for the given prescription warnings.
+func prescriptionAlerts(warnings []service.PrescriptionWarning) []alert {
+	var alerts []alert
+	for _, w := range warnings {
+		alerts = append(alerts, alert{warningAlert, service.PrescriptionWarningText(w)})
+	}
+	return alerts
+}
+
+// onPrescriptionAdded runs the form to add a prescription, offering the given
+// strains, and on submission sends a message with the parsed prescription.
+func onPrescriptionAdded(strains []*can.Strain) tea.Cmd {
+	form := initialPrescriptionForm(strains)
+
+	if err := form.Run(); err != nil {
+		fmt.Fprint(os.Stderr, i18n.T("Error running prescription creation form: %v\n", err))
+		return nil
+	}
+
+	prescription := parsePrescription(form)
+	return func() tea.Msg { return prescriptionSubmittedMsg{prescription} }
+}
+
+// initialPrescriptionForm returns a form for creating a new prescription.
+func initialPrescriptionForm(strains []*can.Strain) *huh.Form {
+	fields := []huh.Field{
+		huh.NewInput().
+			Key("doctor").
+			Title(i18n.T("Doctor")).
+			Description(i18n.T("The prescribing doctor")),
+
+		huh.NewInput().
+			Key("issued").
+			Title(i18n.T("Issue date")).
+			Description(i18n.T("The issue date (YYYY-MM-DD)")).
+			Value(ptr(time.Now().Format(dateLayout))).
+			Validate(validateRequiredDate),
+
+		huh.NewInput().
+			Key("valid").
+			Title(i18n.T("Valid until")).
+			Description(i18n.T("The last day the prescription can be redeemed (YYYY-MM-DD)")).
+			Validate(validateRequiredDate),
+
+		huh.NewInput().
+			Key("grams").
+			Title(i18n.T("Quota (g)")).
+			Description(i18n.T("The allowed grams per period, leave empty if not limited")).
+			Validate(validateFloat),
+
+		huh.NewInput().
+			Key("period").
+			Title(i18n.T("Period (days)")).
+			Description(i18n.T("The length of a quota period")).
+			Value(ptr(strconv.Itoa(can.DefaultQuotaPeriod))).
+			Validate(validatePositiveInt),
+	}
+	if len(strains) > 0 {
+		var options []huh.Option[string]
+		for _, s := range strains {
+			options = append(options, huh.NewOption(s.Strain, s.Strain))
+		}
+		fields = append(fields, huh.NewMultiSelect[string]().
+			Key("strains").
+			Options(options...).
+			Title(i18n.T("Strains")).
+			Description(i18n.T("The allowed strains, select none to allow any")))
+	}
+	return huh.NewForm(huh.NewGroup(fields...)).WithTheme(formTheme())
+}
+
+// validateRequiredDate returns an error if the given input is no date.
+func validateRequiredDate(input string) error {
+	if strings.TrimSpace(input) == "" {
+		return errors.New(i18n.T("Please enter a date as YYYY-MM-DD"))
+	}
+	return validateDate(input)
+}
+
+// validatePositiveInt returns an error if the given input is no positive
+// integer.
+func validatePositiveInt(input string) error {
+	if n, err := strconv.Atoi(strings.TrimSpace(input)); err != nil || n <= 0 {
+		return errors.New(i18n.T("Please enter a positive whole number"))
+	}
+	return nil
+}
+
+// parsePrescription creates a new prescription entity from the given form
+// data.
+func parsePrescription(form *huh.Form) *can.Prescription {
+	period, err := strconv.Atoi(strings.TrimSpace(form.GetString("period")))
+	if err != nil {
+		period = can.DefaultQuotaPeriod
+	}
+	var strains []string
+	if val, ok := form.Get("strains").([]string); ok {
+		strains = val
+	}
+	return &can.Prescription{
+		ID:         uuid.New(),
+		Doctor:     form.GetString("doctor"),
+		IssuedAt:   parseDate(form.GetString("issued")),
+		ValidUntil: parseDate(form.GetString("valid")),
+		Grams:      parseFloatWithDefault(form.GetString("grams"), 0),
+		PeriodDays: period,
+		Strains:    strains,
+		CreatedAt:  time.Now(),
+		UpdatedAt:  time.Now(),
+	}
+}
+
+// prescriptionOptions returns the options to select the prescription a batch
+// of the given strain is dispensed on, among the given prescriptions which
+// are valid now and allow the strain.
+func prescriptionOptions(strain *can.Strain, prescriptions []*can.Prescription, strains []*can.Strain) []huh.Option[uuid.UUID] {
+	now := time.Now()
+	var options []huh.Option[uuid.UUID]
+	for _, p := range prescriptions {
+		if !p.Valid(now) || !p.Allows(strain.Strain) {
+			continue
+		}
+		options = append(options, huh.NewOption(i18n.T("%s, issued %s (%s g left)",
+			p.Doctor, p.IssuedAt.Format(dateLayout), i18n.FormatFloat(p.Remaining(strains, now), 1)), p.ID))
+	}
+	if len(options) == 0 {
+		return nil
+	}
+	return append([]huh.Option[uuid.UUID]{huh.NewOption(i18n.T("None (self-paid)"), uuid.Nil)}, options...)
+}
+
+// PrescriptionListItem is a list item for prescriptions.
+type PrescriptionListItem struct {
+	value     *can.Prescription
+	remaining float64
+	valid     bool
+}
+
+// PrescriptionListItem implementation of list.Item interface ------------------
+
+// FilterValue is the value we use when filtering against this item when
+// we're filtering the list.
+func (pli PrescriptionListItem) FilterValue() string {
+	return pli.value.Doctor + " " + strings.Join(pli.value.Strains, " ")
+}
+
+// Title returns the title for the list item.
+func (pli PrescriptionListItem) Title() string {
+	title := i18n.T("%s, issued %s", pli.value.Doctor, pli.value.IssuedAt.Format(dateLayout))
+	if !pli.valid {
+		title += " (" + i18n.T("not valid") + ")"
+	}
+	return title
+}
+
+// Description returns the description for the list item.
+func (pli PrescriptionListItem) Description() string {
+	strains := i18n.T("any")
+	if len(pli.value.Strains) > 0 {
+		strains = strings.Join(pli.value.Strains, ", ")
+	}
+	if !pli.value.HasQuota() {
+		return i18n.T("Valid until %s, no quota, Strains: %s", pli.value.ValidUntil.Format(dateLayout), strains)
+	}
+	return i18n.T("Valid until %s, %s of %s g left per %d days, Strains: %s",
+		pli.value.ValidUntil.Format(dateLayout),
+		i18n.FormatFloat(pli.remaining, 1),
+		i18n.FormatFloat(pli.value.Grams, 1),
+		pli.value.PeriodDays,
+		strains)
+}
+
+// PrescriptionListModel is a tea.Model for the prescriptions list.
+type PrescriptionListModel struct {
+	list list.Model
+}
+
+// initialPrescriptionListModel creates a new model for the prescriptions
+// list, without any items.
+func initialPrescriptionListModel() *PrescriptionListModel {
+	styles := NewStyles(lipgloss.DefaultRenderer())
+	l := list.New([]list.Item{}, styles.listDelegate(), 60, 20)
+	l.Styles = styles.listStyles()
+	l.Title = i18n.T("Prescriptions")
+	l.SetStatusBarItemName(i18n.T("prescription"), i18n.T("prescriptions"))
+	l.SetShowHelp(false)
+	l.SetFilteringEnabled(false)
+	l.KeyMap.Quit.SetEnabled(false)
+	l.KeyMap.ShowFullHelp.SetEnabled(false)
+	l.KeyMap.CloseFullHelp.SetEnabled(false)
+	return &PrescriptionListModel{list: l}
+}
+
+// PrescriptionListModel implementation of tea.Model interface -----------------
+
+// Init is the first function that will be called. It returns an optional
+// initial command. To not perform an initial command return nil.
+func (plm *PrescriptionListModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update is called when a message is received. Use it to inspect messages
+// and, in response, update the model and/or send a command.
+func (plm *PrescriptionListModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case prescriptionsListedMsg:
+		return plm, plm.list.SetItems(msg.items)
+	}
+
+	var cmd tea.Cmd
+	plm.list, cmd = plm.list.Update(msg)
+	return plm, cmd
+}
+
+// View renders the PrescriptionListModel UI, which is just a string. The view
+// is rendered after every Update.
+func (plm *PrescriptionListModel) View() string {
+	return plm.list.View()
+}
@@ -0,0 +1,124 @@
+package tui
+
+import (
+	"testing"
+	"time"
+
+	can "github.com/TheDonDope/wits-tui/pkg/cannabis"
+	"github.com/TheDonDope/wits-tui/pkg/service"
+	"github.com/TheDonDope/wits-tui/pkg/storage"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testPrescription returns a prescription valid from yesterday for a month.
+func testPrescription() *can.Prescription {
+	today := time.Now()
+	return &can.Prescription{
+		ID:         uuid.New(),
+		Doctor:     "Dr. Green",
+		IssuedAt:   today.AddDate(0, 0, -1),
+		ValidUntil: today.AddDate(0, 1, 0),
+		Grams:      30,
+		PeriodDays: 30,
+		Strains:    []string{"Pink Kush"},
+	}
+}
+
+func TestPrescriptionsHomeModel(t *testing.T) {
+	t.Setenv("STORAGE_MODE", storage.StoreInMemory)
+
+	t.Run("Initialization", func(t *testing.T) {
+		model := initialPrescriptionsHomeModel()
+		assert.Equal(t, breadcrumbTitle(homeTitle, prescriptionsTitle), model.hm.title)
+	})
+
+	t.Run("EscapeKey", func(t *testing.T) {
+		model := initialPrescriptionsHomeModel()
+
+		updatedModel, _ := model.Update(tea.KeyMsg{Type: tea.KeyEscape})
+
+		assert.IsType(t, MenuModel{}, updatedModel)
+	})
+
+	t.Run("Listed", func(t *testing.T) {
+		model := initialPrescriptionsHomeModel()
+		p := testPrescription()
+		p.ValidUntil = time.Now().AddDate(0, 0, 3)
+		require.NoError(t, model.prescriptions.AddPrescription(p))
+
+		msg := model.onPrescriptionsListed()()
+		model.Update(msg)
+		view := model.View()
+
+		assert.Contains(t, view, "Dr. Green")
+		assert.Contains(t, view, "30.0 of 30.0 g left")
+		assert.Contains(t, view, "expires in 3 days")
+	})
+}
+
+func TestPrescriptionAlerts(t *testing.T) {
+	p := testPrescription()
+	alerts := prescriptionAlerts([]service.PrescriptionWarning{
+		{Prescription: p, Kind: service.ExpiresSoon, DaysLeft: 5},
+		{Prescription: p, Kind: service.QuotaNearlyUsed, Remaining: 4.5},
+	})
+
+	require.Len(t, alerts, 2)
+	assert.Equal(t, "The prescription of Dr. Green expires in 5 days.", alerts[0].text)
+	assert.Equal(t, "Only 4.5 g are left of the quota prescribed by Dr. Green.", alerts[1].text)
+}
+
+func TestPrescriptionOptions(t *testing.T) {
+	valid := testPrescription()
+	expired := testPrescription()
+	expired.ValidUntil = time.Now().AddDate(0, 0, -2)
+	other := testPrescription()
+	other.Strains = []string{"Wedding Cake"}
+	prescriptions := []*can.Prescription{valid, expired, other}
+
+	options := prescriptionOptions(&can.Strain{Strain: "Pink Kush"}, prescriptions, nil)
+
+	require.Len(t, options, 2)
+	assert.Equal(t, uuid.Nil, options[0].Value)
+	assert.Equal(t, valid.ID, options[1].Value)
+	assert.Nil(t, prescriptionOptions(&can.Strain{Strain: "Ghost Train Haze"}, prescriptions, nil))
+}
+
+func TestAlertBarModel_View(t *testing.T) {
+	abm := initialAlertBarModel()
+	assert.Empty(t, abm.View())
+
+	abm.alerts = []alert{{warningAlert, "careful"}, {errorAlert, "failed"}}
+	view := abm.View()
+
+	assert.Contains(t, view, "careful")
+	assert.Contains(t, view, "failed")
+}
+
+func TestStrainsHomeModel_PurchaseRejected(t *testing.T) {
+	t.Setenv("STORAGE_MODE", storage.StoreInMemory)
+	shm := initialStrainsHomeModel()
+	p := testPrescription()
+	require.NoError(t, shm.prescriptions.AddPrescription(p))
+	require.NoError(t, shm.service.AddStrain(&can.Strain{ID: uuid.New(), Strain: "Pink Kush"}))
+
+	_, cmd := shm.Update(batchSubmittedMsg{product: "Pink Kush", batch: &can.Batch{
+		PrescriptionID: p.ID, Grams: 40, Remaining: 40, PurchasedAt: time.Now()}})
+
+	assert.Nil(t, cmd)
+	require.Len(t, shm.alerts.alerts, 1)
+	assert.Equal(t, errorAlert, shm.alerts.alerts[0].level)
+	strain, err := shm.service.FindStrainByProduct("Pink Kush")
+	require.NoError(t, err)
+	assert.Empty(t, strain.Batches)
+
+	// Within the quota the batch is added and the strains are listed again
+	_, cmd = shm.Update(batchSubmittedMsg{product: "Pink Kush", batch: &can.Batch{
+		PrescriptionID: p.ID, Grams: 10, Remaining: 10, PurchasedAt: time.Now()}})
+	require.NotNil(t, cmd)
+	assert.IsType(t, strainsListedMsg{}, cmd())
+	assert.Len(t, strain.Batches, 1)
+}
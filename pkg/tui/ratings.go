@@ -0,0 +1,144 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	can "github.com/TheDonDope/wits-tui/pkg/cannabis"
+	"github.com/TheDonDope/wits-tui/pkg/i18n"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/huh"
+	"github.com/google/uuid"
+)
+
+type ratingSubmittedMsg struct {
+	product string
+	rating  *can.Rating
+}
+
+// onStrainRated runs the form to rate the given strain and on submission sends
+// a message with the parsed rating from the form.
+func onStrainRated(strain *can.Strain) tea.Cmd {
+	form := initialRatingForm(strain)
+
+	if err := form.Run(); err != nil {
+		fmt.Fprint(os.Stderr, i18n.T("Error running rating form: %v\n", err))
+		return nil
+	}
+
+	rating := parseRating(form)
+	if rating == nil {
+		return nil
+	}
+	product := strain.Strain
+	return func() tea.Msg { return ratingSubmittedMsg{product: product, rating: rating} }
+}
+
+// initialRatingForm returns a form for rating the given strain and taking
+// tasting notes.
+func initialRatingForm(strain *can.Strain) *huh.Form {
+	return huh.NewForm(
+		huh.NewGroup(
+			huh.NewNote().
+				Title(i18n.T("Rating of %s", strain.Strain)),
+
+			starsSelect("overall", i18n.T("Overall"), i18n.T("How much you like the strain")),
+			starsSelect("taste", i18n.T("Taste"), i18n.T("How much you like the taste and smell")),
+			starsSelect("effect", i18n.T("Effect"), i18n.T("How well the strain works for you")),
+			starsSelect("value", i18n.T("Value"), i18n.T("How much the strain is worth its price")),
+
+			huh.NewInput().
+				Key("tags").
+				Title(i18n.T("Tags")).
+				Description(i18n.T("Comma separated tags (e.g. smooth, citrus)")),
+
+			huh.NewText().
+				Key("notes").
+				Title(i18n.T("Tasting notes")).
+				Description(i18n.T("How the strain smells, tastes and feels")),
+		),
+	).WithTheme(formTheme())
+}
+
+// starsSelect returns a select to rate an aspect from 1 to can.MaxRating stars,
+// or not at all.
+func starsSelect(key, title, description string) *huh.Select[int] {
+	options := []huh.Option[int]{huh.NewOption(i18n.T("not rated"), 0)}
+	for s := can.MaxRating; s > 0; s-- {
+		options = append(options, huh.NewOption(strings.Repeat("★", s), s))
+	}
+	return huh.NewSelect[int]().
+		Key(key).
+		Options(options...).
+		Title(title).
+		Description(description)
+}
+
+// parseRating creates a new rating entity from the given form data. Ratings
+// without any stars, tags or notes are discarded.
+func parseRating(form *huh.Form) *can.Rating {
+	r := &can.Rating{
+		ID:      uuid.New(),
+		Notes:   strings.TrimSpace(form.GetString("notes")),
+		Tags:    parseTags(form.GetString("tags")),
+		RatedAt: time.Now(),
+	}
+	r.Overall, _ = form.Get("overall").(int)
+	r.Taste, _ = form.Get("taste").(int)
+	r.Effect, _ = form.Get("effect").(int)
+	r.Value, _ = form.Get("value").(int)
+	if r.Overall+r.Taste+r.Effect+r.Value == 0 && r.Notes == "" && len(r.Tags) == 0 {
+		return nil
+	}
+	return r
+}
+
+// parseTags splits the given comma separated tags, dropping empty ones.
+func parseTags(input string) []string {
+	var tags []string
+	for _, t := range strings.Split(input, ",") {
+		if t = strings.ToLower(strings.TrimSpace(t)); t != "" {
+			tags = append(tags, t)
+		}
+	}
+	return tags
+}
+
+// ratingSummary returns the average overall rating and the number of ratings
+// of the given averages, e.g. `★ 4.5 (2)`.
+func ratingSummary(avg can.RatingAverages) string {
+	return fmt.Sprintf("★ %s (%d)", i18n.FormatFloat(avg.Overall, 1), avg.Count)
+}
+
+// ratingAspects returns the average ratings of all rated aspects of the given
+// averages.
+func ratingAspects(avg can.RatingAverages) string {
+	var parts []string
+	for _, a := range []struct {
+		label string
+		value float64
+	}{
+		{i18n.T("Overall"), avg.Overall},
+		{i18n.T("Taste"), avg.Taste},
+		{i18n.T("Effect"), avg.Effect},
+		{i18n.T("Value"), avg.Value},
+	} {
+		if a.value > 0 {
+			parts = append(parts, a.label+" "+i18n.FormatFloat(a.value, 1))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// latestNotes returns the tasting notes of the latest rating which has some,
+// or an empty string.
+func latestNotes(strain *can.Strain) string {
+	for i := len(strain.Ratings) - 1; i >= 0; i-- {
+		if n := strain.Ratings[i].Notes; n != "" {
+			return n
+		}
+	}
+	return ""
+}
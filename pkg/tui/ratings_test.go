@@ -0,0 +1,48 @@
+package tui
+
+import (
+	"testing"
+
+	can "github.com/TheDonDope/wits-tui/pkg/cannabis"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTags(t *testing.T) {
+	assert.Equal(t, []string{"smooth", "citrus"}, parseTags(" Smooth, ,citrus "))
+	assert.Empty(t, parseTags(""))
+}
+
+func TestRatingTexts(t *testing.T) {
+	avg := can.RatingAverages{Overall: 4.5, Taste: 4, Count: 2}
+
+	assert.Equal(t, "★ 4.5 (2)", ratingSummary(avg))
+	assert.Equal(t, "Overall 4.5, Taste 4.0", ratingAspects(avg))
+	assert.Equal(t, "–", ratingValue(can.RatingAverages{}))
+}
+
+func TestStrainListItem_Rating(t *testing.T) {
+	strain := &can.Strain{ID: uuid.New(), Strain: "Pink Kush", Favorite: true,
+		Ratings: []*can.Rating{{Overall: 4}, {Overall: 5}}}
+	item := StrainListItem{value: strain}
+
+	assert.Equal(t, "📌 Pink Kush", item.Title())
+	assert.Contains(t, item.Description(), "Rating: ★ 4.5 (2)")
+
+	strain.Ratings = nil
+	assert.NotContains(t, item.Description(), "Rating")
+}
+
+func TestStrainPreviewModel_Ratings(t *testing.T) {
+	spm := initialStrainPreviewModel()
+	spm.strain = &can.Strain{ID: uuid.New(), Strain: "Pink Kush", Ratings: []*can.Rating{
+		{Overall: 4, Value: 3, Notes: "Earthy", Tags: []string{"smooth"}},
+		{Overall: 5, Tags: []string{"sweet"}},
+	}}
+
+	view := spm.View()
+
+	assert.Contains(t, view, "Rating: Overall 4.5, Value 3.0, 2 ratings")
+	assert.Contains(t, view, "Tags: smooth, sweet")
+	assert.Contains(t, view, "Tasting notes: Earthy")
+}
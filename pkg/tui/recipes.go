@@ -0,0 +1,369 @@
+package tui
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	can "github.com/TheDonDope/wits-tui/pkg/cannabis"
+	"github.com/TheDonDope/wits-tui/pkg/i18n"
+	"github.com/TheDonDope/wits-tui/pkg/service"
+	"github.com/TheDonDope/wits-tui/pkg/storage"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/huh"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/google/uuid"
+)
+
+const (
+	recipesTitle = "🍪 Recipes"
+
+	// defaultDecarbTemperature is the suggested oven temperature in °C.
+	defaultDecarbTemperature = 120
+	// defaultDecarbMinutes is the suggested time in the oven in minutes.
+	defaultDecarbMinutes = 40
+)
+
+type recipesListedMsg struct {
+	items []list.Item
+}
+
+type recipeSubmittedMsg struct {
+	recipe *can.Recipe
+}
+
+// RecipesHomeModel is the tea.Model for the Recipes appliance.
+type RecipesHomeModel struct {
+	hm      *HomeModel
+	recipes service.RecipeService
+	strains service.StrainService
+}
+
+// initialRecipesHomeModel returns a new RecipesHomeModel, with the following
+// contents:
+//   - rendered title
+func initialRecipesHomeModel() *RecipesHomeModel {
+	log.Println("💬 💾  (pkg/tui/recipes.go) initialRecipesHomeModel()")
+	r := &RecipesHomeModel{
+		hm:      initialHomeModel(),
+		recipes: service.NewRecipeService(storage.NewRecipeStore()),
+		strains: service.NewStrainService(storage.NewStrainStore()),
+	}
+	r.hm.Title(breadcrumbTitle(r.hm.title, i18n.T(recipesTitle)))
+	r.hm.List(initialRecipeListModel())
+	r.hm.Keys(keys.recipesHelp())
+	return r
+}
+
+// RecipesHomeModel implementation of tea.Model interface ----------------------
+
+// Init is the first function that will be called. It returns an optional
+// initial command. To not perform an initial command return nil.
+func (rhm *RecipesHomeModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update is called when a message is received. Use it to inspect messages
+// and, in response, update the model and/or send a command.
+func (rhm *RecipesHomeModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, keys.Back):
+			return InitialMenuModel(), nil
+		case key.Matches(msg, keys.AddRecipe):
+			return rhm, onRecipeAdded(rhm.strains.GetStrains())
+		}
+	case recipeSubmittedMsg:
+		if err := rhm.recipes.AddRecipe(msg.recipe); err != nil {
+			log.Printf("🚨 💾  (pkg/tui/recipes.go) 🗒️  Failed to add recipe with error: %v \n", err)
+		}
+		return rhm, rhm.onRecipesListed()
+	}
+
+	var cmd tea.Cmd
+	hm, cmd := rhm.hm.Update(msg)
+	rhm.hm = hm.(*HomeModel)
+	return rhm, cmd
+}
+
+// View renders the RecipesHomeModel UI, which is just a string. The view is
+// rendered after every Update.
+func (rhm *RecipesHomeModel) View() string {
+	return rhm.hm.View()
+}
+
+// onRecipesListed retrieves all recipes from the service and returns a
+// message containing the results as a slice of list items.
+func (rhm *RecipesHomeModel) onRecipesListed() tea.Cmd {
+	return func() tea.Msg {
+		items := []list.Item{}
+		for _, r := range rhm.recipes.GetRecipes() {
+			items = append(items, RecipeListItem{value: r})
+		}
+		return recipesListedMsg{items: items}
+	}
+}
+
+// onRecipeAdded runs the form to add a recipe using one of the given strains
+// and on submission sends a message with the parsed recipe. Without strains
+// there is nothing to cook with, so no form is shown.
+func onRecipeAdded(strains []*can.Strain) tea.Cmd {
+	if len(strains) == 0 {
+		log.Println("ℹ️  💾  (pkg/tui/recipes.go) 🗒️  No strains available for a recipe.")
+		return nil
+	}
+	form := initialRecipeForm(strains)
+
+	if err := form.Run(); err != nil {
+		fmt.Fprint(os.Stderr, i18n.T("Error running recipe creation form: %v\n", err))
+		return nil
+	}
+
+	recipe := parseRecipe(form)
+	if recipe == nil {
+		return nil
+	}
+	return func() tea.Msg { return recipeSubmittedMsg{recipe} }
+}
+
+// initialRecipeForm returns a form for creating a new recipe using one of the
+// given strains. The form shows the expected yield of the entered values.
+func initialRecipeForm(strains []*can.Strain) *huh.Form {
+	var options []huh.Option[*can.Strain]
+	for _, s := range strains {
+		options = append(options, huh.NewOption(s.Strain, s))
+	}
+	strain := strains[0]
+	grams := ""
+	temperature := strconv.Itoa(defaultDecarbTemperature)
+	minutes := strconv.Itoa(defaultDecarbMinutes)
+	carrier := can.Butter
+	servings := ""
+	return huh.NewForm(
+		huh.NewGroup(
+			huh.NewInput().
+				Key("name").
+				Title(i18n.T("Name")).
+				Description(i18n.T("The name of the recipe")),
+
+			huh.NewSelect[*can.Strain]().
+				Key("strain").
+				Options(options...).
+				Title(i18n.T("Strain")).
+				Description(i18n.T("The strain to cook with")).
+				Value(&strain),
+
+			huh.NewInput().
+				Key("grams").
+				Title(i18n.T("Amount (g)")).
+				Description(i18n.T("The weight of the flower")).
+				Value(&grams).
+				Validate(validateFloat),
+
+			huh.NewInput().
+				Key("temperature").
+				Title(i18n.T("Temperature (°C)")).
+				Description(i18n.T("The oven temperature")).
+				Value(&temperature).
+				Validate(validatePositiveInt),
+
+			huh.NewInput().
+				Key("minutes").
+				Title(i18n.T("Time (min)")).
+				Description(i18n.T("The time in the oven")).
+				Value(&minutes).
+				Validate(validatePositiveInt),
+
+			huh.NewSelect[can.CarrierType]().
+				Key("carrier").
+				Options(sortedCarriersList()...).
+				Title(i18n.T("Carrier")).
+				Description(i18n.T("The fat the cannabinoids are infused into")).
+				Value(&carrier),
+
+			huh.NewInput().
+				Key("servings").
+				Title(i18n.T("Servings")).
+				Description(i18n.T("The number of servings")).
+				Value(&servings).
+				Validate(validatePositiveInt),
+
+			huh.NewNote().
+				Title(i18n.T("Expected yield")).
+				DescriptionFunc(func() string {
+					r := &can.Recipe{
+						Ingredients: []*can.Ingredient{can.NewIngredient(strain, parseFloatWithDefault(grams, 0))},
+						Temperature: parseIntWithDefault(temperature, 0),
+						Minutes:     parseIntWithDefault(minutes, 0),
+						Carrier:     carrier,
+						Servings:    parseIntWithDefault(servings, 0),
+					}
+					return yieldText(r.Yield())
+				}, []any{&strain, &grams, &temperature, &minutes, &carrier, &servings}),
+
+			huh.NewText().
+				Key("notes").
+				Title(i18n.T("Notes")).
+				Description(i18n.T("Anything worth remembering")),
+		),
+	).WithTheme(formTheme())
+}
+
+// sortedCarriersList returns a list of carrier options for the user to choose
+// from.
+func sortedCarriersList() []huh.Option[can.CarrierType] {
+	var carriers []huh.Option[can.CarrierType]
+	for k, v := range can.Carriers {
+		carriers = append(carriers, huh.NewOption(i18n.Term(v.Name), k))
+	}
+	sort.Slice(carriers, func(i, j int) bool {
+		return carriers[i].Value < carriers[j].Value
+	})
+	return carriers
+}
+
+// parseIntWithDefault parses the given input to an int. If an error occurs
+// the given defaultValue is returned.
+func parseIntWithDefault(input string, defaultValue int) int {
+	if val, err := strconv.Atoi(strings.TrimSpace(input)); err == nil {
+		return val
+	}
+	return defaultValue
+}
+
+// yieldText returns the translated description of the given yield.
+func yieldText(y can.RecipeYield) string {
+	return i18n.T("%s mg THC / %s mg CBD per serving (decarboxylated: %s%% THCA, %s%% CBDA)",
+		i18n.FormatFloat(y.THCPerServing, 1),
+		i18n.FormatFloat(y.CBDPerServing, 1),
+		i18n.FormatFloat(y.THCEfficiency*100, 0),
+		i18n.FormatFloat(y.CBDEfficiency*100, 0))
+}
+
+// parseRecipe creates a new recipe entity from the given form data. Recipes
+// without a strain or servings are discarded.
+func parseRecipe(form *huh.Form) *can.Recipe {
+	strain, ok := form.Get("strain").(*can.Strain)
+	servings := parseIntWithDefault(form.GetString("servings"), 0)
+	if !ok || strain == nil || servings <= 0 {
+		return nil
+	}
+	var carrier can.CarrierType
+	if val, ok := form.Get("carrier").(can.CarrierType); ok {
+		carrier = val
+	}
+	name := strings.TrimSpace(form.GetString("name"))
+	if name == "" {
+		name = strain.Strain
+	}
+	return &can.Recipe{
+		ID:          uuid.New(),
+		Name:        name,
+		Ingredients: []*can.Ingredient{can.NewIngredient(strain, parseFloatWithDefault(form.GetString("grams"), 0))},
+		Temperature: parseIntWithDefault(form.GetString("temperature"), defaultDecarbTemperature),
+		Minutes:     parseIntWithDefault(form.GetString("minutes"), defaultDecarbMinutes),
+		Carrier:     carrier,
+		Servings:    servings,
+		Notes:       form.GetString("notes"),
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+}
+
+// recipeNames returns the names of the given recipes as a comma separated
+// list.
+func recipeNames(recipes []*can.Recipe) string {
+	var names []string
+	for _, r := range recipes {
+		names = append(names, r.Name)
+	}
+	return strings.Join(names, ", ")
+}
+
+// RecipeListItem is a list item for recipes.
+type RecipeListItem struct {
+	value *can.Recipe
+}
+
+// RecipeListItem implementation of list.Item interface ------------------------
+
+// FilterValue is the value we use when filtering against this item when
+// we're filtering the list.
+func (rli RecipeListItem) FilterValue() string {
+	return rli.value.Name
+}
+
+// Title returns the title for the list item.
+func (rli RecipeListItem) Title() string {
+	return rli.value.Name
+}
+
+// Description returns the description for the list item.
+func (rli RecipeListItem) Description() string {
+	var ingredients []string
+	for _, i := range rli.value.Ingredients {
+		ingredients = append(ingredients, i18n.T("%s g %s", i18n.FormatFloat(i.Grams, 1), i.Strain))
+	}
+	return i18n.T("%s, %d °C for %d min, %s, %d servings: %s",
+		strings.Join(ingredients, ", "),
+		rli.value.Temperature,
+		rli.value.Minutes,
+		i18n.Term(can.Carriers[rli.value.Carrier].Name),
+		rli.value.Servings,
+		yieldText(rli.value.Yield()))
+}
+
+// RecipeListModel is a tea.Model for the recipes list.
+type RecipeListModel struct {
+	list list.Model
+}
+
+// initialRecipeListModel creates a new model for the recipes list, without
+// any items.
+func initialRecipeListModel() *RecipeListModel {
+	styles := NewStyles(lipgloss.DefaultRenderer())
+	l := list.New([]list.Item{}, styles.listDelegate(), 60, 20)
+	l.Styles = styles.listStyles()
+	l.Title = i18n.T("Recipes")
+	l.SetStatusBarItemName(i18n.T("recipe"), i18n.T("recipes"))
+	l.SetShowHelp(false)
+	l.SetFilteringEnabled(false)
+	l.KeyMap.Quit.SetEnabled(false)
+	l.KeyMap.ShowFullHelp.SetEnabled(false)
+	l.KeyMap.CloseFullHelp.SetEnabled(false)
+	return &RecipeListModel{list: l}
+}
+
+// RecipeListModel implementation of tea.Model interface -----------------------
+
+// Init is the first function that will be called. It returns an optional
+// initial command. To not perform an initial command return nil.
+func (rlm *RecipeListModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update is called when a message is received. Use it to inspect messages
+// and, in response, update the model and/or send a command.
+func (rlm *RecipeListModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case recipesListedMsg:
+		return rlm, rlm.list.SetItems(msg.items)
+	}
+
+	var cmd tea.Cmd
+	rlm.list, cmd = rlm.list.Update(msg)
+	return rlm, cmd
+}
+
+// View renders the RecipeListModel UI, which is just a string. The view is
+// rendered after every Update.
+func (rlm *RecipeListModel) View() string {
+	return rlm.list.View()
+}
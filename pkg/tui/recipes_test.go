@@ -0,0 +1,74 @@
+package tui
+
+import (
+	"testing"
+
+	can "github.com/TheDonDope/wits-tui/pkg/cannabis"
+	"github.com/TheDonDope/wits-tui/pkg/storage"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testRecipe returns a recipe of 2 g of a strain with 20% THC and 1% CBD.
+func testRecipe() *can.Recipe {
+	strain := &can.Strain{ID: uuid.New(), Strain: "Pink Kush", THC: 20, CBD: 1}
+	return &can.Recipe{
+		ID:          uuid.New(),
+		Name:        "Brownies",
+		Ingredients: []*can.Ingredient{can.NewIngredient(strain, 2)},
+		Temperature: 120,
+		Minutes:     30,
+		Carrier:     can.CoconutOil,
+		Servings:    10,
+	}
+}
+
+func TestRecipesHomeModel(t *testing.T) {
+	t.Setenv("STORAGE_MODE", storage.StoreInMemory)
+
+	t.Run("Initialization", func(t *testing.T) {
+		model := initialRecipesHomeModel()
+		assert.Equal(t, breadcrumbTitle(homeTitle, recipesTitle), model.hm.title)
+	})
+
+	t.Run("EscapeKey", func(t *testing.T) {
+		model := initialRecipesHomeModel()
+
+		updatedModel, _ := model.Update(tea.KeyMsg{Type: tea.KeyEscape})
+
+		assert.IsType(t, MenuModel{}, updatedModel)
+	})
+
+	t.Run("Submitted", func(t *testing.T) {
+		model := initialRecipesHomeModel()
+
+		_, cmd := model.Update(recipeSubmittedMsg{testRecipe()})
+		require.NotNil(t, cmd)
+		model.Update(cmd())
+		view := model.View()
+
+		assert.Len(t, model.recipes.GetRecipes(), 1)
+		assert.Contains(t, view, "Brownies")
+		assert.Contains(t, view, "2.0 g Pink Kush, 120 °C for 30 min")
+	})
+}
+
+func TestYieldText(t *testing.T) {
+	y := can.RecipeYield{THCEfficiency: 0.926, CBDEfficiency: 0.7, THCPerServing: 31.46, CBDPerServing: 1.19}
+
+	assert.Equal(t, "31.5 mg THC / 1.2 mg CBD per serving (decarboxylated: 93% THCA, 70% CBDA)", yieldText(y))
+}
+
+func TestStrainPreviewModel_Recipes(t *testing.T) {
+	r := testRecipe()
+	strain := &can.Strain{ID: r.Ingredients[0].StrainID, Strain: "Pink Kush"}
+	spm := initialStrainPreviewModel()
+	spm.strain = strain
+	assert.NotContains(t, spm.View(), "Recipes")
+
+	spm.recipes = map[uuid.UUID][]*can.Recipe{strain.ID: {r}}
+
+	assert.Contains(t, spm.View(), "Recipes: Brownies")
+}
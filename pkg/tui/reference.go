@@ -0,0 +1,351 @@
+package tui
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	can "github.com/TheDonDope/wits-tui/pkg/cannabis"
+	"github.com/TheDonDope/wits-tui/pkg/i18n"
+	"github.com/TheDonDope/wits-tui/pkg/service"
+	"github.com/TheDonDope/wits-tui/pkg/storage"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/huh"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const referenceTitle = "📖 Reference"
+
+type referenceListedMsg struct {
+	items          []list.Item
+	strains        []*can.Strain
+	filter         service.ReferenceFilter
+	byBoilingPoint bool
+}
+
+type referenceFilteredMsg struct {
+	filter service.ReferenceFilter
+}
+
+// ReferenceHomeModel is the tea.Model for the Reference appliance, browsing the
+// catalog of cannabinoids and terpenes.
+type ReferenceHomeModel struct {
+	hm             *HomeModel
+	strains        service.StrainService
+	filter         service.ReferenceFilter
+	byBoilingPoint bool
+}
+
+// initialReferenceHomeModel returns a new ReferenceHomeModel, with the
+// following contents:
+//   - rendered title
+func initialReferenceHomeModel() *ReferenceHomeModel {
+	log.Println("💬 💾  (pkg/tui/reference.go) initialReferenceHomeModel()")
+	r := &ReferenceHomeModel{
+		hm:      initialHomeModel(),
+		strains: service.NewStrainService(storage.NewStrainStore()),
+	}
+	r.hm.Title(breadcrumbTitle(r.hm.title, i18n.T(referenceTitle)))
+	r.hm.List(initialReferenceListModel())
+	r.hm.Preview(initialReferencePreviewModel())
+	r.hm.Keys(keys.referenceHelp())
+	return r
+}
+
+// ReferenceHomeModel implementation of tea.Model interface --------------------
+
+// Init is the first function that will be called. It returns an optional
+// initial command. To not perform an initial command return nil.
+func (rhm *ReferenceHomeModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update is called when a message is received. Use it to inspect messages
+// and, in response, update the model and/or send a command.
+func (rhm *ReferenceHomeModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, keys.Back) && rhm.filter.Active():
+			// Clear the filter instead of leaving the appliance
+			rhm.filter = service.ReferenceFilter{}
+			return rhm, rhm.onReferenceListed()
+		case key.Matches(msg, keys.Back):
+			return InitialMenuModel(), nil
+		case key.Matches(msg, keys.FilterEffect):
+			return rhm, onReferenceFiltered(i18n.T("Effect"), i18n.T("The effect the entries must have"),
+				service.ReferenceEffects(), rhm.filter.Effect, func(effect string) service.ReferenceFilter {
+					return service.ReferenceFilter{Effect: effect, Flavor: rhm.filter.Flavor}
+				})
+		case key.Matches(msg, keys.FilterFlavor):
+			return rhm, onReferenceFiltered(i18n.T("Flavor"), i18n.T("The flavor the entries must have"),
+				service.ReferenceFlavors(), rhm.filter.Flavor, func(flavor string) service.ReferenceFilter {
+					return service.ReferenceFilter{Effect: rhm.filter.Effect, Flavor: flavor}
+				})
+		case key.Matches(msg, keys.SortByBoiling):
+			rhm.byBoilingPoint = !rhm.byBoilingPoint
+			return rhm, rhm.onReferenceListed()
+		}
+	case referenceFilteredMsg:
+		rhm.filter = msg.filter
+		return rhm, rhm.onReferenceListed()
+	case referenceListedMsg:
+		if rpm, ok := rhm.hm.preview.(*ReferencePreviewModel); ok {
+			rpm.strains = msg.strains
+		}
+	}
+
+	var cmd tea.Cmd
+	hm, cmd := rhm.hm.Update(msg)
+	rhm.hm = hm.(*HomeModel)
+	if rpm, ok := rhm.hm.preview.(*ReferencePreviewModel); ok {
+		rpm.entry = rhm.selected()
+	}
+	return rhm, cmd
+}
+
+// selected returns the catalog entry selected in the list, or nil if there is
+// none.
+func (rhm *ReferenceHomeModel) selected() *service.ReferenceEntry {
+	if rlm, ok := rhm.hm.listView.(*ReferenceListModel); ok {
+		if rli, ok := rlm.list.SelectedItem().(ReferenceListItem); ok {
+			return &rli.value
+		}
+	}
+	return nil
+}
+
+// View renders the ReferenceHomeModel UI, which is just a string. The view is
+// rendered after every Update.
+func (rhm *ReferenceHomeModel) View() string {
+	return rhm.hm.View()
+}
+
+// onReferenceListed returns a message containing the catalog entries matching
+// the filter as a slice of list items, together with the strains of the user.
+func (rhm *ReferenceHomeModel) onReferenceListed() tea.Cmd {
+	return func() tea.Msg {
+		items := []list.Item{}
+		for _, e := range service.ReferenceEntries(rhm.filter, rhm.byBoilingPoint) {
+			items = append(items, ReferenceListItem{value: e})
+		}
+		return referenceListedMsg{
+			items:          items,
+			strains:        rhm.strains.GetStrains(),
+			filter:         rhm.filter,
+			byBoilingPoint: rhm.byBoilingPoint,
+		}
+	}
+}
+
+// onReferenceFiltered runs the form to choose one of the given catalog terms
+// and on submission sends a message with the filter apply returns for the
+// chosen term. Choosing no term matches every entry.
+func onReferenceFiltered(title, description string, terms []string, current string, apply func(string) service.ReferenceFilter) tea.Cmd {
+	form := initialTermForm(title, description, terms, current)
+
+	if err := form.Run(); err != nil {
+		fmt.Fprint(os.Stderr, i18n.T("Error running reference filter form: %v\n", err))
+		return nil
+	}
+
+	filter := apply(form.GetString("term"))
+	return func() tea.Msg { return referenceFilteredMsg{filter} }
+}
+
+// initialTermForm returns a form to choose one of the given catalog terms, or
+// none of them.
+func initialTermForm(title, description string, terms []string, current string) *huh.Form {
+	options := append([]huh.Option[string]{huh.NewOption(i18n.T("All"), "")}, termOptions(terms)...)
+	return huh.NewForm(
+		huh.NewGroup(
+			huh.NewSelect[string]().
+				Key("term").
+				Options(options...).
+				Title(title).
+				Description(description).
+				Value(&current),
+		),
+	).WithTheme(formTheme())
+}
+
+// translatedTerms returns the given catalog terms translated and joined by
+// commas.
+func translatedTerms(terms []string) string {
+	translated := make([]string, len(terms))
+	for i, t := range terms {
+		translated[i] = i18n.Term(t)
+	}
+	return strings.Join(translated, ", ")
+}
+
+// ReferenceListItem is a list item for a cannabinoid or terpene of the
+// catalog.
+type ReferenceListItem struct {
+	value service.ReferenceEntry
+}
+
+// ReferenceListItem implementation of list.Item interface ---------------------
+
+// FilterValue is the value we use when filtering against this item when
+// we're filtering the list.
+func (rli ReferenceListItem) FilterValue() string {
+	return rli.Title()
+}
+
+// Title returns the title for the list item.
+func (rli ReferenceListItem) Title() string {
+	if rli.value.ShortName != "" {
+		return rli.value.ShortName + " – " + i18n.Term(rli.value.Name)
+	}
+	return i18n.Term(rli.value.Name)
+}
+
+// Description returns the description for the list item.
+func (rli ReferenceListItem) Description() string {
+	desc := i18n.T("%d °C, %s", rli.value.BoilingPoint, translatedTerms(rli.value.Effects))
+	if len(rli.value.Flavors) > 0 {
+		desc += ", " + i18n.T("Flavors: %s", translatedTerms(rli.value.Flavors))
+	}
+	if rli.value.Notes != "" {
+		desc += " (" + i18n.Term(rli.value.Notes) + ")"
+	}
+	return desc
+}
+
+// ReferenceListModel is a tea.Model for the catalog list.
+type ReferenceListModel struct {
+	list list.Model
+}
+
+// initialReferenceListModel creates a new model for the catalog list, without
+// any items.
+func initialReferenceListModel() *ReferenceListModel {
+	styles := NewStyles(lipgloss.DefaultRenderer())
+	l := list.New([]list.Item{}, styles.listDelegate(), 60, 30)
+	l.Styles = styles.listStyles()
+	l.Title = i18n.T("Cannabinoids & Terpenes")
+	l.SetStatusBarItemName(i18n.T("entry"), i18n.T("entries"))
+	l.SetShowHelp(false)
+	l.SetFilteringEnabled(false)
+	l.KeyMap.Quit.SetEnabled(false)
+	l.KeyMap.ShowFullHelp.SetEnabled(false)
+	l.KeyMap.CloseFullHelp.SetEnabled(false)
+	return &ReferenceListModel{list: l}
+}
+
+// catalogAlerts returns a warning for every given strain referring to
+// cannabinoids or terpenes missing from the catalog, e.g. after they were
+// removed from the user catalog.
+func catalogAlerts(strains []*can.Strain) []alert {
+	var alerts []alert
+	for _, s := range strains {
+		if ids := s.MissingCatalogIDs(); len(ids) > 0 {
+			alerts = append(alerts, alert{warningAlert, i18n.T("%s refers to entries missing from the catalog: %s", s.Strain, strings.Join(ids, ", "))})
+		}
+	}
+	return alerts
+}
+
+// referenceListTitle returns the title of the catalog list, naming the given
+// filter and order.
+func referenceListTitle(f service.ReferenceFilter, byBoilingPoint bool) string {
+	title := i18n.T("Cannabinoids & Terpenes")
+	if f.Effect != "" {
+		title += " · " + i18n.T("effect: %s", i18n.Term(f.Effect))
+	}
+	if f.Flavor != "" {
+		title += " · " + i18n.T("flavor: %s", i18n.Term(f.Flavor))
+	}
+	if byBoilingPoint {
+		title += " · " + i18n.T("sorted by boiling point")
+	}
+	return title
+}
+
+// ReferenceListModel implementation of tea.Model interface --------------------
+
+// Init is the first function that will be called. It returns an optional
+// initial command. To not perform an initial command return nil.
+func (rlm *ReferenceListModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update is called when a message is received. Use it to inspect messages
+// and, in response, update the model and/or send a command.
+func (rlm *ReferenceListModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case referenceListedMsg:
+		rlm.list.Title = referenceListTitle(msg.filter, msg.byBoilingPoint)
+		return rlm, rlm.list.SetItems(msg.items)
+	}
+
+	var cmd tea.Cmd
+	rlm.list, cmd = rlm.list.Update(msg)
+	return rlm, cmd
+}
+
+// View renders the ReferenceListModel UI, which is just a string. The view is
+// rendered after every Update.
+func (rlm *ReferenceListModel) View() string {
+	return rlm.list.View()
+}
+
+// ReferencePreviewModel is a tea.Model rendering the details of the selected
+// catalog entry and the strains of the user containing it.
+type ReferencePreviewModel struct {
+	styles  *Styles
+	entry   *service.ReferenceEntry
+	strains []*can.Strain
+}
+
+// initialReferencePreviewModel creates a new preview without a selected entry.
+func initialReferencePreviewModel() *ReferencePreviewModel {
+	return &ReferencePreviewModel{styles: NewStyles(lipgloss.DefaultRenderer())}
+}
+
+// ReferencePreviewModel implementation of tea.Model interface -----------------
+
+// Init is the first function that will be called. It returns an optional
+// initial command. To not perform an initial command return nil.
+func (rpm *ReferencePreviewModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update is called when a message is received. Use it to inspect messages
+// and, in response, update the model and/or send a command.
+func (rpm *ReferencePreviewModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	return rpm, nil
+}
+
+// View renders the ReferencePreviewModel UI, which is just a string. The view
+// is rendered after every Update.
+func (rpm *ReferencePreviewModel) View() string {
+	if rpm.entry == nil {
+		return ""
+	}
+	s := rpm.styles
+	var b strings.Builder
+	b.WriteString(s.StatusHeader.Render(ReferenceListItem{value: *rpm.entry}.Title()) + "\n")
+	b.WriteString(i18n.T("Boiling point: %d °C", rpm.entry.BoilingPoint) + "\n")
+	if len(rpm.entry.Effects) > 0 {
+		b.WriteString(i18n.T("Effects: %s", translatedTerms(rpm.entry.Effects)) + "\n")
+	}
+	if len(rpm.entry.Flavors) > 0 {
+		b.WriteString(i18n.T("Flavors: %s", translatedTerms(rpm.entry.Flavors)) + "\n")
+	}
+	containing := service.StrainsContaining(rpm.strains, *rpm.entry)
+	if len(containing) == 0 {
+		b.WriteString(s.Help.Render(i18n.T("None of your strains contains it.")))
+		return s.Status.Render(b.String())
+	}
+	var names []string
+	for _, strain := range containing {
+		names = append(names, strain.Strain)
+	}
+	b.WriteString(i18n.T("Your strains: %s", strings.Join(names, ", ")))
+	return s.Status.Render(b.String())
+}
@@ -0,0 +1,80 @@
+package tui
+
+import (
+	"maps"
+	"testing"
+
+	can "github.com/TheDonDope/wits-tui/pkg/cannabis"
+	"github.com/TheDonDope/wits-tui/pkg/service"
+	"github.com/TheDonDope/wits-tui/pkg/storage"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestReferenceListItem(t *testing.T) {
+	entries := service.ReferenceEntries(service.ReferenceFilter{}, false)
+
+	thca := ReferenceListItem{value: entries[0]}
+	assert.Equal(t, "THCA – Tetrahydrocannabinolic acid", thca.Title())
+	assert.Contains(t, thca.Description(), "120 °C, anti-inflammatory")
+	assert.Contains(t, thca.Description(), "(Acid Conversion. Requires 30 mins. in the oven)")
+
+	myrcene := ReferenceListItem{value: entries[len(can.Cannabinoids)+int(can.BetaMyrcene)]}
+	assert.Equal(t, "β-Myrcene", myrcene.Title())
+	assert.Contains(t, myrcene.Description(), "Flavors: musk, earth, herbal")
+}
+
+func TestReferenceHomeModel(t *testing.T) {
+	t.Setenv("STORAGE_MODE", storage.StoreInMemory)
+	rhm := initialReferenceHomeModel()
+	require.NoError(t, rhm.strains.AddStrain(&can.Strain{Strain: "Pink Kush",
+		Terpenes: []*can.StrainTerpene{{Type: can.Terpinolene}}}))
+
+	// Filtering by effect and sorting by boiling point lists terpinolene first
+	rhm.Update(referenceFilteredMsg{service.ReferenceFilter{Effect: "sedative"}})
+	rhm.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+	rhm.Update(rhm.onReferenceListed()())
+
+	rlm := rhm.hm.listView.(*ReferenceListModel)
+	assert.Len(t, rlm.list.Items(), 5)
+	assert.Contains(t, rlm.list.Title, "effect: sedative")
+	assert.Contains(t, rlm.list.Title, "sorted by boiling point")
+	rpm := rhm.hm.preview.(*ReferencePreviewModel)
+	require.NotNil(t, rpm.entry)
+	assert.Equal(t, "terpinolene", rpm.entry.ID)
+	assert.Contains(t, rpm.View(), "Your strains: Pink Kush")
+
+	// Back clears the filter before leaving the appliance
+	_, cmd := rhm.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.False(t, rhm.filter.Active())
+	rhm.Update(cmd())
+	assert.Len(t, rlm.list.Items(), len(can.Cannabinoids)+len(can.Terpenes))
+	m, _ := rhm.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.IsType(t, MenuModel{}, m)
+}
+
+func TestCatalogAlerts(t *testing.T) {
+	var missing can.Strain
+	require.NoError(t, yaml.Unmarshal([]byte("strain: Pink Kush\ncannabinoids:\n  cbdv: 0.2\nterpenes:\n  - id: geraniol\n"), &missing))
+
+	alerts := catalogAlerts([]*can.Strain{{Strain: "Wedding Cake"}, &missing})
+
+	require.Len(t, alerts, 1)
+	assert.Equal(t, "Pink Kush refers to entries missing from the catalog: cbdv, geraniol", alerts[0].text)
+}
+
+func TestUserCatalogEntries(t *testing.T) {
+	terpenes := maps.Clone(can.Terpenes)
+	t.Cleanup(func() { can.Terpenes = terpenes })
+	require.NoError(t, can.MergeCatalog(&can.Catalog{Terpenes: []*can.Terpene{{ID: "geraniol", Name: "Geraniol", BoilingPoint: 230}}}))
+
+	var names []string
+	for _, o := range sortedTerpenesList() {
+		names = append(names, o.Key)
+	}
+	assert.Contains(t, names, "Geraniol")
+	entries := service.ReferenceEntries(service.ReferenceFilter{}, false)
+	assert.Equal(t, "Geraniol", ReferenceListItem{value: entries[len(entries)-1]}.Title())
+}
@@ -0,0 +1,276 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	can "github.com/TheDonDope/wits-tui/pkg/cannabis"
+	"github.com/TheDonDope/wits-tui/pkg/i18n"
+	"github.com/TheDonDope/wits-tui/pkg/notify"
+	"github.com/TheDonDope/wits-tui/pkg/service"
+	"github.com/TheDonDope/wits-tui/pkg/storage"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/huh"
+	"github.com/google/uuid"
+)
+
+// reminderInterval is the interval in which the reminders are evaluated while
+// the tui runs.
+const reminderInterval = time.Minute
+
+type remindersDueMsg struct {
+	due []service.DueReminder
+}
+
+type reminderSubmittedMsg struct {
+	reminder *can.Reminder
+}
+
+// WatchReminders evaluates the reminders right away and then every minute
+// until the given context is done. Due reminders are notified through the
+// configured notifiers and sent as a message with the given function, e.g.
+// the Send function of the running program.
+func WatchReminders(ctx context.Context, send func(tea.Msg)) {
+	log.Println("💬 🔔  (pkg/tui/reminders.go) WatchReminders()")
+	n := notify.New(userSettings.Reminders, os.Stderr, false)
+	check := func() {
+		if due := checkReminders(n, time.Now()); len(due) > 0 {
+			send(remindersDueMsg{due})
+		}
+	}
+	check()
+	ticker := time.NewTicker(reminderInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			check()
+		}
+	}
+}
+
+// checkReminders notifies the reminders due at the given time through the
+// given notifier and marks them as notified. It returns the due reminders.
+// Without a configured storage no reminders are due.
+func checkReminders(n notify.Notifier, at time.Time) []service.DueReminder {
+	reminders := storage.NewReminderStore()
+	strains := storage.NewStrainStore()
+	sessions := storage.NewSessionStore()
+	prescriptions := storage.NewPrescriptionStore()
+	breaks := storage.NewBreakStore()
+	if reminders == nil || strains == nil || sessions == nil || prescriptions == nil || breaks == nil {
+		return nil
+	}
+	svc := service.NewReminderService(reminders)
+	c := service.GatherReminderConditions(strains.GetStrains(), sessions.GetSessions(),
+		service.NewPrescriptionService(prescriptions), service.NewBreakService(breaks), at, stockThresholds())
+	due := svc.Due(c, at)
+	for _, d := range due {
+		if err := n.Notify(service.ReminderNotification(d)); err != nil {
+			log.Printf("🚨 🔔  (pkg/tui/reminders.go) 🗒️  Failed to notify reminder with error: %v \n", err)
+		}
+	}
+	if err := svc.MarkNotified(due, at); err != nil {
+		log.Printf("🚨 🔔  (pkg/tui/reminders.go) 🗒️  Failed to mark reminders as notified with error: %v \n", err)
+	}
+	return due
+}
+
+// reminderAlerts returns the alerts for the given due reminders.
+func reminderAlerts(due []service.DueReminder) []alert {
+	var alerts []alert
+	for _, d := range due {
+		n := service.ReminderNotification(d)
+		alerts = append(alerts, alert{reminderAlert, fmt.Sprintf("%s %s: %s", d.At.Format(can.TimeOfDayLayout), n.Title, n.Body)})
+	}
+	return alerts
+}
+
+// onReminderAdded runs the form to add a reminder, offering the given strains,
+// and on submission sends a message with the parsed reminder.
+func onReminderAdded(strains []*can.Strain) tea.Cmd {
+	form := initialReminderForm(strains)
+
+	if err := form.Run(); err != nil {
+		fmt.Fprint(os.Stderr, i18n.T("Error running reminder form: %v\n", err))
+		return nil
+	}
+
+	reminder := parseReminder(form)
+	return func() tea.Msg { return reminderSubmittedMsg{reminder} }
+}
+
+// initialReminderForm returns a form for adding a reminder of any of the given
+// strains.
+func initialReminderForm(strains []*can.Strain) *huh.Form {
+	kinds := make([]huh.Option[can.ReminderKind], len(can.ReminderKinds))
+	for i, k := range can.ReminderKinds {
+		kinds[i] = huh.NewOption(service.ReminderKindText(k), k)
+	}
+	weekdays := make([]huh.Option[time.Weekday], 7)
+	for i := range weekdays {
+		// Weekdays are offered starting on monday
+		d := time.Weekday((i + 1) % 7)
+		weekdays[i] = huh.NewOption(i18n.T(d.String()), d)
+	}
+	products := []huh.Option[string]{huh.NewOption(i18n.T("any strain"), "")}
+	for _, s := range strains {
+		products = append(products, huh.NewOption(s.Strain, s.Strain))
+	}
+	return huh.NewForm(
+		huh.NewGroup(
+			huh.NewSelect[can.ReminderKind]().
+				Key("kind").
+				Options(kinds...).
+				Title(i18n.T("Kind")).
+				Description(i18n.T("What to be reminded of")),
+
+			huh.NewInput().
+				Key("times").
+				Title(i18n.T("Times")).
+				Description(i18n.T("Comma separated times of day (HH:MM)")).
+				Value(ptr("08:00")).
+				Validate(validateTimes),
+
+			huh.NewMultiSelect[time.Weekday]().
+				Key("weekdays").
+				Options(weekdays...).
+				Title(i18n.T("Weekdays")).
+				Description(i18n.T("Select none for every day")),
+		),
+		huh.NewGroup(
+			huh.NewSelect[string]().
+				Key("strain").
+				Options(products...).
+				Title(i18n.T("Strain")).
+				Description(i18n.T("The strain to dose or reorder")),
+
+			huh.NewInput().
+				Key("grams").
+				Title(i18n.T("Amount (g)")).
+				Description(i18n.T("The amount of a dose")).
+				Validate(validateFloat),
+
+			huh.NewInput().
+				Key("lead").
+				Title(i18n.T("Lead time (days)")).
+				Description(i18n.T("The days ahead to refill or reorder")).
+				Value(ptr("0")).
+				Validate(validateWholeNumber),
+
+			huh.NewInput().
+				Key("notes").
+				Title(i18n.T("Notes")).
+				Description(i18n.T("Shown with the reminder")),
+		),
+	).WithTheme(formTheme())
+}
+
+// validateTimes returns an error if the given input is no comma separated
+// list of times of day.
+func validateTimes(input string) error {
+	times := parseTimes(input)
+	if len(times) == 0 {
+		return errors.New(i18n.T("Please enter at least one time as HH:MM"))
+	}
+	for _, t := range times {
+		if _, _, err := can.ParseTimeOfDay(t); err != nil {
+			return errors.New(i18n.T("Please enter the times as HH:MM"))
+		}
+	}
+	return nil
+}
+
+// parseTimes splits the given comma separated times of day, dropping empty
+// ones.
+func parseTimes(input string) []string {
+	var times []string
+	for _, t := range strings.Split(input, ",") {
+		if t = strings.TrimSpace(t); t != "" {
+			times = append(times, t)
+		}
+	}
+	return times
+}
+
+// parseReminder creates a new reminder entity from the given form data.
+func parseReminder(form *huh.Form) *can.Reminder {
+	r := &can.Reminder{
+		ID:        uuid.New(),
+		Kind:      can.DoseReminder,
+		Times:     parseTimes(form.GetString("times")),
+		Strain:    form.GetString("strain"),
+		Grams:     parseFloatWithDefault(form.GetString("grams"), 0),
+		Notes:     strings.TrimSpace(form.GetString("notes")),
+		CreatedAt: time.Now(),
+	}
+	if kind, ok := form.Get("kind").(can.ReminderKind); ok {
+		r.Kind = kind
+	}
+	if weekdays, ok := form.Get("weekdays").([]time.Weekday); ok {
+		r.Weekdays = weekdays
+	}
+	r.LeadDays, _ = strconv.Atoi(strings.TrimSpace(form.GetString("lead")))
+	return r
+}
+
+// RemindersModel is a tea.Model rendering the scheduled reminders.
+type RemindersModel struct {
+	styles    *Styles
+	reminders []*can.Reminder
+}
+
+// RemindersModel implementation of tea.Model interface ------------------------
+
+// Init is the first function that will be called. It returns an optional
+// initial command. To not perform an initial command return nil.
+func (rm *RemindersModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update is called when a message is received. Use it to inspect messages
+// and, in response, update the model and/or send a command.
+func (rm *RemindersModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	return rm, nil
+}
+
+// View renders the RemindersModel UI, which is just a string. The view is
+// rendered after every Update.
+func (rm *RemindersModel) View() string {
+	s := rm.styles
+	var b strings.Builder
+	b.WriteString(s.StatusHeader.Render(i18n.T("Reminders")) + "\n")
+	if len(rm.reminders) == 0 {
+		b.WriteString(s.Help.Render(i18n.T("No reminders yet, press %s to add one.", keys.AddReminder.Help().Key)))
+		return s.Status.Render(b.String())
+	}
+	for _, r := range rm.reminders {
+		b.WriteString("\n" + s.Highlight.Render(service.ReminderKindText(r.Kind)) + "  " + reminderSchedule(r))
+		if r.Strain != "" {
+			b.WriteString(", " + r.Strain)
+		}
+	}
+	return s.Status.Render(b.String())
+}
+
+// reminderSchedule returns the translated schedule of the given reminder, e.g.
+// `08:00, 20:00 (Monday, Thursday)`.
+func reminderSchedule(r *can.Reminder) string {
+	schedule := strings.Join(r.Times, ", ")
+	if len(r.Weekdays) == 0 {
+		return schedule + " " + i18n.T("(every day)")
+	}
+	days := make([]string, len(r.Weekdays))
+	for i, d := range r.Weekdays {
+		days[i] = i18n.T(d.String())
+	}
+	return schedule + " (" + strings.Join(days, ", ") + ")"
+}
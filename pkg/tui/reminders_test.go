@@ -0,0 +1,100 @@
+package tui
+
+import (
+	"testing"
+	"time"
+
+	can "github.com/TheDonDope/wits-tui/pkg/cannabis"
+	"github.com/TheDonDope/wits-tui/pkg/notify"
+	"github.com/TheDonDope/wits-tui/pkg/service"
+	"github.com/TheDonDope/wits-tui/pkg/storage"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingNotifier records the notifications instead of delivering them.
+type recordingNotifier struct {
+	notifications []notify.Notification
+}
+
+func (rn *recordingNotifier) Notify(n notify.Notification) error {
+	rn.notifications = append(rn.notifications, n)
+	return nil
+}
+
+func TestReminderAlerts(t *testing.T) {
+	due := []service.DueReminder{{
+		Reminder: &can.Reminder{Kind: can.DoseReminder},
+		At:       time.Date(2024, time.March, 12, 8, 0, 0, 0, time.UTC),
+	}}
+
+	alerts := reminderAlerts(due)
+
+	require.Len(t, alerts, 1)
+	assert.Equal(t, reminderAlert, alerts[0].level)
+	assert.Equal(t, "08:00 Dose: Take your dose.", alerts[0].text)
+}
+
+func TestValidateTimes(t *testing.T) {
+	assert.NoError(t, validateTimes("08:00"))
+	assert.NoError(t, validateTimes("08:00, 20:30,"))
+	assert.Error(t, validateTimes(""))
+	assert.Error(t, validateTimes("08:00, 8pm"))
+	assert.Equal(t, []string{"08:00", "20:30"}, parseTimes(" 08:00 ,, 20:30 "))
+}
+
+func TestRemindersModel_View(t *testing.T) {
+	styles := NewStyles(lipgloss.DefaultRenderer())
+
+	t.Run("Empty", func(t *testing.T) {
+		rm := &RemindersModel{styles: styles}
+		assert.Contains(t, rm.View(), "No reminders yet, press alt+m to add one.")
+	})
+
+	t.Run("Scheduled", func(t *testing.T) {
+		rm := &RemindersModel{styles: styles, reminders: []*can.Reminder{
+			{Kind: can.DoseReminder, Times: []string{"08:00", "20:00"}, Strain: "Gorilla Glue"},
+			{Kind: can.ReorderReminder, Times: []string{"09:00"}, Weekdays: []time.Weekday{time.Monday, time.Thursday}},
+		}}
+
+		view := rm.View()
+
+		assert.Contains(t, view, "08:00, 20:00 (every day), Gorilla Glue")
+		assert.Contains(t, view, "09:00 (Monday, Thursday)")
+	})
+}
+
+func TestCheckReminders(t *testing.T) {
+	t.Setenv("STORAGE_MODE", storage.StoreYMLFile)
+	t.Setenv("WITS_DIR", t.TempDir())
+	at := time.Now()
+	store := storage.NewReminderStore()
+	require.NoError(t, store.AddReminder(&can.Reminder{
+		ID:        uuid.New(),
+		Kind:      can.DoseReminder,
+		Times:     []string{at.Add(-time.Minute).Format(can.TimeOfDayLayout)},
+		CreatedAt: at.AddDate(0, 0, -1),
+	}))
+	n := &recordingNotifier{}
+
+	due := checkReminders(n, at)
+
+	require.Len(t, due, 1)
+	require.Len(t, n.notifications, 1)
+	assert.Equal(t, "Dose", n.notifications[0].Title)
+	assert.Empty(t, checkReminders(n, at), "notified reminders are not due again")
+}
+
+func TestHomeModel_RemindersDue(t *testing.T) {
+	hm := initialHomeModel()
+	due := []service.DueReminder{{
+		Reminder: &can.Reminder{Kind: can.DoseReminder},
+		At:       time.Date(2024, time.March, 12, 8, 0, 0, 0, time.UTC),
+	}}
+
+	hm.Update(remindersDueMsg{due})
+
+	assert.Contains(t, hm.View(), "08:00 Dose: Take your dose.")
+}
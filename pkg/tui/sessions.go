@@ -0,0 +1,192 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	can "github.com/TheDonDope/wits-tui/pkg/cannabis"
+	"github.com/TheDonDope/wits-tui/pkg/i18n"
+	"github.com/TheDonDope/wits-tui/pkg/service"
+	"github.com/TheDonDope/wits-tui/pkg/storage"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/huh"
+	"github.com/google/uuid"
+)
+
+type sessionSubmittedMsg struct {
+	session *can.Session
+}
+
+// onSessionLogged runs the form to log a consumption session of the given
+// strain, warning about the given tolerance break, and on submission sends a
+// message with the parsed session data from the form.
+func onSessionLogged(strain *can.Strain, tbreak *can.ToleranceBreak) tea.Cmd {
+	form := initialSessionForm(strain, tbreak)
+
+	if err := form.Run(); err != nil {
+		fmt.Fprint(os.Stderr, i18n.T("Error running session form: %v\n", err))
+		return nil
+	}
+
+	session := parseSession(form, strain.Strain)
+	if session == nil {
+		return nil
+	}
+	return func() tea.Msg { return sessionSubmittedMsg{session} }
+}
+
+// initialSessionForm returns a form for logging a consumption session of the
+// given strain. The form shows the dose of the entered amount and method and
+// warns if the session is within the given tolerance break or its tapering,
+// followed by the journal of the session.
+func initialSessionForm(strain *can.Strain, tbreak *can.ToleranceBreak) *huh.Form {
+	grams := ""
+	method := can.Vaporizing
+	started := time.Now().Format(dateLayout)
+	calc := dosageCalculator()
+	fields := []huh.Field{
+		huh.NewNote().
+			Title(i18n.T("Session with %s", strain.Strain)),
+
+		huh.NewInput().
+			Key("grams").
+			Title(i18n.T("Amount (g)")).
+			Description(i18n.T("The consumed weight")).
+			Value(&grams).
+			Validate(validateFloat),
+
+		huh.NewSelect[can.ConsumptionMethod]().
+			Key("method").
+			Options(sortedMethodsList()...).
+			Title(i18n.T("Method")).
+			Description(i18n.T("The consumption method")).
+			Value(&method),
+
+		huh.NewNote().
+			Title(i18n.T("Dose")).
+			DescriptionFunc(func() string {
+				g := parseFloatWithDefault(grams, 0)
+				return doseText(calc.Dose(g, strain.Potency(), method))
+			}, []any{&grams, &method}),
+
+		huh.NewInput().
+			Key("started").
+			Title(i18n.T("Date")).
+			Description(i18n.T("The date of the session (YYYY-MM-DD)")).
+			Value(&started).
+			Validate(validateDate),
+	}
+	if note := breakNote(tbreak, &started); note != nil {
+		fields = append(fields, note)
+	}
+	fields = append(fields, huh.NewText().
+		Key("notes").
+		Title(i18n.T("Notes")).
+		Description(i18n.T("Anything worth remembering")))
+	groups := []*huh.Group{huh.NewGroup(fields...)}
+	return huh.NewForm(append(groups, journalGroups()...)...).WithTheme(formTheme())
+}
+
+// sortedMethodsList returns a list of consumption method options for the user
+// to choose from.
+func sortedMethodsList() []huh.Option[can.ConsumptionMethod] {
+	var methods []huh.Option[can.ConsumptionMethod]
+	for k, v := range can.ConsumptionMethods {
+		methods = append(methods, huh.NewOption(i18n.Term(v), k))
+	}
+	sort.Slice(methods, func(i, j int) bool {
+		return methods[i].Value < methods[j].Value
+	})
+	return methods
+}
+
+// doseText returns the translated description of the given dose.
+func doseText(d can.Dose) string {
+	return i18n.T("%s mg THC / %s mg CBD, of which %s mg THC / %s mg CBD are absorbed",
+		i18n.FormatFloat(d.THC, 1),
+		i18n.FormatFloat(d.CBD, 1),
+		i18n.FormatFloat(d.AbsorbedTHC, 1),
+		i18n.FormatFloat(d.AbsorbedCBD, 1))
+}
+
+// parseSession creates a new session entity of the given product from the
+// given form data, including its journal. Sessions without a consumed amount
+// are discarded.
+func parseSession(form *huh.Form, product string) *can.Session {
+	grams := parseFloatWithDefault(form.GetString("grams"), 0)
+	if grams <= 0 {
+		return nil
+	}
+	started := parseDate(form.GetString("started"))
+	if started.IsZero() {
+		started = time.Now()
+	}
+	var method can.ConsumptionMethod
+	if val, ok := form.Get("method").(can.ConsumptionMethod); ok {
+		method = val
+	}
+	session := &can.Session{
+		ID:        uuid.New(),
+		Strain:    product,
+		Grams:     grams,
+		Method:    method,
+		StartedAt: started,
+		Notes:     form.GetString("notes"),
+		CreatedAt: time.Now(),
+	}
+	parseJournal(form, session)
+	return session
+}
+
+// stockThresholds returns the stock thresholds of the user settings.
+func stockThresholds() service.StockThresholds {
+	return service.StockThresholds{
+		LowGrams:    userSettings.Stock.LowGrams,
+		LowDays:     userSettings.Stock.LowDays,
+		UsageWindow: userSettings.Stock.UsageWindowDays,
+	}
+}
+
+// stockStatus returns the translated status line of the given overall stock
+// forecast.
+func stockStatus(overall service.StockForecast) string {
+	if !overall.Predicted() {
+		return i18n.T("Stock: %s g, no consumption logged recently", i18n.FormatFloat(overall.Amount, 1))
+	}
+	return i18n.T("Stock: %s g, %s g per day, lasts until %s",
+		i18n.FormatFloat(overall.Amount, 1),
+		i18n.FormatFloat(overall.DailyUsage, 2),
+		overall.RunOut.Format(dateLayout))
+}
+
+// stockAlerts returns the warnings for the given forecasts running low.
+func stockAlerts(overall service.StockForecast, perStrain []service.StockForecast) []alert {
+	var alerts []alert
+	if overall.Low {
+		alerts = append(alerts, alert{warningAlert, i18n.T("Your stock is running low: %s g left.", i18n.FormatFloat(overall.Amount, 1))})
+	}
+	for _, f := range perStrain {
+		if f.Low {
+			alerts = append(alerts, alert{warningAlert, i18n.T("%s runs out in %s days.", f.Product, i18n.FormatFloat(f.DaysLeft, 0))})
+		}
+	}
+	return alerts
+}
+
+// menuAlerts returns the stock and prescription warnings shown on the main
+// menu. Without a configured storage there are no warnings.
+func menuAlerts() []alert {
+	strains := storage.NewStrainStore()
+	sessions := storage.NewSessionStore()
+	prescriptions := storage.NewPrescriptionStore()
+	if strains == nil || sessions == nil || prescriptions == nil {
+		return nil
+	}
+	now := time.Now()
+	overall, perStrain := service.ForecastStock(strains.GetStrains(), sessions.GetSessions(), now, stockThresholds())
+	alerts := stockAlerts(overall, perStrain)
+	warnings := service.NewPrescriptionService(prescriptions).Warnings(strains.GetStrains(), now)
+	return append(alerts, prescriptionAlerts(warnings)...)
+}
@@ -0,0 +1,57 @@
+package tui
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	can "github.com/TheDonDope/wits-tui/pkg/cannabis"
+	"github.com/TheDonDope/wits-tui/pkg/service"
+	"github.com/TheDonDope/wits-tui/pkg/storage"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStockStatus(t *testing.T) {
+	assert.Equal(t, "Stock: 12.5 g, no consumption logged recently",
+		stockStatus(service.StockForecast{Amount: 12.5, DaysLeft: math.Inf(1)}))
+
+	runOut := time.Date(2024, time.May, 1, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, "Stock: 12.5 g, 0.25 g per day, lasts until 2024-05-01",
+		stockStatus(service.StockForecast{Amount: 12.5, DailyUsage: 0.25, DaysLeft: 50, RunOut: runOut}))
+}
+
+func TestStockAlerts(t *testing.T) {
+	alerts := stockAlerts(
+		service.StockForecast{Amount: 2, Low: true},
+		[]service.StockForecast{
+			{Product: "Pink Kush", DaysLeft: 3, Low: true},
+			{Product: "Wedding Cake", DaysLeft: 30},
+		})
+
+	require.Len(t, alerts, 2)
+	assert.Equal(t, "Your stock is running low: 2.0 g left.", alerts[0].text)
+	assert.Equal(t, "Pink Kush runs out in 3 days.", alerts[1].text)
+}
+
+func TestStrainsHomeModel_SessionLogged(t *testing.T) {
+	t.Setenv("STORAGE_MODE", storage.StoreInMemory)
+	shm := initialStrainsHomeModel()
+	require.NoError(t, shm.service.AddStrain(&can.Strain{ID: uuid.New(), Strain: "Pink Kush", Amount: 2}))
+
+	_, cmd := shm.Update(sessionSubmittedMsg{&can.Session{
+		ID: uuid.New(), Strain: "Pink Kush", Grams: 0.5, StartedAt: time.Now()}})
+	require.NotNil(t, cmd)
+	msg := cmd()
+	require.IsType(t, strainsListedMsg{}, msg)
+	shm.Update(msg)
+
+	strain, err := shm.service.FindStrainByProduct("Pink Kush")
+	require.NoError(t, err)
+	assert.InDelta(t, 1.5, strain.Amount, 1e-9)
+	assert.Len(t, shm.sessions.GetSessions(), 1)
+	assert.Contains(t, shm.alerts.status, "Stock: 1.5 g")
+	// 1.5 g are below the default threshold of 5 g
+	assert.Contains(t, shm.View(), "Your stock is running low")
+}
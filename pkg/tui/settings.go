@@ -1,7 +1,19 @@
 package tui
 
 import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+
+	can "github.com/TheDonDope/wits-tui/pkg/cannabis"
+	"github.com/TheDonDope/wits-tui/pkg/i18n"
+	"github.com/TheDonDope/wits-tui/pkg/service"
+	"github.com/TheDonDope/wits-tui/pkg/storage"
+	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/huh"
+	"github.com/charmbracelet/lipgloss"
 )
 
 const settingsTitle = "🔧 Settings"
@@ -21,6 +33,18 @@ var settingsActions = map[settingsAction]string{
 	localization:     markedText("🌍 &Localization"),
 	backupAndRestore: markedText("💾 &Backup & Restore")}
 
+type themeSelectedMsg struct {
+	name string
+}
+
+type localeSelectedMsg struct {
+	locale i18n.Locale
+}
+
+type currencySelectedMsg struct {
+	currency string
+}
+
 // SettingsHomeModel is the tea.Model for the Settings appliance.
 type SettingsHomeModel struct {
 	hm *HomeModel
@@ -32,7 +56,8 @@ func initialSettingsModel() *SettingsHomeModel {
 	s := &SettingsHomeModel{
 		hm: initialHomeModel(),
 	}
-	s.hm.Title(breadcrumbTitle(s.hm.title, settingsTitle))
+	s.hm.Title(breadcrumbTitle(s.hm.title, i18n.T(settingsTitle)))
+	s.hm.Keys(keys.settingsHelp())
 	return s
 }
 
@@ -49,12 +74,34 @@ func (shm *SettingsHomeModel) Init() tea.Cmd {
 func (shm *SettingsHomeModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "q", "ctrl+c":
+		switch {
+		case key.Matches(msg, keys.Quit):
 			return shm, tea.Quit
-		case "esc":
+		case key.Matches(msg, keys.Back):
 			return InitialMenuModel(), nil
+		case key.Matches(msg, keys.Appearance):
+			return shm, onThemeSelected()
+		case key.Matches(msg, keys.Localization):
+			return shm, onLocaleSelected()
+		case key.Matches(msg, keys.Currency):
+			return shm, onCurrencySelected()
+		case key.Matches(msg, keys.AddReminder):
+			if strains := storage.NewStrainStore(); strains != nil {
+				return shm, onReminderAdded(strains.GetStrains())
+			}
+			return shm, nil
 		}
+	case themeSelectedMsg:
+		shm.applyTheme(msg.name)
+		return shm, nil
+	case localeSelectedMsg:
+		return shm.applyLocale(msg.locale), nil
+	case currencySelectedMsg:
+		applyCurrency(msg.currency)
+		return shm, nil
+	case reminderSubmittedMsg:
+		shm.addReminder(msg.reminder)
+		return shm, nil
 	}
 
 	var cmd tea.Cmd
@@ -68,3 +115,142 @@ func (shm *SettingsHomeModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 func (shm *SettingsHomeModel) View() string {
 	return shm.hm.View()
 }
+
+// addReminder adds the given reminder and shows all reminders. The store is
+// opened for every reminder, as the reminders are also updated in the
+// background when they are notified.
+func (shm *SettingsHomeModel) addReminder(r *can.Reminder) {
+	store := storage.NewReminderStore()
+	if store == nil {
+		return
+	}
+	svc := service.NewReminderService(store)
+	if err := svc.AddReminder(r); err != nil {
+		log.Printf("🚨 💾  (pkg/tui/settings.go) 🗒️  Failed to add reminder with error: %v \n", err)
+	}
+	shm.hm.Preview(&RemindersModel{styles: shm.hm.styles, reminders: svc.GetReminders()})
+}
+
+// applyTheme activates and persists the theme with the given name and
+// rerenders the settings with it.
+func (shm *SettingsHomeModel) applyTheme(name string) {
+	t, err := resolveTheme(name)
+	if err != nil {
+		log.Printf("🚨 💾  (pkg/tui/settings.go) 🗒️  Failed to apply theme with error: %v \n", err)
+		return
+	}
+	theme = t
+	userSettings.Appearance.Theme = name
+	if err := userSettings.Save(); err != nil {
+		log.Printf("🚨 💾  (pkg/tui/settings.go) 🗒️  Failed to save settings with error: %v \n", err)
+	}
+	shm.hm.styles = NewStyles(lipgloss.DefaultRenderer())
+	shm.hm.help = shm.hm.styles.helpModel()
+}
+
+// onThemeSelected runs the form to select a theme and on submission sends a
+// message with the name of the selected theme.
+func onThemeSelected() tea.Cmd {
+	themes, err := loadThemes()
+	if err != nil {
+		log.Printf("🚨 💾  (pkg/tui/settings.go) 🗒️  Failed to load themes with error: %v \n", err)
+		return nil
+	}
+	var options []huh.Option[string]
+	for _, name := range themeNames(themes) {
+		options = append(options, huh.NewOption(name, name))
+	}
+	name := theme.Name
+	form := huh.NewForm(
+		huh.NewGroup(
+			huh.NewSelect[string]().
+				Options(options...).
+				Title(i18n.T("Theme")).
+				Description(i18n.T("The color theme")).
+				Value(&name),
+		),
+	).WithTheme(formTheme())
+
+	if err := form.Run(); err != nil {
+		fmt.Fprint(os.Stderr, i18n.T("Error running theme selection form: %v\n", err))
+		return nil
+	}
+	return func() tea.Msg { return themeSelectedMsg{name} }
+}
+
+// applyLocale activates and persists the given locale and returns the settings
+// rerendered in it.
+func (shm *SettingsHomeModel) applyLocale(l i18n.Locale) *SettingsHomeModel {
+	if err := i18n.SetLocale(l); err != nil {
+		log.Printf("🚨 💾  (pkg/tui/settings.go) 🗒️  Failed to apply locale with error: %v \n", err)
+		return shm
+	}
+	userSettings.Locale = string(l)
+	if err := userSettings.Save(); err != nil {
+		log.Printf("🚨 💾  (pkg/tui/settings.go) 🗒️  Failed to save settings with error: %v \n", err)
+	}
+	// Rebuild the keys and the model, as both contain translated texts
+	if km, err := NewKeyMap(userSettings.Keybindings); err == nil {
+		keys = km
+	}
+	return initialSettingsModel()
+}
+
+// onLocaleSelected runs the form to select a locale and on submission sends a
+// message with the selected locale.
+func onLocaleSelected() tea.Cmd {
+	var options []huh.Option[i18n.Locale]
+	for _, l := range i18n.Locales() {
+		options = append(options, huh.NewOption(i18n.Name(l), l))
+	}
+	locale := i18n.Current()
+	form := huh.NewForm(
+		huh.NewGroup(
+			huh.NewSelect[i18n.Locale]().
+				Options(options...).
+				Title(i18n.T("Language")).
+				Description(i18n.T("The language of the user interface")).
+				Value(&locale),
+		),
+	).WithTheme(formTheme())
+
+	if err := form.Run(); err != nil {
+		fmt.Fprint(os.Stderr, i18n.T("Error running language selection form: %v\n", err))
+		return nil
+	}
+	return func() tea.Msg { return localeSelectedMsg{locale} }
+}
+
+// applyCurrency activates and persists the given currency.
+func applyCurrency(c string) {
+	userSettings.Currency = c
+	if err := userSettings.Save(); err != nil {
+		log.Printf("🚨 💾  (pkg/tui/settings.go) 🗒️  Failed to save settings with error: %v \n", err)
+	}
+}
+
+// onCurrencySelected runs the form to enter the currency and on submission
+// sends a message with the entered currency code.
+func onCurrencySelected() tea.Cmd {
+	c := currency()
+	form := huh.NewForm(
+		huh.NewGroup(
+			huh.NewInput().
+				Title(i18n.T("Currency")).
+				Description(i18n.T("The ISO 4217 code of the currency prices are paid in (e.g. EUR)")).
+				Validate(func(s string) error {
+					if s == "" || validateCurrency(s) != nil {
+						return errors.New(i18n.T("Please enter a three letter currency code"))
+					}
+					return nil
+				}).
+				Value(&c),
+		),
+	).WithTheme(formTheme())
+
+	if err := form.Run(); err != nil {
+		fmt.Fprint(os.Stderr, i18n.T("Error running currency form: %v\n", err))
+		return nil
+	}
+	return func() tea.Msg { return currencySelectedMsg{c} }
+}
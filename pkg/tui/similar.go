@@ -0,0 +1,97 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+
+	"github.com/TheDonDope/wits-tui/pkg/i18n"
+	"github.com/TheDonDope/wits-tui/pkg/service"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/huh"
+)
+
+// similarShown is the number of similar strains shown in the details.
+const similarShown = 5
+
+type strainsRecommendedMsg struct {
+	effects []string
+	flavors []string
+}
+
+// similarStrainLines returns a line per similar strain, naming its similarity
+// in percent and the reasons for it.
+func similarStrainLines(similar []service.StrainSimilarity) []string {
+	var lines []string
+	for i, sim := range similar {
+		if i == similarShown {
+			break
+		}
+		lines = append(lines, service.SimilarityLine(sim))
+	}
+	return lines
+}
+
+// recommendationText returns the status describing the best of the given
+// matches, or that there is none.
+func recommendationText(matches []service.StrainMatch) string {
+	if len(matches) == 0 {
+		return i18n.T("None of your strains provides the desired effects or flavors.")
+	}
+	best := matches[0]
+	return i18n.T("Best match: %s (%s%%), provides %s",
+		best.Strain.Strain,
+		i18n.FormatFloat(best.Score*100, 0),
+		translatedTerms(slices.Concat(best.Effects, best.Flavors)))
+}
+
+// onStrainsRecommended runs the form to choose the desired effects and flavors
+// and on submission sends a message with the chosen ones.
+func onStrainsRecommended() tea.Cmd {
+	var effects, flavors []string
+	form := initialRecommendationForm(&effects, &flavors)
+
+	if err := form.Run(); err != nil {
+		fmt.Fprint(os.Stderr, i18n.T("Error running recommendation form: %v\n", err))
+		return nil
+	}
+	if len(effects)+len(flavors) == 0 {
+		return nil
+	}
+	return func() tea.Msg { return strainsRecommendedMsg{effects: effects, flavors: flavors} }
+}
+
+// initialRecommendationForm returns a form to choose the desired effects and
+// flavors among those of the reference catalog.
+func initialRecommendationForm(effects, flavors *[]string) *huh.Form {
+	return huh.NewForm(
+		huh.NewGroup(
+			huh.NewMultiSelect[string]().
+				Key("effects").
+				Options(termOptions(service.ReferenceEffects())...).
+				Title(i18n.T("Effects")).
+				Description(i18n.T("The desired effects")).
+				Value(effects),
+			huh.NewMultiSelect[string]().
+				Key("flavors").
+				Options(termOptions(service.ReferenceFlavors())...).
+				Title(i18n.T("Flavors")).
+				Description(i18n.T("The desired flavors")).
+				Value(flavors),
+		),
+	).WithTheme(formTheme())
+}
+
+// termOptions returns the given catalog terms as options, sorted by their
+// translation.
+func termOptions(terms []string) []huh.Option[string] {
+	var options []huh.Option[string]
+	for _, t := range terms {
+		options = append(options, huh.NewOption(i18n.Term(t), t))
+	}
+	sort.SliceStable(options, func(i, j int) bool {
+		return options[i].Key < options[j].Key
+	})
+	return options
+}
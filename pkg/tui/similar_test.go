@@ -0,0 +1,48 @@
+package tui
+
+import (
+	"testing"
+
+	can "github.com/TheDonDope/wits-tui/pkg/cannabis"
+	"github.com/TheDonDope/wits-tui/pkg/service"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSimilarStrainLines(t *testing.T) {
+	similar := make([]service.StrainSimilarity, similarShown+1)
+	for i := range similar {
+		similar[i] = service.StrainSimilarity{Strain: &can.Strain{Strain: "Twin"}, Score: 0.9, RatioKnown: true, RatioDelta: 0.25}
+	}
+
+	lines := similarStrainLines(similar)
+
+	assert.Len(t, lines, similarShown)
+	assert.Equal(t, "Twin (90%): THC share differs by 25%", lines[0])
+}
+
+func TestRecommendationText(t *testing.T) {
+	assert.Equal(t, "None of your strains provides the desired effects or flavors.", recommendationText(nil))
+
+	matches := []service.StrainMatch{{
+		Strain:  &can.Strain{Strain: "Lavender"},
+		Score:   0.5,
+		Effects: []string{"sedative"},
+		Flavors: []string{"citrus"},
+	}}
+	assert.Equal(t, "Best match: Lavender (50%), provides sedative, citrus", recommendationText(matches))
+}
+
+func TestStrainPreviewModel_Similar(t *testing.T) {
+	spm := initialStrainPreviewModel()
+	spm.strain = &can.Strain{ID: uuid.New(), Strain: "Pink Kush"}
+	spm.showSimilar = true
+
+	assert.Contains(t, spm.View(), "No other strains to compare.")
+
+	spm.similar = []service.StrainSimilarity{{Strain: &can.Strain{Strain: "Purple Kush"}, Score: 0.2}}
+	view := spm.View()
+	assert.Contains(t, view, "Similar strains")
+	assert.Contains(t, view, "Purple Kush (20%)")
+	assert.NotContains(t, view, "No batches")
+}
@@ -1,6 +1,15 @@
 package tui
 
 import (
+	"log"
+	"strings"
+	"time"
+
+	can "github.com/TheDonDope/wits-tui/pkg/cannabis"
+	"github.com/TheDonDope/wits-tui/pkg/i18n"
+	"github.com/TheDonDope/wits-tui/pkg/service"
+	"github.com/TheDonDope/wits-tui/pkg/storage"
+	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 )
 
@@ -19,18 +28,37 @@ var statisticsActions = map[statisticsAction]string{
 	trends:        markedText("📈 &Trends"),
 	dosageTracker: markedText("🔢 &Dosage Tracker")}
 
+// dosageTrackerDays is the number of past days shown by the dosage tracker.
+const dosageTrackerDays = 14
+
+type spendSummarizedMsg struct {
+	summary []*service.MonthlySpend
+}
+
+type dosageTrackedMsg struct {
+	days []*service.DailyDose
+}
+
 // StatisticsHomeModel is the tea.Model for the Statistics appliance.
 type StatisticsHomeModel struct {
-	hm *HomeModel
+	hm       *HomeModel
+	strains  service.StrainService
+	sessions service.SessionService
+	breaks   service.BreakService
 }
 
 // initialStatisticsHomeModel returns a new StatisticsHomeModel, with the following contents:
 //   - rendered title
 func initialStatisticsHomeModel() *StatisticsHomeModel {
+	strains := storage.NewStrainStore()
 	s := &StatisticsHomeModel{
-		hm: initialHomeModel(),
+		hm:       initialHomeModel(),
+		strains:  service.NewStrainService(strains),
+		sessions: service.NewSessionService(storage.NewSessionStore(), strains),
+		breaks:   service.NewBreakService(storage.NewBreakStore()),
 	}
-	s.hm.Title(breadcrumbTitle(s.hm.title, statisticsTitle))
+	s.hm.Title(breadcrumbTitle(s.hm.title, i18n.T(statisticsTitle)))
+	s.hm.Keys(keys.statisticsHelp())
 	return s
 }
 
@@ -47,12 +75,49 @@ func (shm *StatisticsHomeModel) Init() tea.Cmd {
 func (shm *StatisticsHomeModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "q", "ctrl+c":
+		switch {
+		case key.Matches(msg, keys.Quit):
 			return shm, tea.Quit
-		case "esc":
+		case key.Matches(msg, keys.Back):
 			return InitialMenuModel(), nil
+		case key.Matches(msg, keys.Spending):
+			return shm, shm.onSpendSummarized()
+		case key.Matches(msg, keys.DosageTracker):
+			return shm, shm.onDosageTracked()
+		case key.Matches(msg, keys.SymptomRelief):
+			return shm, shm.onReliefCorrelated()
+		case key.Matches(msg, keys.Tolerance):
+			return shm, shm.onToleranceTracked()
+		case key.Matches(msg, keys.PlanBreak):
+			return shm, onBreakPlanned(shm.tolerance())
+		case key.Matches(msg, keys.CheckIn):
+			if tbreak := shm.breaks.CurrentBreak(time.Now()); tbreak != nil {
+				return shm, onCheckedIn(tbreak)
+			}
+			return shm, shm.onToleranceTracked()
+		}
+	case spendSummarizedMsg:
+		shm.hm.Preview(&SpendModel{styles: shm.hm.styles, summary: msg.summary, currency: currency()})
+		return shm, nil
+	case dosageTrackedMsg:
+		shm.hm.Preview(&DosageModel{styles: shm.hm.styles, days: msg.days})
+		return shm, nil
+	case reliefCorrelatedMsg:
+		shm.hm.Preview(&ReliefModel{styles: shm.hm.styles, report: msg.report})
+		return shm, nil
+	case toleranceTrackedMsg:
+		shm.hm.Preview(&ToleranceModel{styles: shm.hm.styles, tolerance: msg.tolerance, progress: msg.progress})
+		return shm, nil
+	case breakPlannedMsg:
+		if err := shm.breaks.PlanBreak(msg.tbreak); err != nil {
+			log.Printf("🚨 💾  (pkg/tui/statistics.go) 🗒️  Failed to plan tolerance break with error: %v \n", err)
+		}
+		return shm, shm.onToleranceTracked()
+	case checkInSubmittedMsg:
+		if _, err := shm.breaks.CheckIn(msg.id, msg.checkIn); err != nil {
+			log.Printf("🚨 💾  (pkg/tui/statistics.go) 🗒️  Failed to check in with error: %v \n", err)
 		}
+		return shm, shm.onToleranceTracked()
 	}
 
 	var cmd tea.Cmd
@@ -66,3 +131,141 @@ func (shm *StatisticsHomeModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 func (shm *StatisticsHomeModel) View() string {
 	return shm.hm.View()
 }
+
+// onSpendSummarized summarizes the spending on all strains and returns a
+// message containing the monthly summary.
+func (shm *StatisticsHomeModel) onSpendSummarized() tea.Cmd {
+	return func() tea.Msg {
+		return spendSummarizedMsg{service.SummarizeSpend(shm.strains.GetStrains(), time.Time{}, time.Time{})}
+	}
+}
+
+// onDosageTracked sums the doses of the sessions of the last days and returns
+// a message containing the daily doses.
+func (shm *StatisticsHomeModel) onDosageTracked() tea.Cmd {
+	return func() tea.Msg {
+		from := startOfDay(time.Now()).AddDate(0, 0, 1-dosageTrackerDays)
+		return dosageTrackedMsg{service.TrackDosage(shm.sessions.GetSessions(), dosageCalculator(), from, time.Time{})}
+	}
+}
+
+// onReliefCorrelated correlates the relief of the journaled symptoms with the
+// strains and returns a message containing the report.
+func (shm *StatisticsHomeModel) onReliefCorrelated() tea.Cmd {
+	return func() tea.Msg {
+		return reliefCorrelatedMsg{service.CorrelateRelief(shm.sessions.GetSessions(), shm.strains.GetStrains())}
+	}
+}
+
+// tolerance estimates the tolerance from all sessions.
+func (shm *StatisticsHomeModel) tolerance() service.Tolerance {
+	return service.EstimateTolerance(shm.sessions.GetSessions(), dosageCalculator(), time.Now())
+}
+
+// onToleranceTracked estimates the tolerance and tracks the progress of the
+// current tolerance break, returning a message containing both.
+func (shm *StatisticsHomeModel) onToleranceTracked() tea.Cmd {
+	return func() tea.Msg {
+		now := time.Now()
+		msg := toleranceTrackedMsg{tolerance: shm.tolerance()}
+		if tbreak := shm.breaks.CurrentBreak(now); tbreak != nil {
+			p := service.TrackBreak(tbreak, shm.sessions.GetSessions(), now)
+			msg.progress = &p
+		} else if breaks := shm.breaks.GetBreaks(); len(breaks) > 0 {
+			// Show the outcome of the latest break until the next one is planned
+			p := service.TrackBreak(breaks[0], shm.sessions.GetSessions(), now)
+			msg.progress = &p
+		}
+		return msg
+	}
+}
+
+// startOfDay returns the start of the day of the given time.
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// SpendModel is a tea.Model rendering the monthly spending, grouped by
+// manufacturer and pharmacy.
+type SpendModel struct {
+	styles   *Styles
+	summary  []*service.MonthlySpend
+	currency string
+}
+
+// SpendModel implementation of tea.Model interface ----------------------------
+
+// Init is the first function that will be called. It returns an optional
+// initial command. To not perform an initial command return nil.
+func (sm *SpendModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update is called when a message is received. Use it to inspect messages
+// and, in response, update the model and/or send a command.
+func (sm *SpendModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	return sm, nil
+}
+
+// View renders the SpendModel UI, which is just a string. The view is
+// rendered after every Update.
+func (sm *SpendModel) View() string {
+	s := sm.styles
+	var b strings.Builder
+	b.WriteString(s.StatusHeader.Render(i18n.T("Monthly Spending")) + "\n")
+	if len(sm.summary) == 0 {
+		b.WriteString(s.Help.Render(i18n.T("No priced purchases yet, add a batch with its price to a strain.")))
+		return s.Status.Render(b.String())
+	}
+	for _, ms := range sm.summary {
+		b.WriteString("\n" + s.Highlight.Render(ms.Month.Format("2006-01")) + "  " +
+			i18n.T("%s for %s g", i18n.FormatMoney(ms.Total, sm.currency), i18n.FormatFloat(ms.Grams, 1)) + "\n")
+		b.WriteString("  " + service.SpendGroupsLine(i18n.T("Manufacturers"), ms.ByManufacturer, sm.currency) + "\n")
+		b.WriteString("  " + service.SpendGroupsLine(i18n.T("Pharmacies"), ms.ByPharmacy, sm.currency) + "\n")
+	}
+	return s.Status.Render(strings.TrimSuffix(b.String(), "\n"))
+}
+
+// DosageModel is a tea.Model rendering the daily doses of THC and CBD.
+type DosageModel struct {
+	styles *Styles
+	days   []*service.DailyDose
+}
+
+// DosageModel implementation of tea.Model interface ---------------------------
+
+// Init is the first function that will be called. It returns an optional
+// initial command. To not perform an initial command return nil.
+func (dm *DosageModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update is called when a message is received. Use it to inspect messages
+// and, in response, update the model and/or send a command.
+func (dm *DosageModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	return dm, nil
+}
+
+// View renders the DosageModel UI, which is just a string. The view is
+// rendered after every Update.
+func (dm *DosageModel) View() string {
+	s := dm.styles
+	var b strings.Builder
+	b.WriteString(s.StatusHeader.Render(i18n.T("Dosage of the last %d days", dosageTrackerDays)) + "\n")
+	if len(dm.days) == 0 {
+		b.WriteString(s.Help.Render(i18n.T("No sessions yet, log a session of a strain.")))
+		return s.Status.Render(b.String())
+	}
+	var thc, absorbed float64
+	for _, d := range dm.days {
+		thc += d.THC
+		absorbed += d.AbsorbedTHC
+		b.WriteString("\n" + s.Highlight.Render(d.Day.Format(dateLayout)) + "  " +
+			i18n.T("%s g in %d sessions", i18n.FormatFloat(d.Grams, 2), d.Sessions) + "\n")
+		b.WriteString("  " + doseText(can.Dose{THC: d.THC, CBD: d.CBD, AbsorbedTHC: d.AbsorbedTHC, AbsorbedCBD: d.AbsorbedCBD}) + "\n")
+	}
+	b.WriteString("\n" + i18n.T("Average per day: %s mg THC, of which %s mg are absorbed",
+		i18n.FormatFloat(thc/float64(len(dm.days)), 1),
+		i18n.FormatFloat(absorbed/float64(len(dm.days)), 1)))
+	return s.Status.Render(b.String())
+}
@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"regexp"
 	"testing"
+	"time"
 
+	"github.com/TheDonDope/wits-tui/pkg/service"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -71,6 +73,48 @@ func TestStatisticsHomeModel(t *testing.T) {
 		})
 	})
 
+	t.Run("Spending", func(t *testing.T) {
+		model := initialStatisticsHomeModel()
+		summary := []*service.MonthlySpend{{
+			Month:          time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC),
+			Total:          150,
+			Grams:          15,
+			ByManufacturer: map[string]float64{"Aurora": 100, "Tilray": 50},
+			ByPharmacy:     map[string]float64{"": 150},
+		}}
+
+		_, cmd := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'s'}, Alt: true})
+		require.NotNil(t, cmd)
+		model.Update(spendSummarizedMsg{summary})
+		view := model.View()
+
+		assert.Contains(t, view, "2024-01")
+		assert.Contains(t, view, "Aurora 100.00 EUR, Tilray 50.00 EUR")
+		assert.Contains(t, view, "unknown 150.00 EUR")
+	})
+
+	t.Run("DosageTracker", func(t *testing.T) {
+		model := initialStatisticsHomeModel()
+		days := []*service.DailyDose{{
+			Day:         time.Date(2024, time.March, 10, 0, 0, 0, 0, time.UTC),
+			Sessions:    2,
+			Grams:       0.3,
+			THC:         60,
+			CBD:         2,
+			AbsorbedTHC: 21,
+			AbsorbedCBD: 0.7,
+		}}
+
+		_, cmd := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}, Alt: true})
+		require.NotNil(t, cmd)
+		model.Update(dosageTrackedMsg{days})
+		view := model.View()
+
+		assert.Contains(t, view, "2024-03-10")
+		assert.Contains(t, view, "0.30 g in 2 sessions")
+		assert.Contains(t, view, "60.0 mg THC / 2.0 mg CBD, of which 21.0 mg THC / 0.7 mg CBD are absorbed")
+	})
+
 	t.Run("View", func(t *testing.T) {
 		model := initialStatisticsHomeModel()
 		view := model.View()
@@ -1,19 +1,24 @@
 package tui
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"os"
+	"slices"
 	"sort"
-	"strconv"
+	"strings"
 	"time"
 
 	can "github.com/TheDonDope/wits-tui/pkg/cannabis"
+	"github.com/TheDonDope/wits-tui/pkg/i18n"
 	"github.com/TheDonDope/wits-tui/pkg/service"
 	"github.com/TheDonDope/wits-tui/pkg/storage"
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/huh"
+	"github.com/charmbracelet/lipgloss"
 	"github.com/google/uuid"
 )
 
@@ -34,36 +39,64 @@ var strainsActions = map[strainsAction]string{
 	editStrain:   markedText("✏️ &Edit Strain"),
 	deleteStrain: markedText("❌ &Delete Strain")}
 
-var (
-	sortedGenetics  = sortedGeneticsList()
-	sortedRadiation = sortedRadiationList()
-	sortedTerpenes  = sortedTerpenesList()
-)
-
 type strainsListedMsg struct {
-	items []list.Item
+	items   []list.Item
+	order   service.StrainSortOrder
+	status  string
+	alerts  []alert
+	recipes map[uuid.UUID][]*can.Recipe
 }
 
 type strainSubmittedMsg struct {
 	strain *can.Strain
 }
 
+type batchSubmittedMsg struct {
+	product string
+	batch   *can.Batch
+}
+
 // StrainsHomeModel is the tea.Model for the Strains appliance
 type StrainsHomeModel struct {
-	hm      *HomeModel
-	service service.StrainService
+	hm            *HomeModel
+	service       service.StrainService
+	prescriptions service.PrescriptionService
+	sessions      service.SessionService
+	recipes       service.RecipeService
+	breaks        service.BreakService
+	cultivars     service.CultivarService
+	directory     service.DirectoryService
+	order         service.StrainSortOrder
+	alerts        *AlertBarModel
 }
 
 // initialStrainsHomeModel returns a new StrainsHomeModel, with the following contents:
 //   - rendered title
 func initialStrainsHomeModel() *StrainsHomeModel {
 	log.Println("💬 💾  (pkg/tui/strains.go) initialStrainsHomeModel()")
+	// The sessions update the stock of the same strains the appliance lists
+	strains := storage.NewStrainStore()
 	s := &StrainsHomeModel{
-		hm:      initialHomeModel(),
-		service: service.NewStrainService(storage.NewStrainStore()),
+		hm:            initialHomeModel(),
+		service:       service.NewStrainService(strains),
+		prescriptions: service.NewPrescriptionService(storage.NewPrescriptionStore()),
+		sessions:      service.NewSessionService(storage.NewSessionStore(), strains),
+		recipes:       service.NewRecipeService(storage.NewRecipeStore()),
+		breaks:        service.NewBreakService(storage.NewBreakStore()),
+		cultivars:     service.NewCultivarService(storage.NewCultivarStore()),
+		directory:     service.NewDirectoryService(storage.NewManufacturerStore(), storage.NewPharmacyStore()),
+		order:         service.SortByName,
+		alerts:        initialAlertBarModel(),
 	}
-	s.hm.Title(breadcrumbTitle(s.hm.title, strainsTitle))
+	if order, err := service.ParseStrainSortOrder(userSettings.Strains.SortOrder); err == nil {
+		s.order = order
+	}
+	s.hm.Title(breadcrumbTitle(s.hm.title, i18n.T(strainsTitle)))
 	s.hm.List(initialStrainListModel())
+	s.hm.Table(initialStrainTableModel())
+	s.hm.Preview(initialStrainPreviewModel())
+	s.hm.Bar(s.alerts)
+	s.hm.Keys(keys.strainsHelp())
 	return s
 }
 
@@ -80,28 +113,254 @@ func (shm *StrainsHomeModel) Init() tea.Cmd {
 func (shm *StrainsHomeModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "q", "ctrl+c":
-			return shm, tea.Quit
-		case "esc":
+		if shm.filtering() {
+			break
+		}
+		switch {
+		case key.Matches(msg, keys.Back) && shm.filterApplied():
+			// Clear the applied filter instead of leaving the appliance
+			break
+		case key.Matches(msg, keys.Back):
 			return InitialMenuModel(), nil
-		case "alt+n", "ctrl+n":
-			return shm, onStrainAdded()
+		case key.Matches(msg, keys.AddStrain):
+			return shm, onStrainAdded(cultivarNames(shm.cultivars.GetCultivars()), manufacturerNames(shm.directory.GetManufacturers()))
+		case key.Matches(msg, keys.AddBatch):
+			if strain := shm.selected(); strain != nil {
+				options := prescriptionOptions(strain, shm.prescriptions.GetPrescriptions(), shm.service.GetStrains())
+				return shm, onBatchAdded(strain, options, pharmacyNames(shm.directory.GetPharmacies()))
+			}
+			return shm, nil
+		case key.Matches(msg, keys.LogSession):
+			if strain := shm.selected(); strain != nil {
+				return shm, onSessionLogged(strain, shm.breaks.CurrentBreak(time.Now()))
+			}
+			return shm, nil
+		case key.Matches(msg, keys.RateStrain):
+			if strain := shm.selected(); strain != nil {
+				return shm, onStrainRated(strain)
+			}
+			return shm, nil
+		case key.Matches(msg, keys.Favorite):
+			if strain := shm.selected(); strain != nil {
+				if _, err := shm.service.ToggleFavorite(strain.Strain); err != nil {
+					log.Printf("🚨 💾  (pkg/tui/strains.go) 🗒️  Failed to toggle favorite with error: %v \n", err)
+				}
+				return shm, shm.onStrainsListed()
+			}
+			return shm, nil
+		case key.Matches(msg, keys.Sort):
+			return shm, shm.onStrainsSorted()
+		case key.Matches(msg, keys.Similar):
+			if spm, ok := shm.hm.preview.(*StrainPreviewModel); ok {
+				spm.showSimilar = !spm.showSimilar
+				spm.showLineage = false
+			}
+		case key.Matches(msg, keys.Lineage):
+			if spm, ok := shm.hm.preview.(*StrainPreviewModel); ok {
+				spm.showLineage = !spm.showLineage
+				spm.showSimilar = false
+			}
+		case key.Matches(msg, keys.EditCultivar):
+			strain := shm.selected()
+			if strain == nil || strain.Cultivar == "" {
+				return shm, nil
+			}
+			cultivar, err := shm.cultivars.FindCultivar(strain.Cultivar)
+			if err != nil {
+				cultivar = &can.Cultivar{Name: strain.Cultivar}
+			}
+			return shm, onCultivarEdited(cultivar)
+		case key.Matches(msg, keys.Directory):
+			var manufacturer string
+			if strain := shm.selected(); strain != nil {
+				manufacturer = strain.Manufacturer
+			}
+			return shm, onDirectoryEdited(shm.directory.GetManufacturers(), shm.directory.GetPharmacies(), manufacturer)
+		case key.Matches(msg, keys.Recommend):
+			return shm, onStrainsRecommended()
 		}
 	case strainSubmittedMsg:
+		// Strains of the same cultivar share its spelling
+		if c, err := shm.cultivars.FindCultivar(msg.strain.Cultivar); err == nil {
+			msg.strain.Cultivar = c.Name
+		}
+		if _, err := shm.directory.Migrate([]*can.Strain{msg.strain}); err != nil {
+			log.Printf("🚨 💾  (pkg/tui/strains.go) 🗒️  Failed to add manufacturer with error: %v \n", err)
+		}
 		shm.service.AddStrain(msg.strain)
+		if _, err := shm.cultivars.SyncStrains([]*can.Strain{msg.strain}); err != nil {
+			log.Printf("🚨 💾  (pkg/tui/strains.go) 🗒️  Failed to add cultivar with error: %v \n", err)
+		}
 		// TODO: redirect to home view?
 		return shm, shm.onStrainsListed()
 	case strainsListedMsg:
-		return shm.hm.Update(msg)
+		shm.alerts.status = msg.status
+		shm.alerts.alerts = msg.alerts
+		if spm, ok := shm.hm.preview.(*StrainPreviewModel); ok {
+			spm.recipes = msg.recipes
+		}
+	case cultivarSubmittedMsg:
+		if err := shm.cultivars.SaveCultivar(msg.cultivar); err != nil {
+			log.Printf("🚨 💾  (pkg/tui/strains.go) 🗒️  Failed to save cultivar with error: %v \n", err)
+		}
+		return shm, shm.onStrainsListed()
+	case directorySubmittedMsg:
+		var err error
+		if msg.manufacturer != nil {
+			err = shm.directory.SaveManufacturer(msg.manufacturer)
+		} else {
+			err = shm.directory.SavePharmacy(msg.pharmacy)
+		}
+		if err != nil {
+			log.Printf("🚨 💾  (pkg/tui/strains.go) 🗒️  Failed to save directory entry with error: %v \n", err)
+		}
+		// Renamed and merged entries are renamed in the strains as well
+		shm.migrateDirectory(shm.service.GetStrains())
+		return shm, shm.onStrainsListed()
+	case sessionSubmittedMsg:
+		if _, err := shm.sessions.LogSession(msg.session); err != nil {
+			log.Printf("🚨 💾  (pkg/tui/strains.go) 🗒️  Failed to log session with error: %v \n", err)
+		}
+		return shm, shm.onStrainsListed()
+	case ratingSubmittedMsg:
+		if _, err := shm.service.AddRating(msg.product, msg.rating); err != nil {
+			log.Printf("🚨 💾  (pkg/tui/strains.go) 🗒️  Failed to add rating with error: %v \n", err)
+		}
+		return shm, shm.onStrainsListed()
+	case strainsRecommendedMsg:
+		matches := service.RecommendStrains(shm.service.GetStrains(), msg.effects, msg.flavors)
+		shm.alerts.status = recommendationText(matches)
+		if len(matches) > 0 {
+			shm.selectStrain(matches[0].Strain)
+		}
+	case batchSubmittedMsg:
+		if err := shm.prescriptions.CheckPurchase(msg.product, msg.batch, shm.service.GetStrains()); err != nil {
+			log.Printf("🚨 💾  (pkg/tui/strains.go) 🗒️  Rejected batch with error: %v \n", err)
+			shm.alerts.alerts = append(shm.alerts.alerts, alert{errorAlert, purchaseErrorText(err)})
+			return shm, nil
+		}
+		strain, err := shm.service.AddBatch(msg.product, msg.batch)
+		if err != nil {
+			log.Printf("🚨 💾  (pkg/tui/strains.go) 🗒️  Failed to add batch with error: %v \n", err)
+		} else {
+			shm.migrateDirectory([]*can.Strain{strain})
+		}
+		return shm, shm.onStrainsListed()
 	}
 
 	var cmd tea.Cmd
-	hm, cmd := shm.hm.Update(msg)
-	shm.hm = hm.(*HomeModel)
+	if shm.filtering() {
+		// Keys are typed into the filter and must not trigger any bindings
+		shm.hm.listView, cmd = shm.hm.listView.Update(msg)
+	} else {
+		var hm tea.Model
+		hm, cmd = shm.hm.Update(msg)
+		shm.hm = hm.(*HomeModel)
+	}
+	if spm, ok := shm.hm.preview.(*StrainPreviewModel); ok {
+		spm.strain = shm.selected()
+		spm.similar = nil
+		if spm.showSimilar && spm.strain != nil {
+			spm.similar = service.SimilarStrains(spm.strain, shm.service.GetStrains())
+		}
+		spm.cultivar, spm.lineage = nil, nil
+		if spm.strain != nil && spm.strain.Cultivar != "" {
+			spm.cultivar, _ = shm.cultivars.FindCultivar(spm.strain.Cultivar)
+			if spm.showLineage {
+				spm.lineage = shm.cultivars.Lineage(spm.strain.Cultivar, shm.service.GetStrains())
+			}
+		}
+	}
 	return shm, cmd
 }
 
+// selected returns the strain selected in the visible list or table, or nil if
+// there is none.
+func (shm *StrainsHomeModel) selected() *can.Strain {
+	var strain *can.Strain
+	if shm.hm.tableShown() {
+		if stm, ok := shm.hm.tableView.(*StrainTableModel); ok {
+			strain = stm.selected()
+		}
+	} else if slm, ok := shm.hm.listView.(*StrainListModel); ok {
+		if sli, ok := slm.list.SelectedItem().(StrainListItem); ok {
+			strain = sli.value
+		}
+	}
+	// The placeholder shown for an empty list is no actual strain
+	if strain == nil || strain.ID == uuid.Nil {
+		return nil
+	}
+	return strain
+}
+
+// selectStrain selects the given strain in the list, if it is listed.
+func (shm *StrainsHomeModel) selectStrain(strain *can.Strain) {
+	slm, ok := shm.hm.listView.(*StrainListModel)
+	if !ok {
+		return
+	}
+	for i, item := range slm.list.VisibleItems() {
+		if sli, ok := item.(StrainListItem); ok && sli.value.ID == strain.ID {
+			slm.list.Select(i)
+			return
+		}
+	}
+}
+
+// MigrateStrains migrates the stored strains to the cultivars and the
+// directory. It is run once on startup, before the appliances are opened:
+//   - strains stored before cultivars were introduced only name their
+//     cultivar, which is added to the cultivars
+//   - strains stored before the directory was introduced name their
+//     manufacturers and pharmacies in free text, which are mapped onto the
+//     directory
+func MigrateStrains() {
+	log.Println("💬 💾  (pkg/tui/strains.go) MigrateStrains()")
+	strainStore := storage.NewStrainStore()
+	if strainStore == nil {
+		return
+	}
+	shm := &StrainsHomeModel{
+		service:   service.NewStrainService(strainStore),
+		cultivars: service.NewCultivarService(storage.NewCultivarStore()),
+		directory: service.NewDirectoryService(storage.NewManufacturerStore(), storage.NewPharmacyStore()),
+	}
+	if _, err := shm.cultivars.SyncStrains(shm.service.GetStrains()); err != nil {
+		log.Printf("🚨 💾  (pkg/tui/strains.go) 🗒️  Failed to sync cultivars with error: %v \n", err)
+	}
+	shm.migrateDirectory(shm.service.GetStrains())
+}
+
+// migrateDirectory maps the manufacturers, countries and pharmacies of the
+// given strains onto the directory and stores the changed strains.
+func (shm *StrainsHomeModel) migrateDirectory(strains []*can.Strain) {
+	result, err := shm.directory.Migrate(strains)
+	if err != nil {
+		log.Printf("🚨 💾  (pkg/tui/strains.go) 🗒️  Failed to migrate directory with error: %v \n", err)
+	}
+	if result == nil {
+		return
+	}
+	for _, s := range result.Strains {
+		if err := shm.service.UpdateStrain(s); err != nil {
+			log.Printf("🚨 💾  (pkg/tui/strains.go) 🗒️  Failed to update strain with error: %v \n", err)
+		}
+	}
+}
+
+// filtering reports whether the strain list is currently being filtered.
+func (shm *StrainsHomeModel) filtering() bool {
+	slm, ok := shm.hm.listView.(*StrainListModel)
+	return ok && slm.list.FilterState() == list.Filtering
+}
+
+// filterApplied reports whether the strain list is currently filtered.
+func (shm *StrainsHomeModel) filterApplied() bool {
+	slm, ok := shm.hm.listView.(*StrainListModel)
+	return ok && slm.list.FilterState() == list.FilterApplied
+}
+
 // View renders the StrainsHomeModel UI, which is just a string. The view is
 // rendered after every Update.
 func (shm *StrainsHomeModel) View() string {
@@ -113,11 +372,11 @@ func (shm *StrainsHomeModel) View() string {
 func (shm *StrainsHomeModel) onStrainsListed() tea.Cmd {
 	return func() tea.Msg {
 		items := []list.Item{}
-		strains := shm.service.GetStrains()
+		strains := shm.service.ListStrains(shm.order)
 
 		if len(strains) == 0 {
 			items = append(items, StrainListItem{value: &can.Strain{
-				Strain:   "No strains available, press alt+n to create a new one.",
+				Strain:   i18n.T("No strains available, press %s to create a new one.", keys.AddStrain.Help().Key),
 				Cultivar: "",
 				THC:      0,
 				CBD:      0,
@@ -127,17 +386,49 @@ func (shm *StrainsHomeModel) onStrainsListed() tea.Cmd {
 				items = append(items, StrainListItem{value: strain})
 			}
 		}
-		return strainsListedMsg{items}
+		now := time.Now()
+		overall, perStrain := service.ForecastStock(strains, shm.sessions.GetSessions(), now, stockThresholds())
+		alerts := stockAlerts(overall, perStrain)
+		alerts = append(alerts, prescriptionAlerts(shm.prescriptions.Warnings(strains, now))...)
+		alerts = append(alerts, breakAlerts(shm.breaks.CurrentBreak(now), shm.sessions.GetSessions(), now)...)
+		alerts = append(alerts, catalogAlerts(strains)...)
+		return strainsListedMsg{items: items, order: shm.order, status: stockStatus(overall), alerts: alerts, recipes: shm.recipes.RecipesOfStrains()}
+	}
+}
+
+// purchaseErrorText returns the translated description of the given error
+// rejecting a purchase.
+func purchaseErrorText(err error) string {
+	switch {
+	case errors.Is(err, service.ErrQuotaExceeded):
+		return i18n.T("The batch exceeds the remaining quota of the prescription.")
+	case errors.Is(err, service.ErrPrescriptionNotValid):
+		return i18n.T("The prescription is not valid at the purchase date.")
+	case errors.Is(err, service.ErrStrainNotPrescribed):
+		return i18n.T("The strain is not allowed by the prescription.")
+	}
+	return err.Error()
+}
+
+// onStrainsSorted switches to the next sort order, remembers it in the settings
+// and lists the strains again.
+func (shm *StrainsHomeModel) onStrainsSorted() tea.Cmd {
+	shm.order = shm.order.Next()
+	userSettings.Strains.SortOrder = string(shm.order)
+	if err := userSettings.Save(); err != nil {
+		log.Printf("🚨 💾  (pkg/tui/strains.go) 🗒️  Failed to save sort order with error: %v \n", err)
 	}
+	return shm.onStrainsListed()
 }
 
-// onStrainAdded runs the form to add a strain and on submission sends a message
-// with the parsed strain data from the form.
-func onStrainAdded() tea.Cmd {
-	form := initialStrainForm()
+// onStrainAdded runs the form to add a strain, suggesting the given cultivar
+// and manufacturer names, and on submission sends a message with the parsed
+// strain data from the form.
+func onStrainAdded(cultivars, manufacturers []string) tea.Cmd {
+	form := initialStrainForm(cultivars, manufacturers)
 
 	if err := form.Run(); err != nil {
-		fmt.Fprintf(os.Stderr, "Error running strain creation form: %v\n", err)
+		fmt.Fprint(os.Stderr, i18n.T("Error running strain creation form: %v\n", err))
 		return nil // Return nil to prevent further processing
 	}
 
@@ -153,7 +444,7 @@ func onStrainAdded() tea.Cmd {
 func sortedGeneticsList() []huh.Option[can.GeneticType] {
 	var genetics []huh.Option[can.GeneticType]
 	for k, v := range can.Genetics {
-		genetics = append(genetics, huh.NewOption(v, k))
+		genetics = append(genetics, huh.NewOption(i18n.Term(v), k))
 	}
 	sort.Slice(genetics, func(i, j int) bool {
 		return genetics[i].Value < genetics[j].Value
@@ -161,88 +452,163 @@ func sortedGeneticsList() []huh.Option[can.GeneticType] {
 	return genetics
 }
 
-// sortedRadiationList returns a list of options for radiation treatment for the user to choose from.
-func sortedRadiationList() []huh.Option[bool] {
-	var radiations []huh.Option[bool]
-	radiations = append(radiations, huh.NewOption("No", false))
-	radiations = append(radiations, huh.NewOption("Yes", true))
-	sort.Slice(radiations, func(i, j int) bool {
-		// We consider `false`` to be "smaller" than `true``, so we put `false`` before `true`
-		return !radiations[i].Value && radiations[j].Value
+// sortedTreatmentList returns a list of treatment options for the user to
+// choose from, starting with the unknown treatment, which is preselected.
+func sortedTreatmentList() []huh.Option[can.TreatmentType] {
+	var treatments []huh.Option[can.TreatmentType]
+	for k, v := range can.Treatments {
+		treatments = append(treatments, huh.NewOption(i18n.Term(v), k))
+	}
+	sort.Slice(treatments, func(i, j int) bool {
+		return treatments[i].Value < treatments[j].Value
 	})
-	return radiations
+	return treatments
+}
+
+// treatmentLabel returns the translated name of the given treatment, naming
+// who declared it if known.
+func treatmentLabel(t can.Treatment) string {
+	label := i18n.Term(can.Treatments[t.Type])
+	if t.DeclaredBy != "" {
+		label += " (" + i18n.T("declared by %s", t.DeclaredBy) + ")"
+	}
+	return label
 }
 
 // sortedTerpenesList returns a list of terpene options for the user to choose from.
-func sortedTerpenesList() []huh.Option[*can.Terpene] {
-	var terpenes []huh.Option[*can.Terpene]
-	for _, t := range can.Terpenes {
-		terpenes = append(terpenes, huh.NewOption(t.Name, t))
+func sortedTerpenesList() []huh.Option[can.TerpeneType] {
+	var terpenes []huh.Option[can.TerpeneType]
+	for k, v := range can.Terpenes {
+		terpenes = append(terpenes, huh.NewOption(i18n.Term(v.Name), k))
 	}
 	sort.Slice(terpenes, func(i, j int) bool {
-		return terpenes[i].Value.Name < terpenes[j].Value.Name
+		return terpenes[i].Key < terpenes[j].Key
 	})
 	return terpenes
 }
 
-// initialStrainForm returns a form for creating a new strain.
-func initialStrainForm() *huh.Form {
-	return huh.NewForm(
+// initialStrainForm returns a form for creating a new strain, suggesting the
+// given cultivar and manufacturer names. After the details, the lab tested cannabinoid profile
+// and the content of every selected terpene can be entered.
+func initialStrainForm(cultivars, manufacturers []string) *huh.Form {
+	var selected []can.TerpeneType
+	groups := []*huh.Group{
 		huh.NewGroup(
 			huh.NewInput().
 				Key("strain").
-				Title("Strain").
-				Description("The product name"),
+				Title(i18n.T("Strain")).
+				Description(i18n.T("The product name")),
 
 			huh.NewInput().
 				Key("cultivar").
-				Title("Cultivar").
-				Description("The plant name"),
+				Title(i18n.T("Cultivar")).
+				Description(i18n.T("The plant name")).
+				Suggestions(cultivars),
 
 			huh.NewInput().
 				Key("manufacturer").
-				Title("Manufacturer").
-				Description("The producing company / importer"),
+				Title(i18n.T("Manufacturer")).
+				Description(i18n.T("The producing company / importer")).
+				Suggestions(manufacturers),
 
 			huh.NewInput().
 				Key("country").
-				Title("Country").
-				Description("The country of origin"),
+				Title(i18n.T("Country")).
+				Description(i18n.T("The country of origin")).
+				Suggestions(countrySuggestions()).
+				Validate(validateCountry),
 
 			huh.NewSelect[can.GeneticType]().
 				Key("genetic").
-				Options(sortedGenetics...).
-				Title("Genetic").
-				Description("The phenotype"),
+				Options(sortedGeneticsList()...).
+				Title(i18n.T("Genetic")).
+				Description(i18n.T("The phenotype")),
+
+			huh.NewSelect[can.TreatmentType]().
+				Key("treatment").
+				Options(sortedTreatmentList()...).
+				Title(i18n.T("Treatment")).
+				Description(i18n.T("The treatment against germs, e.g. an irradiation")),
 
-			huh.NewSelect[bool]().
-				Key("radiated").
-				Options(sortedRadiation...).
-				Title("Radiated").
-				Description("If the plant was radiation treated"),
+			huh.NewInput().
+				Key("declared_by").
+				Title(i18n.T("Declared by")).
+				Description(i18n.T("Who declared the treatment (e.g. the manufacturer), leave empty if unknown")),
 
 			huh.NewInput().
 				Key("thc").
-				Title("THC (%)").
-				Description("The THC content"),
+				Title(i18n.T("THC (%)")).
+				Description(i18n.T("The THC content")),
 
 			huh.NewInput().
 				Key("cbd").
-				Title("CBD (%)").
-				Description("The CBD content"),
+				Title(i18n.T("CBD (%)")).
+				Description(i18n.T("The CBD content")),
 
-			huh.NewMultiSelect[*can.Terpene]().
+			huh.NewMultiSelect[can.TerpeneType]().
 				Key("terpenes").
-				Options(sortedTerpenes...).
-				Title("Terpenes").
-				Description("The contained terpenes"),
+				Options(sortedTerpenesList()...).
+				Title(i18n.T("Terpenes")).
+				Description(i18n.T("The contained terpenes")).
+				Value(&selected),
 
 			huh.NewInput().
 				Key("amount").
-				Title("Amount (g)").
-				Description("The weight"),
+				Title(i18n.T("Amount (g)")).
+				Description(i18n.T("The weight")),
 		),
-	)
+		huh.NewGroup(cannabinoidFields()...).
+			Title(i18n.T("Cannabinoid profile")).
+			Description(i18n.T("The content as listed on the lab certificate, leave empty if not tested")),
+	}
+	for _, o := range sortedTerpenesList() {
+		t := o.Value
+		groups = append(groups, huh.NewGroup(
+			huh.NewInput().
+				Key(terpeneKey(t)).
+				Title(i18n.T("%s (%%)", i18n.Term(can.Terpenes[t].Name))).
+				Description(i18n.T("The terpene content, leave empty if unknown")).
+				Validate(validateFloat),
+		).WithHideFunc(func() bool { return !slices.Contains(selected, t) }))
+	}
+	return huh.NewForm(groups...).WithTheme(formTheme())
+}
+
+// profileCannabinoids returns the cannabinoids of a lab tested profile, in
+// the order of their types. Benzene is no cannabinoid of the flower and left
+// out.
+func profileCannabinoids() []can.CannabinoidType {
+	var types []can.CannabinoidType
+	for c := range can.Cannabinoids {
+		if c != can.Benzene {
+			types = append(types, c)
+		}
+	}
+	slices.Sort(types)
+	return types
+}
+
+// cannabinoidFields returns an input for the content of every cannabinoid of
+// the profile.
+func cannabinoidFields() []huh.Field {
+	var fields []huh.Field
+	for _, c := range profileCannabinoids() {
+		fields = append(fields, huh.NewInput().
+			Key(cannabinoidKey(c)).
+			Title(i18n.T("%s (%%)", can.Cannabinoids[c].ShortName)).
+			Validate(validateFloat))
+	}
+	return fields
+}
+
+// cannabinoidKey returns the key of the form field of the given cannabinoid.
+func cannabinoidKey(c can.CannabinoidType) string {
+	return fmt.Sprintf("cannabinoid_%d", c)
+}
+
+// terpeneKey returns the key of the form field of the given terpene.
+func terpeneKey(t can.TerpeneType) string {
+	return "terpene_" + t.ID()
 }
 
 // parseStrain creates a new strain entity from the given form data.
@@ -256,10 +622,26 @@ func parseStrain(form *huh.Form) *can.Strain {
 	if val, ok := form.Get("genetic").(can.GeneticType); ok {
 		genetic = val
 	}
+	treatment := can.Treatment{DeclaredBy: strings.TrimSpace(form.GetString("declared_by"))}
+	if val, ok := form.Get("treatment").(can.TreatmentType); ok {
+		treatment.Type = val
+	}
 
-	var terpenes []*can.Terpene
-	if val, ok := form.Get("terpenes").([]*can.Terpene); ok {
-		terpenes = val
+	var terpenes []*can.StrainTerpene
+	if val, ok := form.Get("terpenes").([]can.TerpeneType); ok {
+		for _, t := range val {
+			terpenes = append(terpenes, &can.StrainTerpene{
+				Type:    t,
+				Percent: parseFloatWithDefault(form.GetString(terpeneKey(t)), 0),
+			})
+		}
+	}
+
+	cannabinoids := map[can.CannabinoidType]float64{}
+	for _, c := range profileCannabinoids() {
+		if v := parseFloatWithDefault(form.GetString(cannabinoidKey(c)), 0); v > 0 {
+			cannabinoids[c] = v
+		}
 	}
 
 	return &can.Strain{
@@ -267,11 +649,12 @@ func parseStrain(form *huh.Form) *can.Strain {
 		Strain:       form.GetString("strain"),
 		Cultivar:     form.GetString("cultivar"),
 		Manufacturer: form.GetString("manufacturer"),
-		Country:      form.GetString("country"),
+		Country:      parseCountry(form.GetString("country")),
 		Genetic:      genetic,
-		Radiated:     form.GetBool("radiated"),
+		Treatment:    treatment,
 		THC:          thc,
 		CBD:          cbd,
+		Cannabinoids: cannabinoids,
 		Terpenes:     terpenes,
 		Amount:       amount,
 		CreatedAt:    time.Now(),
@@ -279,10 +662,11 @@ func parseStrain(form *huh.Form) *can.Strain {
 	}
 }
 
-// parseFloatWithDefault parses the given input to a float64. If an error occurs
-// the given defaultValue is returned.
+// parseFloatWithDefault parses the given input to a float64, accepting the
+// decimal separator of the current locale. If an error occurs the given
+// defaultValue is returned.
 func parseFloatWithDefault(input string, defaultValue float64) float64 {
-	if val, err := strconv.ParseFloat(input, 64); err == nil {
+	if val, err := i18n.ParseFloat(input); err == nil {
 		return val
 	}
 	return defaultValue
@@ -298,17 +682,32 @@ type StrainListItem struct {
 // FilterValue is the value we use when filtering against this item when
 // we're filtering the list.
 func (sli StrainListItem) FilterValue() string {
-	return sli.value.Cultivar
+	return service.StrainSearchText(sli.value)
 }
 
-// Title returns the title for the list item.
+// Title returns the title for the list item, marking favorites.
 func (sli StrainListItem) Title() string {
+	if sli.value.Favorite {
+		return "📌 " + sli.value.Strain
+	}
 	return sli.value.Strain
 }
 
-// Description returns the description for the list item.
+// Description returns the description for the list item, naming the dominant
+// terpene if its content is known and the average rating if it was rated.
 func (sli StrainListItem) Description() string {
-	return fmt.Sprintf("Amount: %.1f g, THC/CBD: %.1f%% / %.1f%%, Genetic: %s", sli.value.Amount, sli.value.THC, sli.value.CBD, can.Genetics[sli.value.Genetic])
+	desc := i18n.T("Amount: %s g, THC/CBD: %s%% / %s%%, Genetic: %s",
+		i18n.FormatFloat(sli.value.Amount, 1),
+		i18n.FormatFloat(sli.value.THC, 1),
+		i18n.FormatFloat(sli.value.CBD, 1),
+		i18n.Term(can.Genetics[sli.value.Genetic]))
+	if t := sli.value.DominantTerpene(); t != nil {
+		desc += ", " + i18n.T("Dominant terpene: %s", i18n.Term(t.Name()))
+	}
+	if avg := sli.value.AverageRatings(); avg.Overall > 0 {
+		desc += ", " + i18n.T("Rating: %s", ratingSummary(avg))
+	}
+	return desc
 }
 
 // StrainListModel is a tea.Model for the strains list.
@@ -320,8 +719,16 @@ type StrainListModel struct {
 // items.
 func initialStrainListModel() *StrainListModel {
 	log.Println("💬 💾  (pkg/tui/strains.go) initialStrainListModel()")
-	l := list.New([]list.Item{}, list.NewDefaultDelegate(), 60, 30)
-	l.Title = "Entries"
+	styles := NewStyles(lipgloss.DefaultRenderer())
+	l := list.New([]list.Item{}, styles.listDelegate(), 60, 30)
+	l.Styles = styles.listStyles()
+	l.Title = i18n.T("Entries")
+	l.SetStatusBarItemName(i18n.T("strain"), i18n.T("strains"))
+	l.SetShowHelp(false)
+	l.KeyMap.Quit.SetEnabled(false)
+	l.KeyMap.Filter = keys.Filter
+	l.KeyMap.ShowFullHelp.SetEnabled(false)
+	l.KeyMap.CloseFullHelp.SetEnabled(false)
 	log.Printf("✅ 💾  (pkg/tui/strains.go) initialStrainListModel() -> len(l.Items()): %v \n", len(l.Items()))
 	return &StrainListModel{list: l}
 }
@@ -338,15 +745,9 @@ func (slm *StrainListModel) Init() tea.Cmd {
 // and, in response, update the model and/or send a command.
 func (slm *StrainListModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
-	case tea.KeyMsg:
-		switch msg.String() {
-		case "esc":
-			return InitialMenuModel(), nil
-		case "alt+n", "ctrl+n":
-			return slm, onStrainAdded()
-		}
-
 	case strainsListedMsg:
+		slm.list.Title = i18n.T("Entries") + " · " + i18n.T("sorted by %s", sortOrderLabel(msg.order))
+		slm.list.Filter = strainFilter(msg.items)
 		return slm, slm.list.SetItems(msg.items)
 	}
 
@@ -360,3 +761,41 @@ func (slm *StrainListModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 func (slm *StrainListModel) View() string {
 	return slm.list.View()
 }
+
+// sortOrderLabel returns the translated label of the given sort order.
+func sortOrderLabel(o service.StrainSortOrder) string {
+	switch o {
+	case service.SortByTHC:
+		return "THC"
+	case service.SortByCBD:
+		return "CBD"
+	case service.SortByAmount:
+		return i18n.T("amount")
+	case service.SortByCreated:
+		return i18n.T("created")
+	case service.SortByUpdated:
+		return i18n.T("updated")
+	case service.SortByRating:
+		return i18n.T("rating")
+	}
+	return i18n.T("name")
+}
+
+// strainFilter returns a list.FilterFunc matching the given items against the
+// filter term parsed as a strain query (e.g. `thc>20 genetic:indica`). Terms
+// which are no valid query fall back to the default fuzzy filter.
+func strainFilter(items []list.Item) list.FilterFunc {
+	return func(term string, targets []string) []list.Rank {
+		q, err := service.ParseStrainQuery(term)
+		if err != nil || len(items) != len(targets) {
+			return list.DefaultFilter(term, targets)
+		}
+		ranks := []list.Rank{}
+		for i, item := range items {
+			if sli, ok := item.(StrainListItem); ok && q.Matches(sli.value) {
+				ranks = append(ranks, list.Rank{Index: i})
+			}
+		}
+		return ranks
+	}
+}
@@ -0,0 +1,247 @@
+package tui
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+
+	can "github.com/TheDonDope/wits-tui/pkg/cannabis"
+	"github.com/TheDonDope/wits-tui/pkg/i18n"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/google/uuid"
+)
+
+// strainColumn is a column of the strains table.
+type strainColumn struct {
+	title string
+	width int
+	value func(s *can.Strain) string
+	less  func(a, b *can.Strain) bool
+}
+
+// strainColumns contains all available columns of the strains table, keyed by
+// their identifier used in the settings.
+var strainColumns = map[string]strainColumn{
+	"product": {"Strain", 24,
+		func(s *can.Strain) string { return s.Strain },
+		func(a, b *can.Strain) bool { return strings.ToLower(a.Strain) < strings.ToLower(b.Strain) }},
+	"cultivar": {"Cultivar", 18,
+		func(s *can.Strain) string { return s.Cultivar },
+		func(a, b *can.Strain) bool { return strings.ToLower(a.Cultivar) < strings.ToLower(b.Cultivar) }},
+	"manufacturer": {"Manufacturer", 16,
+		func(s *can.Strain) string { return s.Manufacturer },
+		func(a, b *can.Strain) bool { return strings.ToLower(a.Manufacturer) < strings.ToLower(b.Manufacturer) }},
+	"country": {"Country", 12,
+		func(s *can.Strain) string { return s.Country },
+		func(a, b *can.Strain) bool { return strings.ToLower(a.Country) < strings.ToLower(b.Country) }},
+	"genetic": {"Genetic", 8,
+		func(s *can.Strain) string { return i18n.Term(can.Genetics[s.Genetic]) },
+		func(a, b *can.Strain) bool { return a.Genetic < b.Genetic }},
+	"radiated": {"Radiated", 9,
+		func(s *can.Strain) string { return yesNo(s.Treatment.Irradiated()) },
+		func(a, b *can.Strain) bool { return !a.Treatment.Irradiated() && b.Treatment.Irradiated() }},
+	"treatment": {"Treatment", 14,
+		func(s *can.Strain) string { return i18n.Term(can.Treatments[s.Treatment.Type]) },
+		func(a, b *can.Strain) bool { return a.Treatment.Type < b.Treatment.Type }},
+	"thc": {"THC (%)", 8,
+		func(s *can.Strain) string { return i18n.FormatFloat(s.THC, 1) },
+		func(a, b *can.Strain) bool { return a.THC < b.THC }},
+	"cbd": {"CBD (%)", 8,
+		func(s *can.Strain) string { return i18n.FormatFloat(s.CBD, 1) },
+		func(a, b *can.Strain) bool { return a.CBD < b.CBD }},
+	"amount": {"Amount (g)", 10,
+		func(s *can.Strain) string { return i18n.FormatFloat(s.Amount, 1) },
+		func(a, b *can.Strain) bool { return a.Amount < b.Amount }},
+	"terpenes": {"Terpenes", 8,
+		func(s *can.Strain) string { return strconv.Itoa(len(s.Terpenes)) },
+		func(a, b *can.Strain) bool { return len(a.Terpenes) < len(b.Terpenes) }},
+	"cost_per_gram": {"Price/g", 10,
+		func(s *can.Strain) string { return costValue(s.CostPerGram()) },
+		func(a, b *can.Strain) bool { return costLess(a.CostPerGram, b.CostPerGram) }},
+	"cost_per_mg_thc": {"Price/mg THC", 12,
+		func(s *can.Strain) string { return costValue(s.CostPerMgTHC()) },
+		func(a, b *can.Strain) bool { return costLess(a.CostPerMgTHC, b.CostPerMgTHC) }},
+	"rating": {"Rating", 7,
+		func(s *can.Strain) string { return ratingValue(s.AverageRatings()) },
+		func(a, b *can.Strain) bool { return a.AverageRatings().Overall < b.AverageRatings().Overall }},
+	"updated": {"Updated", 10,
+		func(s *can.Strain) string { return s.UpdatedAt.Format("2006-01-02") },
+		func(a, b *can.Strain) bool { return a.UpdatedAt.Before(b.UpdatedAt) }},
+}
+
+// defaultStrainColumns are the columns shown if none are configured.
+var defaultStrainColumns = []string{"product", "cultivar", "manufacturer", "genetic", "thc", "cbd", "amount", "updated"}
+
+// validateStrainColumns returns an error if any of the given column
+// identifiers is unknown.
+func validateStrainColumns(columns []string) error {
+	for _, c := range columns {
+		if _, ok := strainColumns[c]; !ok {
+			return fmt.Errorf("unknown strains table column %q", c)
+		}
+	}
+	return nil
+}
+
+// yesNo returns the translated yes or no for the given value.
+func yesNo(b bool) string {
+	if b {
+		return i18n.T("Yes")
+	}
+	return i18n.T("No")
+}
+
+// costValue returns the given cost in the configured currency, or a dash if
+// the cost is unknown.
+func costValue(cost float64, ok bool) string {
+	if !ok {
+		return "–"
+	}
+	return i18n.FormatMoney(cost, currency())
+}
+
+// ratingValue returns the average overall rating of the given averages, or a
+// dash if the strain was not rated.
+func ratingValue(avg can.RatingAverages) string {
+	if avg.Overall <= 0 {
+		return "–"
+	}
+	return i18n.FormatFloat(avg.Overall, 1)
+}
+
+// costLess compares two costs, sorting unknown costs first.
+func costLess(a, b func() (float64, bool)) bool {
+	ca, okA := a()
+	cb, okB := b()
+	if okA != okB {
+		return okB
+	}
+	return ca < cb
+}
+
+// StrainTableModel is a tea.Model rendering the strains as a table.
+type StrainTableModel struct {
+	table   table.Model
+	columns []string
+	strains []*can.Strain
+	sortCol int
+	desc    bool
+}
+
+// initialStrainTableModel creates a new model for the strains table, with the
+// columns configured in the settings and without any rows.
+func initialStrainTableModel() *StrainTableModel {
+	log.Println("💬 💾  (pkg/tui/strains_table.go) initialStrainTableModel()")
+	columns := userSettings.Strains.Columns
+	if len(columns) == 0 || validateStrainColumns(columns) != nil {
+		columns = defaultStrainColumns
+	}
+	styles := NewStyles(lipgloss.DefaultRenderer())
+	stm := &StrainTableModel{columns: columns}
+	stm.table = table.New(
+		table.WithFocused(true),
+		table.WithHeight(20),
+		table.WithStyles(styles.tableStyles()),
+	)
+	stm.refresh()
+	return stm
+}
+
+// StrainTableModel implementation of tea.Model interface ----------------------
+
+// Init is the first function that will be called. It returns an optional
+// initial command. To not perform an initial command return nil.
+func (stm *StrainTableModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update is called when a message is received. Use it to inspect messages
+// and, in response, update the model and/or send a command.
+func (stm *StrainTableModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, keys.SortColumnLeft):
+			stm.sortCol = (stm.sortCol - 1 + len(stm.columns)) % len(stm.columns)
+			stm.refresh()
+			return stm, nil
+		case key.Matches(msg, keys.SortColumnRight):
+			stm.sortCol = (stm.sortCol + 1) % len(stm.columns)
+			stm.refresh()
+			return stm, nil
+		case key.Matches(msg, keys.ReverseSort):
+			stm.desc = !stm.desc
+			stm.refresh()
+			return stm, nil
+		}
+	case strainsListedMsg:
+		stm.strains = nil
+		for _, item := range msg.items {
+			if sli, ok := item.(StrainListItem); ok && sli.value.ID != uuid.Nil {
+				stm.strains = append(stm.strains, sli.value)
+			}
+		}
+		stm.refresh()
+		return stm, nil
+	}
+
+	var cmd tea.Cmd
+	stm.table, cmd = stm.table.Update(msg)
+	return stm, cmd
+}
+
+// View renders the StrainTableModel UI, which is just a string. The view is
+// rendered after every Update.
+func (stm *StrainTableModel) View() string {
+	return stm.table.View()
+}
+
+// selected returns the strain at the cursor of the table, or nil if the table
+// is empty.
+func (stm *StrainTableModel) selected() *can.Strain {
+	c := stm.table.Cursor()
+	if c < 0 || c >= len(stm.strains) {
+		return nil
+	}
+	return stm.strains[c]
+}
+
+// refresh sorts the strains by the sort column and rebuilds the columns and
+// rows of the table.
+func (stm *StrainTableModel) refresh() {
+	sortBy := strainColumns[stm.columns[stm.sortCol]]
+	sort.SliceStable(stm.strains, func(i, j int) bool {
+		if stm.desc {
+			return sortBy.less(stm.strains[j], stm.strains[i])
+		}
+		return sortBy.less(stm.strains[i], stm.strains[j])
+	})
+
+	cols := make([]table.Column, len(stm.columns))
+	for i, id := range stm.columns {
+		c := strainColumns[id]
+		title := i18n.T(c.title)
+		if i == stm.sortCol {
+			title += map[bool]string{false: " ▲", true: " ▼"}[stm.desc]
+		}
+		cols[i] = table.Column{Title: title, Width: max(c.width, lipgloss.Width(title))}
+	}
+	rows := make([]table.Row, len(stm.strains))
+	for i, s := range stm.strains {
+		row := make(table.Row, len(stm.columns))
+		for j, id := range stm.columns {
+			row[j] = strainColumns[id].value(s)
+		}
+		rows[i] = row
+	}
+	// Rows have to be cleared first, as they must not have more cells than
+	// there are columns
+	stm.table.SetRows(nil)
+	stm.table.SetColumns(cols)
+	stm.table.SetRows(rows)
+}
@@ -0,0 +1,104 @@
+package tui
+
+import (
+	"testing"
+
+	can "github.com/TheDonDope/wits-tui/pkg/cannabis"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// listedStrainTableModel returns a strains table listing the test strains.
+func listedStrainTableModel(t *testing.T) *StrainTableModel {
+	t.Helper()
+	items := testStrainItems()
+	for _, item := range items {
+		item.(StrainListItem).value.ID = uuid.New()
+	}
+	stm := initialStrainTableModel()
+	stm.Update(strainsListedMsg{items: items})
+	require.Len(t, stm.table.Rows(), 3)
+	return stm
+}
+
+// firstColumn returns the values of the first column of the table.
+func firstColumn(stm *StrainTableModel) []string {
+	var values []string
+	for _, r := range stm.table.Rows() {
+		values = append(values, r[0])
+	}
+	return values
+}
+
+func TestStrainTableModel(t *testing.T) {
+	t.Run("DefaultColumns", func(t *testing.T) {
+		stm := initialStrainTableModel()
+		assert.Len(t, stm.table.Columns(), len(defaultStrainColumns))
+		assert.Contains(t, stm.table.Columns()[0].Title, "▲")
+	})
+
+	t.Run("ConfiguredColumns", func(t *testing.T) {
+		prev := userSettings.Strains.Columns
+		t.Cleanup(func() { userSettings.Strains.Columns = prev })
+		userSettings.Strains.Columns = []string{"manufacturer", "thc"}
+
+		stm := initialStrainTableModel()
+
+		require.Len(t, stm.table.Columns(), 2)
+		assert.Contains(t, stm.table.Columns()[1].Title, "THC")
+	})
+
+	t.Run("SkipsPlaceholder", func(t *testing.T) {
+		stm := initialStrainTableModel()
+		stm.Update(strainsListedMsg{items: testStrainItems()})
+		assert.Empty(t, stm.table.Rows())
+	})
+
+	t.Run("SortByColumn", func(t *testing.T) {
+		stm := listedStrainTableModel(t)
+		assert.Equal(t, []string{"Ghost Train Haze", "Pink Kush", "Wedding Cake"}, firstColumn(stm))
+
+		stm.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'r'}})
+		assert.Equal(t, []string{"Wedding Cake", "Pink Kush", "Ghost Train Haze"}, firstColumn(stm))
+		assert.Contains(t, stm.table.Columns()[0].Title, "▼")
+
+		// Sort by the THC column, which is the fifth one
+		for range 4 {
+			stm.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'>'}})
+		}
+		assert.Contains(t, stm.table.Columns()[4].Title, "▼")
+		assert.Equal(t, []string{"Wedding Cake", "Pink Kush", "Ghost Train Haze"}, firstColumn(stm))
+
+		stm.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'<'}})
+		assert.Contains(t, stm.table.Columns()[3].Title, "▼")
+	})
+}
+
+func TestValidateStrainColumns(t *testing.T) {
+	assert.NoError(t, validateStrainColumns([]string{"product", "terpenes", "radiated", "treatment"}))
+	assert.Error(t, validateStrainColumns([]string{"product", "color"}))
+}
+
+func TestCostColumns(t *testing.T) {
+	priced := &can.Strain{Batches: []*can.Batch{{Price: 100, Grams: 10, THC: 20}}}
+	unpriced := &can.Strain{}
+	column := strainColumns["cost_per_gram"]
+
+	assert.Equal(t, "10.00 EUR", column.value(priced))
+	assert.Equal(t, "–", column.value(unpriced))
+	assert.True(t, column.less(unpriced, priced))
+	assert.False(t, column.less(priced, unpriced))
+}
+
+func TestTreatmentColumns(t *testing.T) {
+	gamma := &can.Strain{Treatment: can.Treatment{Type: can.GammaIrradiated}}
+	plasma := &can.Strain{Treatment: can.Treatment{Type: can.ColdPlasma}}
+
+	assert.Equal(t, "Yes", strainColumns["radiated"].value(gamma))
+	assert.Equal(t, "No", strainColumns["radiated"].value(plasma), "cold plasma is no irradiation")
+	assert.Equal(t, "Gamma irradiation", strainColumns["treatment"].value(gamma))
+	assert.True(t, strainColumns["treatment"].less(gamma, plasma))
+	assert.True(t, strainColumns["treatment"].less(&can.Strain{}, gamma), "unknown treatments are sorted first")
+}
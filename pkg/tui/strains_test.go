@@ -0,0 +1,101 @@
+package tui
+
+import (
+	"testing"
+
+	can "github.com/TheDonDope/wits-tui/pkg/cannabis"
+	"github.com/TheDonDope/wits-tui/pkg/service"
+	"github.com/TheDonDope/wits-tui/pkg/storage"
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testStrainItems returns list items for a set of distinct strains.
+func testStrainItems() []list.Item {
+	return []list.Item{
+		StrainListItem{value: &can.Strain{Strain: "Pink Kush", Manufacturer: "Aurora", Genetic: can.Indica, THC: 22,
+			Terpenes: []*can.StrainTerpene{{Type: can.Limonene, Percent: 0.6}}}},
+		StrainListItem{value: &can.Strain{Strain: "Ghost Train Haze", Manufacturer: "Tilray", Genetic: can.Sativa, THC: 18}},
+		StrainListItem{value: &can.Strain{Strain: "Wedding Cake", Manufacturer: "Aurora", Genetic: can.Hybrid, THC: 25}},
+	}
+}
+
+func TestStrainListItem_FilterValue(t *testing.T) {
+	item := testStrainItems()[0].(StrainListItem)
+	value := item.FilterValue()
+
+	assert.Contains(t, value, "Pink Kush")
+	assert.Contains(t, value, "Aurora")
+	assert.Contains(t, value, "Limonene")
+}
+
+func TestStrainFilter(t *testing.T) {
+	items := testStrainItems()
+	targets := make([]string, len(items))
+	for i, item := range items {
+		targets[i] = item.FilterValue()
+	}
+	filter := strainFilter(items)
+
+	tests := []struct {
+		name     string
+		term     string
+		expected []int
+	}{
+		{"FreeText", "kush", []int{0}},
+		{"Manufacturer", "manu:aurora", []int{0, 2}},
+		{"Combined", "manu:aurora thc>23", []int{2}},
+		{"Terpene", "terp:limonene", []int{0}},
+		{"NoMatch", "genetic:sativa thc>20", []int{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			indexes := []int{}
+			for _, r := range filter(tt.term, targets) {
+				indexes = append(indexes, r.Index)
+			}
+			assert.Equal(t, tt.expected, indexes)
+		})
+	}
+}
+
+func TestStrainListModel_Listed(t *testing.T) {
+	slm := initialStrainListModel()
+
+	slm.Update(strainsListedMsg{items: testStrainItems(), order: service.SortByTHC})
+
+	assert.Len(t, slm.list.Items(), 3)
+	assert.Contains(t, slm.list.Title, "THC")
+}
+
+func TestStrainListItem_Description(t *testing.T) {
+	items := testStrainItems()
+
+	assert.Contains(t, items[0].(StrainListItem).Description(), "Dominant terpene: Limonene")
+	assert.NotContains(t, items[1].(StrainListItem).Description(), "Dominant terpene")
+}
+
+func TestTreatmentLabel(t *testing.T) {
+	assert.Equal(t, "Unknown", treatmentLabel(can.Treatment{}))
+	assert.Equal(t, "Untreated", treatmentLabel(can.Treatment{Type: can.Untreated}))
+	assert.Equal(t, "E-beam irradiation (declared by Manufacturer)", treatmentLabel(can.Treatment{Type: can.EBeamIrradiated, DeclaredBy: "Manufacturer"}))
+}
+
+func TestMigrateStrains(t *testing.T) {
+	t.Setenv("STORAGE_MODE", storage.StoreYMLFile)
+	t.Setenv("WITS_DIR", t.TempDir())
+	require.NoError(t, storage.NewStrainStore().AddStrain(&can.Strain{ID: uuid.New(), Strain: "Pink Kush", Cultivar: "Pink Kush", Manufacturer: "Aurora", Country: "Canada"}))
+
+	MigrateStrains()
+
+	_, err := storage.NewCultivarStore().FindCultivar("Pink Kush")
+	assert.NoError(t, err)
+	_, err = storage.NewManufacturerStore().FindManufacturer("Aurora")
+	assert.NoError(t, err)
+	strain, err := storage.NewStrainStore().FindStrainByProduct("Pink Kush")
+	require.NoError(t, err)
+	assert.Equal(t, "CA", strain.Country)
+}
@@ -0,0 +1,302 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	can "github.com/TheDonDope/wits-tui/pkg/cannabis"
+	"github.com/TheDonDope/wits-tui/pkg/i18n"
+	"github.com/TheDonDope/wits-tui/pkg/service"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/huh"
+	"github.com/google/uuid"
+)
+
+const (
+	// defaultBreakDays is the proposed length of a tolerance break.
+	defaultBreakDays = 14
+	// defaultTaperDays is the proposed length of the tapering period.
+	defaultTaperDays = 3
+	// progressBarWidth is the number of characters of the progress bar.
+	progressBarWidth = 20
+)
+
+type toleranceTrackedMsg struct {
+	tolerance service.Tolerance
+	progress  *service.BreakProgress
+}
+
+type breakPlannedMsg struct {
+	tbreak *can.ToleranceBreak
+}
+
+type checkInSubmittedMsg struct {
+	id      uuid.UUID
+	checkIn *can.CheckIn
+}
+
+// onBreakPlanned runs the form to plan a tolerance break, proposing the given
+// estimated tolerance as the baseline of the tapering, and on submission sends
+// a message with the parsed break.
+func onBreakPlanned(tolerance service.Tolerance) tea.Cmd {
+	form := initialBreakForm(tolerance)
+
+	if err := form.Run(); err != nil {
+		fmt.Fprint(os.Stderr, i18n.T("Error running T-break form: %v\n", err))
+		return nil
+	}
+
+	tbreak := parseBreak(form)
+	return func() tea.Msg { return breakPlannedMsg{tbreak} }
+}
+
+// initialBreakForm returns a form for planning a tolerance break.
+func initialBreakForm(tolerance service.Tolerance) *huh.Form {
+	return huh.NewForm(
+		huh.NewGroup(
+			huh.NewNote().
+				Title(i18n.T("Plan a T-break")).
+				Description(toleranceText(tolerance)),
+
+			huh.NewInput().
+				Key("starts").
+				Title(i18n.T("Start date")).
+				Description(i18n.T("The first day without consumption (YYYY-MM-DD)")).
+				Value(ptr(time.Now().AddDate(0, 0, defaultTaperDays+1).Format(dateLayout))).
+				Validate(validateRequiredDate),
+
+			huh.NewInput().
+				Key("days").
+				Title(i18n.T("Length (days)")).
+				Description(i18n.T("The target length of the break")).
+				Value(ptr(strconv.Itoa(defaultBreakDays))).
+				Validate(validatePositiveInt),
+
+			huh.NewInput().
+				Key("taper").
+				Title(i18n.T("Tapering (days)")).
+				Description(i18n.T("The days before the break to reduce the consumption, 0 to stop at once")).
+				Value(ptr(strconv.Itoa(defaultTaperDays))).
+				Validate(validateWholeNumber),
+
+			huh.NewInput().
+				Key("baseline").
+				Title(i18n.T("Baseline (mg THC per day)")).
+				Description(i18n.T("The absorbed THC per day the tapering starts from")).
+				Value(ptr(strconv.FormatFloat(tolerance.DailyTHC, 'f', 1, 64))).
+				Validate(validateFloat),
+		),
+	).WithTheme(formTheme())
+}
+
+// validateWholeNumber returns an error if the given input is no whole number
+// of zero or more.
+func validateWholeNumber(input string) error {
+	if n, err := strconv.Atoi(strings.TrimSpace(input)); err != nil || n < 0 {
+		return errors.New(i18n.T("Please enter a whole number"))
+	}
+	return nil
+}
+
+// parseBreak creates a new tolerance break entity from the given form data.
+func parseBreak(form *huh.Form) *can.ToleranceBreak {
+	days, _ := strconv.Atoi(strings.TrimSpace(form.GetString("days")))
+	taper, _ := strconv.Atoi(strings.TrimSpace(form.GetString("taper")))
+	return &can.ToleranceBreak{
+		ID:          uuid.New(),
+		StartsAt:    parseDate(form.GetString("starts")),
+		Days:        days,
+		TaperDays:   taper,
+		BaselineTHC: parseFloatWithDefault(form.GetString("baseline"), 0),
+		CreatedAt:   time.Now(),
+	}
+}
+
+// onCheckedIn runs the form for the daily check-in of the given tolerance
+// break and on submission sends a message with the parsed check-in.
+func onCheckedIn(tbreak *can.ToleranceBreak) tea.Cmd {
+	form := initialCheckInForm(tbreak)
+
+	if err := form.Run(); err != nil {
+		fmt.Fprint(os.Stderr, i18n.T("Error running check-in form: %v\n", err))
+		return nil
+	}
+
+	checkIn := parseCheckIn(form)
+	id := tbreak.ID
+	return func() tea.Msg { return checkInSubmittedMsg{id: id, checkIn: checkIn} }
+}
+
+// initialCheckInForm returns a form for the daily check-in of the given
+// tolerance break, prefilled with the check-in of today if there is one.
+func initialCheckInForm(tbreak *can.ToleranceBreak) *huh.Form {
+	abstained := true
+	craving := ""
+	notes := ""
+	if c := tbreak.CheckInOn(time.Now()); c != nil {
+		abstained = c.Abstained
+		craving = strconv.Itoa(c.Craving)
+		notes = c.Notes
+	}
+	return huh.NewForm(
+		huh.NewGroup(
+			huh.NewNote().
+				Title(i18n.T("Check-in of %s", time.Now().Format(dateLayout))),
+
+			huh.NewConfirm().
+				Key("abstained").
+				Title(i18n.T("Did you abstain today?")).
+				Affirmative(i18n.T("Yes")).
+				Negative(i18n.T("No")).
+				Value(&abstained),
+
+			huh.NewInput().
+				Key("craving").
+				Title(i18n.T("Craving")).
+				Description(i18n.T("Rate the craving from 0 (none) to %d (strong)", can.MaxSymptomScore)).
+				Value(&craving).
+				Validate(validateScore),
+
+			huh.NewText().
+				Key("notes").
+				Title(i18n.T("Notes")).
+				Description(i18n.T("How the day went")).
+				Value(&notes),
+		),
+	).WithTheme(formTheme())
+}
+
+// parseCheckIn creates a new check-in of today from the given form data.
+func parseCheckIn(form *huh.Form) *can.CheckIn {
+	c := &can.CheckIn{
+		Day:       time.Now(),
+		Abstained: form.GetBool("abstained"),
+		Notes:     strings.TrimSpace(form.GetString("notes")),
+	}
+	c.Craving, _ = parseScore(form.GetString("craving"))
+	return c
+}
+
+// breakNote returns the note of the session form about the given tolerance
+// break at the date entered into the given input, or nil if there is no
+// break.
+func breakNote(tbreak *can.ToleranceBreak, started *string) *huh.Note {
+	if tbreak == nil {
+		return nil
+	}
+	return huh.NewNote().
+		Title(i18n.T("T-break")).
+		DescriptionFunc(func() string {
+			at := parseDate(*started)
+			if at.IsZero() {
+				at = time.Now()
+			}
+			return breakText(tbreak, at)
+		}, started)
+}
+
+// breakText returns the translated description of the given tolerance break
+// at the given time: a warning during the break, the allowed THC during the
+// tapering and the start date otherwise.
+func breakText(tbreak *can.ToleranceBreak, at time.Time) string {
+	if tbreak.Active(at) {
+		return "⚠️ " + i18n.T("This is day %d of %d of your T-break.", tbreak.Day(at), tbreak.Days)
+	}
+	if limit, ok := tbreak.TaperLimit(at); ok {
+		return i18n.T("Tapering before your T-break: at most %s mg absorbed THC today.", i18n.FormatFloat(limit, 1))
+	}
+	if at.Before(tbreak.TaperStartsAt()) {
+		return i18n.T("Your T-break starts on %s.", tbreak.StartsAt.Format(dateLayout))
+	}
+	return i18n.T("Your T-break ended on %s.", tbreak.EndsAt().AddDate(0, 0, -1).Format(dateLayout))
+}
+
+// breakAlerts returns the warnings about the given sessions logged during the
+// given tolerance break up to the given time, or none if there is no break.
+func breakAlerts(tbreak *can.ToleranceBreak, sessions []*can.Session, at time.Time) []alert {
+	if tbreak == nil || !tbreak.Active(at) {
+		return nil
+	}
+	p := service.TrackBreak(tbreak, sessions, at)
+	if p.Slips == 0 {
+		return nil
+	}
+	return []alert{{warningAlert, i18n.T("You logged sessions on %d days of your T-break.", p.Slips)}}
+}
+
+// toleranceText returns the translated description of the given estimated
+// tolerance.
+func toleranceText(tolerance service.Tolerance) string {
+	if tolerance.Level == service.NoTolerance {
+		return i18n.T("No consumption logged, no tolerance estimated.")
+	}
+	return i18n.T("Estimated tolerance: %s, %s mg absorbed THC per day",
+		i18n.Term(tolerance.Level.String()), i18n.FormatFloat(tolerance.DailyTHC, 1))
+}
+
+// progressBar returns the given progress from 0 to 1 as a bar of characters.
+func progressBar(progress float64) string {
+	filled := int(progress*progressBarWidth + 0.5)
+	filled = min(max(filled, 0), progressBarWidth)
+	return "[" + strings.Repeat("█", filled) + strings.Repeat("░", progressBarWidth-filled) + "]"
+}
+
+// ToleranceModel is a tea.Model rendering the estimated tolerance and the
+// progress of the current tolerance break.
+type ToleranceModel struct {
+	styles    *Styles
+	tolerance service.Tolerance
+	progress  *service.BreakProgress
+}
+
+// ToleranceModel implementation of tea.Model interface ------------------------
+
+// Init is the first function that will be called. It returns an optional
+// initial command. To not perform an initial command return nil.
+func (tm *ToleranceModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update is called when a message is received. Use it to inspect messages
+// and, in response, update the model and/or send a command.
+func (tm *ToleranceModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	return tm, nil
+}
+
+// View renders the ToleranceModel UI, which is just a string. The view is
+// rendered after every Update.
+func (tm *ToleranceModel) View() string {
+	s := tm.styles
+	var b strings.Builder
+	b.WriteString(s.StatusHeader.Render(i18n.T("Tolerance & T-Break")) + "\n")
+	b.WriteString(toleranceText(tm.tolerance) + "\n")
+	p := tm.progress
+	if p == nil {
+		b.WriteString("\n" + s.Help.Render(i18n.T("No T-break planned, press %s to plan one.", keys.PlanBreak.Help().Key)))
+		return s.Status.Render(b.String())
+	}
+	tb := p.Break
+	b.WriteString("\n" + s.Highlight.Render(i18n.T("T-break from %s to %s",
+		tb.StartsAt.Format(dateLayout), tb.EndsAt().AddDate(0, 0, -1).Format(dateLayout))) + "\n")
+	switch p.Phase {
+	case service.BreakPlanned:
+		b.WriteString(i18n.T("Planned, tapering starts on %s", tb.TaperStartsAt().Format(dateLayout)) + "\n")
+	case service.BreakTapering:
+		limit, _ := tb.TaperLimit(time.Now())
+		b.WriteString(i18n.T("Tapering: at most %s mg absorbed THC today", i18n.FormatFloat(limit, 1)) + "\n")
+	case service.BreakActive:
+		b.WriteString(i18n.T("Day %d of %d, %d days left", p.Day, tb.Days, p.DaysLeft) + "\n")
+	case service.BreakFinished:
+		b.WriteString(i18n.T("Finished") + "\n")
+	}
+	b.WriteString(progressBar(p.Progress) + " " + i18n.FormatFloat(p.Progress*100, 0) + " %\n")
+	b.WriteString(i18n.T("Streak: %d days, longest: %d days, slips: %d", p.Streak, p.LongestStreak, p.Slips))
+	if p.Phase == service.BreakActive && !p.CheckedIn {
+		b.WriteString("\n" + s.Help.Render(i18n.T("Not checked in today, press %s to check in.", keys.CheckIn.Help().Key)))
+	}
+	return s.Status.Render(b.String())
+}
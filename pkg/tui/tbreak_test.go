@@ -0,0 +1,119 @@
+package tui
+
+import (
+	"testing"
+	"time"
+
+	can "github.com/TheDonDope/wits-tui/pkg/cannabis"
+	"github.com/TheDonDope/wits-tui/pkg/service"
+	"github.com/TheDonDope/wits-tui/pkg/storage"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testBreak returns a 14 day break starting on 2024-03-10 with 3 tapering
+// days from a baseline of 40 mg THC per day.
+func testBreak() *can.ToleranceBreak {
+	return &can.ToleranceBreak{
+		ID:          uuid.New(),
+		StartsAt:    time.Date(2024, time.March, 10, 0, 0, 0, 0, time.UTC),
+		Days:        14,
+		TaperDays:   3,
+		BaselineTHC: 40,
+	}
+}
+
+func TestBreakText(t *testing.T) {
+	b := testBreak()
+
+	assert.Equal(t, "Your T-break starts on 2024-03-10.", breakText(b, time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, "Tapering before your T-break: at most 20.0 mg absorbed THC today.",
+		breakText(b, time.Date(2024, time.March, 8, 12, 0, 0, 0, time.UTC)))
+	assert.Equal(t, "⚠️ This is day 3 of 14 of your T-break.", breakText(b, time.Date(2024, time.March, 12, 12, 0, 0, 0, time.UTC)))
+	assert.Equal(t, "Your T-break ended on 2024-03-23.", breakText(b, b.EndsAt()))
+	assert.Nil(t, breakNote(nil, ptr("")))
+}
+
+func TestBreakAlerts(t *testing.T) {
+	b := testBreak()
+	at := time.Date(2024, time.March, 12, 12, 0, 0, 0, time.UTC)
+	sessions := []*can.Session{{StartedAt: time.Date(2024, time.March, 11, 20, 0, 0, 0, time.UTC)}}
+
+	alerts := breakAlerts(b, sessions, at)
+
+	require.Len(t, alerts, 1)
+	assert.Equal(t, "You logged sessions on 1 days of your T-break.", alerts[0].text)
+	assert.Empty(t, breakAlerts(b, nil, at))
+	assert.Empty(t, breakAlerts(b, sessions, b.EndsAt()), "only during the break")
+	assert.Empty(t, breakAlerts(nil, sessions, at))
+}
+
+func TestValidateWholeNumber(t *testing.T) {
+	assert.NoError(t, validateWholeNumber("0"))
+	assert.NoError(t, validateWholeNumber(" 3 "))
+	assert.Error(t, validateWholeNumber("-1"))
+	assert.Error(t, validateWholeNumber("x"))
+}
+
+func TestProgressBar(t *testing.T) {
+	assert.Equal(t, "[░░░░░░░░░░░░░░░░░░░░]", progressBar(0))
+	assert.Equal(t, "[██████████░░░░░░░░░░]", progressBar(0.5))
+	assert.Equal(t, "[████████████████████]", progressBar(1.2))
+}
+
+func TestToleranceModel_View(t *testing.T) {
+	styles := NewStyles(lipgloss.DefaultRenderer())
+
+	t.Run("Empty", func(t *testing.T) {
+		tm := &ToleranceModel{styles: styles}
+		view := tm.View()
+		assert.Contains(t, view, "No consumption logged, no tolerance estimated.")
+		assert.Contains(t, view, "No T-break planned, press alt+p to plan one.")
+	})
+
+	t.Run("Active", func(t *testing.T) {
+		b := testBreak()
+		tm := &ToleranceModel{
+			styles:    styles,
+			tolerance: service.Tolerance{DailyTHC: 12.5, Level: service.ModerateTolerance, Days: 30},
+			progress: &service.BreakProgress{Break: b, Phase: service.BreakActive, Day: 4, DaysLeft: 11,
+				Progress: 3.0 / 14, Streak: 3, LongestStreak: 3},
+		}
+
+		view := tm.View()
+
+		assert.Contains(t, view, "Estimated tolerance: moderate, 12.5 mg absorbed THC per day")
+		assert.Contains(t, view, "T-break from 2024-03-10 to 2024-03-23")
+		assert.Contains(t, view, "Day 4 of 14, 11 days left")
+		assert.Contains(t, view, "Streak: 3 days, longest: 3 days, slips: 0")
+		assert.Contains(t, view, "Not checked in today, press alt+k to check in.")
+	})
+}
+
+func TestStatisticsHomeModel_Tolerance(t *testing.T) {
+	t.Setenv("STORAGE_MODE", storage.StoreInMemory)
+	shm := initialStatisticsHomeModel()
+	b := testBreak()
+	b.StartsAt = time.Now().AddDate(0, 0, -2)
+
+	_, cmd := shm.Update(breakPlannedMsg{b})
+	require.NotNil(t, cmd)
+	require.Len(t, shm.breaks.GetBreaks(), 1)
+
+	_, cmd = shm.Update(checkInSubmittedMsg{id: b.ID, checkIn: &can.CheckIn{Day: time.Now(), Abstained: true, Craving: 4}})
+	require.NotNil(t, cmd)
+	msg := cmd()
+	require.IsType(t, toleranceTrackedMsg{}, msg)
+	shm.Update(msg)
+
+	view := shm.View()
+	assert.Contains(t, view, "Day 3 of 14, 12 days left")
+	assert.Contains(t, view, "Streak: 3 days")
+
+	_, cmd = shm.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'o'}, Alt: true})
+	require.NotNil(t, cmd)
+	assert.IsType(t, toleranceTrackedMsg{}, cmd())
+}
@@ -0,0 +1,206 @@
+package tui
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/huh"
+	"github.com/charmbracelet/lipgloss"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	themesDir = "themes"
+
+	defaultThemeName      = "default"
+	highContrastThemeName = "high-contrast"
+	monochromeThemeName   = "monochrome"
+	solarizedThemeName    = "solarized"
+)
+
+var (
+	red    = lipgloss.AdaptiveColor{Light: "#FE5F86", Dark: "#FE5F86"}
+	indigo = lipgloss.AdaptiveColor{Light: "#5A56E0", Dark: "#7571F9"}
+	green  = lipgloss.AdaptiveColor{Light: "#02BA84", Dark: "#02BF87"}
+)
+
+// Theme is a color palette used to build the Styles. Colors left empty are not
+// rendered at all.
+type Theme struct {
+	Name       string                 `yaml:"name"`
+	Primary    lipgloss.AdaptiveColor `yaml:"primary"`    // Headers and borders
+	Success    lipgloss.AdaptiveColor `yaml:"success"`    // Status headers
+	Error      lipgloss.AdaptiveColor `yaml:"error"`      // Error headers
+	Highlight  lipgloss.AdaptiveColor `yaml:"highlight"`  // Highlighted text
+	Muted      lipgloss.AdaptiveColor `yaml:"muted"`      // Help and unselected items
+	Text       lipgloss.AdaptiveColor `yaml:"text"`       // Text on the banner and surface
+	Banner     lipgloss.AdaptiveColor `yaml:"banner"`     // Background of the main menu header
+	Surface    lipgloss.AdaptiveColor `yaml:"surface"`    // Background of selected items
+	Background lipgloss.AdaptiveColor `yaml:"background"` // Background of unselected items
+}
+
+// theme is the active Theme, which can be replaced by Configure.
+var theme = builtinThemes[defaultThemeName]
+
+// builtinThemes contains all themes shipped with wits, keyed by their name.
+var builtinThemes = map[string]Theme{
+	defaultThemeName: {
+		Name:       defaultThemeName,
+		Primary:    indigo,
+		Success:    green,
+		Error:      red,
+		Highlight:  ansiColor("212"),
+		Muted:      ansiColor("240"),
+		Text:       ansiColor("230"),
+		Banner:     ansiColor("#4CAF50"),
+		Surface:    ansiColor("236"),
+		Background: ansiColor("0")},
+	highContrastThemeName: {
+		Name:       highContrastThemeName,
+		Primary:    lipgloss.AdaptiveColor{Light: "#0000FF", Dark: "#FFFF00"},
+		Success:    lipgloss.AdaptiveColor{Light: "#006400", Dark: "#00FF00"},
+		Error:      lipgloss.AdaptiveColor{Light: "#B00000", Dark: "#FF0000"},
+		Highlight:  lipgloss.AdaptiveColor{Light: "#000000", Dark: "#FFFFFF"},
+		Muted:      lipgloss.AdaptiveColor{Light: "#000000", Dark: "#FFFFFF"},
+		Text:       lipgloss.AdaptiveColor{Light: "#FFFFFF", Dark: "#000000"},
+		Banner:     lipgloss.AdaptiveColor{Light: "#000000", Dark: "#FFFFFF"},
+		Surface:    lipgloss.AdaptiveColor{Light: "#0000FF", Dark: "#FFFF00"},
+		Background: lipgloss.AdaptiveColor{Light: "#FFFFFF", Dark: "#000000"}},
+	monochromeThemeName: {
+		Name: monochromeThemeName},
+	solarizedThemeName: {
+		Name:       solarizedThemeName,
+		Primary:    ansiColor("#268BD2"),
+		Success:    ansiColor("#859900"),
+		Error:      ansiColor("#DC322F"),
+		Highlight:  ansiColor("#D33682"),
+		Muted:      lipgloss.AdaptiveColor{Light: "#93A1A1", Dark: "#586E75"},
+		Text:       lipgloss.AdaptiveColor{Light: "#073642", Dark: "#EEE8D5"},
+		Banner:     ansiColor("#2AA198"),
+		Surface:    lipgloss.AdaptiveColor{Light: "#EEE8D5", Dark: "#073642"},
+		Background: lipgloss.AdaptiveColor{Light: "#FDF6E3", Dark: "#002B36"}},
+}
+
+// ansiColor returns an adaptive color using the same color for both light and
+// dark backgrounds.
+func ansiColor(c string) lipgloss.AdaptiveColor {
+	return lipgloss.AdaptiveColor{Light: c, Dark: c}
+}
+
+// loadThemes returns all built-in themes merged with the custom themes from
+// the themes folder in the WITS_DIR. Custom themes are named after their file
+// unless they declare a name themselves.
+func loadThemes() (map[string]Theme, error) {
+	themes := make(map[string]Theme, len(builtinThemes))
+	for name, t := range builtinThemes {
+		themes[name] = t
+	}
+	files, err := filepath.Glob(filepath.Join(os.Getenv("WITS_DIR"), themesDir, "*.yml"))
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range files {
+		data, err := os.ReadFile(f)
+		if err != nil {
+			return nil, err
+		}
+		var t Theme
+		if err := yaml.Unmarshal(data, &t); err != nil {
+			return nil, fmt.Errorf("invalid theme %s: %w", f, err)
+		}
+		if t.Name == "" {
+			t.Name = strings.TrimSuffix(filepath.Base(f), filepath.Ext(f))
+		}
+		log.Printf("💬 💾  (pkg/tui/theme.go) loadThemes() -> custom theme: %v \n", t.Name)
+		themes[t.Name] = t
+	}
+	return themes, nil
+}
+
+// themeNames returns the sorted names of the given themes.
+func themeNames(themes map[string]Theme) []string {
+	var names []string
+	for name := range themes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// resolveTheme returns the theme to use for the given name. The monochrome
+// theme is always used if the NO_COLOR environment variable is set.
+func resolveTheme(name string) (Theme, error) {
+	if os.Getenv("NO_COLOR") != "" {
+		return builtinThemes[monochromeThemeName], nil
+	}
+	if name == "" {
+		return builtinThemes[defaultThemeName], nil
+	}
+	themes, err := loadThemes()
+	if err != nil {
+		return Theme{}, err
+	}
+	t, ok := themes[name]
+	if !ok {
+		return Theme{}, fmt.Errorf("unknown theme %q", name)
+	}
+	return t, nil
+}
+
+// formTheme returns the huh form theme built from the colors of the active
+// theme. Styles whose color is left empty keep the look of huh's base theme.
+func formTheme() *huh.Theme {
+	t := huh.ThemeBase()
+	fg := func(s lipgloss.Style, c lipgloss.AdaptiveColor) lipgloss.Style {
+		if c == (lipgloss.AdaptiveColor{}) {
+			return s
+		}
+		return s.Foreground(c)
+	}
+	bg := func(s lipgloss.Style, c lipgloss.AdaptiveColor) lipgloss.Style {
+		if c == (lipgloss.AdaptiveColor{}) {
+			return s
+		}
+		return s.Background(c)
+	}
+
+	f := &t.Focused
+	if theme.Primary != (lipgloss.AdaptiveColor{}) {
+		f.Base = f.Base.BorderForeground(theme.Primary)
+	}
+	f.Card = f.Base
+	f.Title = fg(f.Title, theme.Primary).Bold(true)
+	f.NoteTitle = fg(f.NoteTitle, theme.Primary).Bold(true).MarginBottom(1)
+	f.Directory = fg(f.Directory, theme.Primary)
+	f.Description = fg(f.Description, theme.Muted)
+	f.ErrorIndicator = fg(f.ErrorIndicator, theme.Error)
+	f.ErrorMessage = fg(f.ErrorMessage, theme.Error)
+	f.SelectSelector = fg(f.SelectSelector, theme.Highlight)
+	f.NextIndicator = fg(f.NextIndicator, theme.Highlight)
+	f.PrevIndicator = fg(f.PrevIndicator, theme.Highlight)
+	f.MultiSelectSelector = fg(f.MultiSelectSelector, theme.Highlight)
+	f.SelectedOption = fg(f.SelectedOption, theme.Success)
+	f.SelectedPrefix = fg(f.SelectedPrefix, theme.Success)
+	f.UnselectedPrefix = fg(f.UnselectedPrefix, theme.Muted)
+	f.FocusedButton = bg(fg(f.FocusedButton, theme.Text), theme.Highlight)
+	f.Next = f.FocusedButton
+	f.BlurredButton = bg(fg(f.BlurredButton, theme.Text), theme.Surface)
+	f.TextInput.Cursor = fg(f.TextInput.Cursor, theme.Success)
+	f.TextInput.Placeholder = fg(f.TextInput.Placeholder, theme.Muted)
+	f.TextInput.Prompt = fg(f.TextInput.Prompt, theme.Highlight)
+
+	t.Blurred = t.Focused
+	t.Blurred.Base = t.Blurred.Base.BorderStyle(lipgloss.HiddenBorder())
+	t.Blurred.Card = t.Blurred.Base
+	t.Blurred.MultiSelectSelector = lipgloss.NewStyle().SetString("  ")
+	t.Blurred.NextIndicator = lipgloss.NewStyle()
+	t.Blurred.PrevIndicator = lipgloss.NewStyle()
+
+	t.Group.Title = t.Focused.Title
+	t.Group.Description = t.Focused.Description
+	return t
+}
@@ -0,0 +1,94 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/TheDonDope/wits-tui/pkg/settings"
+	"github.com/charmbracelet/huh"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveTheme(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		th, err := resolveTheme("")
+		require.NoError(t, err)
+		assert.Equal(t, defaultThemeName, th.Name)
+	})
+
+	t.Run("BuiltIn", func(t *testing.T) {
+		for _, name := range []string{highContrastThemeName, monochromeThemeName, solarizedThemeName} {
+			t.Run(name, func(t *testing.T) {
+				th, err := resolveTheme(name)
+				require.NoError(t, err)
+				assert.Equal(t, builtinThemes[name], th)
+			})
+		}
+	})
+
+	t.Run("Unknown", func(t *testing.T) {
+		t.Setenv("WITS_DIR", t.TempDir())
+		_, err := resolveTheme("neon")
+		assert.Error(t, err)
+	})
+
+	t.Run("NoColor", func(t *testing.T) {
+		t.Setenv("NO_COLOR", "1")
+		th, err := resolveTheme(solarizedThemeName)
+		require.NoError(t, err)
+		assert.Equal(t, monochromeThemeName, th.Name)
+	})
+
+	t.Run("Custom", func(t *testing.T) {
+		tempDir := t.TempDir()
+		t.Setenv("WITS_DIR", tempDir)
+		require.NoError(t, os.MkdirAll(filepath.Join(tempDir, themesDir), 0755))
+		data := []byte("primary:\n  light: \"#112233\"\n  dark: \"#445566\"\n")
+		require.NoError(t, os.WriteFile(filepath.Join(tempDir, themesDir, "forest.yml"), data, 0644))
+
+		th, err := resolveTheme("forest")
+
+		require.NoError(t, err)
+		assert.Equal(t, "forest", th.Name)
+		assert.Equal(t, lipgloss.AdaptiveColor{Light: "#112233", Dark: "#445566"}, th.Primary)
+	})
+}
+
+func TestConfigureTheme(t *testing.T) {
+	t.Cleanup(func() { theme = builtinThemes[defaultThemeName] })
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LANG", "C")
+	s := settings.Default()
+	s.Appearance.Theme = solarizedThemeName
+
+	require.NoError(t, Configure(s))
+	styles := NewStyles(lipgloss.DefaultRenderer())
+
+	assert.Equal(t, builtinThemes[solarizedThemeName].Primary, styles.HeaderText.GetForeground())
+}
+
+func TestFormTheme(t *testing.T) {
+	t.Cleanup(func() { theme = builtinThemes[defaultThemeName] })
+
+	t.Run("Custom", func(t *testing.T) {
+		theme = Theme{Name: "forest", Primary: ansiColor("#2E7D32"), Error: ansiColor("#C62828"), Highlight: ansiColor("#FBC02D")}
+		ft := formTheme()
+
+		assert.Equal(t, theme.Primary, ft.Focused.Title.GetForeground())
+		assert.Equal(t, theme.Primary, ft.Group.Title.GetForeground())
+		assert.Equal(t, theme.Error, ft.Focused.ErrorMessage.GetForeground())
+		assert.Equal(t, theme.Highlight, ft.Focused.FocusedButton.GetBackground())
+		assert.Equal(t, theme.Highlight, ft.Blurred.SelectSelector.GetForeground())
+	})
+
+	t.Run("Monochrome", func(t *testing.T) {
+		theme = builtinThemes[monochromeThemeName]
+		ft := formTheme()
+
+		assert.Equal(t, lipgloss.NoColor{}, ft.Focused.Title.GetForeground())
+		assert.Equal(t, huh.ThemeBase().Focused.FocusedButton.GetBackground(), ft.Focused.FocusedButton.GetBackground())
+	})
+}